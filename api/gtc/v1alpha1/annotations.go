@@ -0,0 +1,16 @@
+package v1alpha1
+
+const (
+	// AnnotationAllowedClientNamespaces lists, comma separated, the namespaces of the clients allowed to read a GRPCListener
+	// when gTC enforces authorization. "*" allows clients from any namespace.
+	// Clients are always allowed to read the GRPCListeners of their own namespace.
+	AnnotationAllowedClientNamespaces = "gtc.dev/allowed-client-namespaces"
+
+	// AnnotationHostname sets, on a GRPCListener, a cluster-wide unique short name clients of any namespace
+	// can dial with `xds:///<hostname>`.
+	AnnotationHostname = "gtc.dev/hostname"
+
+	// AnnotationWeight sets, on a Pod, the load balancing weight of its endpoints. It must be a positive integer.
+	// Endpoints of pods without a valid weight get the default weight of 1.
+	AnnotationWeight = "gtc.dev/weight"
+)
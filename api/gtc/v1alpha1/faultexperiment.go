@@ -0,0 +1,72 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+const (
+	FaultExperimentPhasePending   = "Pending"
+	FaultExperimentPhaseRunning   = "Running"
+	FaultExperimentPhaseCompleted = "Completed"
+	FaultExperimentPhaseInvalid   = "Invalid"
+)
+
+// FaultExperiment injects a fault in the calls of a GRPCListener of its namespace for a limited time,
+// without editing the GRPCListener itself. Once expired, the fault is removed.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Listener",type=string,JSONPath=`.spec.target.listener`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type FaultExperiment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FaultExperimentSpec   `json:"spec,omitempty"`
+	Status FaultExperimentStatus `json:"status,omitempty"`
+}
+
+// FaultExperimentSpec defines which calls are faulted, how and when.
+type FaultExperimentSpec struct {
+	// Target is the listener, route or backend the fault is injected in.
+	Target FaultExperimentTarget `json:"target"`
+	// StartTime is the time the experiment starts at, its creation time if not set.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// Duration of the experiment.
+	Duration metav1.Duration `json:"duration"`
+	// Fault to inject while the experiment runs. It replaces the fault interceptor of the target.
+	Fault FaultInterceptor `json:"fault"`
+}
+
+// FaultExperimentTarget is a listener, a route of a listener or a backend of a route.
+type FaultExperimentTarget struct {
+	// Listener is the name of the GRPCListener, in the namespace of the experiment.
+	Listener string `json:"listener"`
+	// Route is the index of the route among the routes of the listener, followed by the routes of its virtual hosts.
+	// The fault applies to the whole listener if not set.
+	// +optional
+	Route *int `json:"route,omitempty"`
+	// Backend is the index of the backend among the backends of the route. It requires Route.
+	// +optional
+	Backend *int `json:"backend,omitempty"`
+}
+
+// FaultExperimentStatus is the observed state of a FaultExperiment.
+type FaultExperimentStatus struct {
+	// Phase of the experiment, Pending until it starts, Running until it expires, then Completed.
+	// It is Invalid while its fault can't be applied to its target, the target is then left untouched.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+	// Message tells why the experiment is Invalid.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// FaultExperimentList contains a list of FaultExperiment
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type FaultExperimentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FaultExperiment `json:"items"`
+}
@@ -6,6 +6,10 @@ type Interceptor struct {
 	// Fault Interceptor configuration.
 	// +optional
 	Fault *FaultInterceptor `json:"fault,omitempty"`
+	// NodeSelector only applies this interceptor to the clients it selects.
+	// A listener interceptor not applying to a client is disabled, so that route and backend overrides still apply.
+	// +optional
+	NodeSelector *NodeSelector `json:"nodeSelector,omitempty"`
 }
 
 type FaultInterceptor struct {
@@ -15,7 +19,8 @@ type FaultInterceptor struct {
 	Abort *FaultAbort `json:"abort,omitempty"`
 	// The maximum number of faults that can be active at a single time.
 	MaxActiveFaults *uint32 `json:"maxActiveFaults,omitempty"`
-	// Specifies a set of headers that the filter should match on.
+	// Only inject faults in calls whose metadata match all of these headers.
+	// Supported on listener and route interceptors only, and not on routes matching a fraction of the calls.
 	Headers []HeaderMatcher `json:"headers,omitempty"`
 }
 
@@ -25,9 +30,18 @@ type FaultAbort struct {
 	// Metadata adds a fault controlled by an call metadata.
 	Metadata *MetadataFault `json:"metadata,omitempty"`
 	// Percentage controls how much this fault will occur.
+	// Defaults to 100% for faults controlled by metadata.
 	Percentage *Fraction `json:"percentage,omitempty"`
 }
 
+// MetadataFault makes a fault controlled by the metadata of each call:
+//   - x-envoy-fault-delay-request: delay to inject, in milliseconds.
+//   - x-envoy-fault-abort-grpc-request: gRPC status code to return.
+//   - x-envoy-fault-abort-request: HTTP status code to return, converted to a gRPC status code. Takes precedence over the gRPC status code.
+//   - x-envoy-fault-delay-request-percentage and x-envoy-fault-abort-request-percentage: numerator of the fault percentage,
+//     over the denominator of the fault percentage. Can only lower the percentage of the fault.
+//
+// Calls without a delay or abort metadata are left untouched.
 type MetadataFault struct{}
 
 type FaultDelay struct {
@@ -36,5 +50,6 @@ type FaultDelay struct {
 	// Metadata adds a fault controlled by an call metadata.
 	Metadata *MetadataFault `json:"metadata,omitempty"`
 	// Percentage controls how much this fault will occur.
+	// Defaults to 100% for faults controlled by metadata.
 	Percentage *Fraction `json:"percentage,omitempty"`
 }
@@ -9,6 +9,14 @@ type RegexMatcher struct {
 	Engine string `json:"engine,omitempty"`
 }
 
+// RegexRewrite rewrites the portions of a value matching a regex.
+type RegexRewrite struct {
+	// Pattern matched against the value.
+	Pattern RegexMatcher `json:"pattern"`
+	// Substitution of the matched portions of the value, capture groups can be referenced with \1, \2...
+	Substitution string `json:"substitution"`
+}
+
 type RangeMatcher struct {
 	// Start of the range (inclusive)
 	Start int64 `json:"start,omitempty"`
@@ -16,18 +24,25 @@ type RangeMatcher struct {
 	End int64 `json:"end,omitempty"`
 }
 
-// PathMatcher indicates a match based on the path of a gRPC call.
+// PathMatcher indicates a match based on the path of a gRPC call, formatted as /<package>.<service>/<method>.
+// Exactly one of prefix, path or regex must be set.
 type PathMatcher struct {
 	// Path Must match the prefix of the request.
 	// +optional
-	// +kubebuilder:default:=/
+	// +kubebuilder:validation:Pattern=`^/`
 	Prefix string `json:"prefix,omitempty"`
 	// Path Must match exactly.
 	// +optional
+	// +kubebuilder:validation:Pattern=`^/`
 	Path string `json:"path,omitempty"`
 	// Path Must Match a Regex.
 	// +optional
 	Regex *RegexMatcher `json:"regex,omitempty"`
+	// CaseSensitive tells if the prefix and the path are matched case sensitively.
+	// It doesn't apply to regexes, which can use the (?i) flag instead.
+	// +optional
+	// +kubebuilder:default:=true
+	CaseSensitive *bool `json:"caseSensitive,omitempty"`
 }
 
 // HeaderMatcher indicates a match based on an http header.
@@ -0,0 +1,60 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+const (
+	ReferenceKindService       = "Service"
+	ReferenceKindPod           = "Pod"
+	ReferenceKindServiceImport = "ServiceImport"
+)
+
+// ReferenceGrant allows GRPCListeners of other namespaces to reference Services, Pods or ServiceImports of its own namespace.
+// Backends referencing a resource of another namespace without a matching ReferenceGrant resolve no endpoints,
+// and the ResolvedRefs condition of their GRPCListener is set to false with the RefNotPermitted reason.
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+type ReferenceGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ReferenceGrantSpec `json:"spec,omitempty"`
+}
+
+// ReferenceGrantSpec defines who can reference which Services.
+type ReferenceGrantSpec struct {
+	// From lists the namespaces of the GRPCListeners allowed to reference resources of this namespace.
+	// +kubebuilder:validation:MinItems:=1
+	From []ReferenceGrantFrom `json:"from"`
+	// To lists the resources that can be referenced.
+	// +kubebuilder:validation:MinItems:=1
+	To []ReferenceGrantTo `json:"to"`
+}
+
+// ReferenceGrantFrom is a namespace allowed to reference Services.
+type ReferenceGrantFrom struct {
+	// Namespace of the GRPCListeners.
+	Namespace string `json:"namespace"`
+}
+
+// ReferenceGrantTo is a resource allowed to be referenced.
+type ReferenceGrantTo struct {
+	// Kind of the resource, either Service, Pod or ServiceImport.
+	// +optional
+	// +kubebuilder:validation:Enum:=Service;Pod;ServiceImport
+	// +kubebuilder:default:=Service
+	Kind string `json:"kind,omitempty"`
+	// Name of the resource. If not set, all resources of this kind in the namespace can be referenced.
+	// Pods are always referenced by label selector, hence this must be left empty for the Pod kind.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// ReferenceGrantList contains a list of ReferenceGrant
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ReferenceGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ReferenceGrant `json:"items"`
+}
@@ -35,6 +35,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&GRPCListener{},
 		&GRPCListenerList{},
+		&ReferenceGrant{},
+		&ReferenceGrantList{},
+		&FaultExperiment{},
+		&FaultExperimentList{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
@@ -24,7 +24,6 @@ import (
 
 // GRPCListener is the Schema for the services API
 // +genclient
-// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
@@ -32,7 +31,8 @@ type GRPCListener struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec GRPCListenerSpec `json:"spec,omitempty"`
+	Spec   GRPCListenerSpec   `json:"spec,omitempty"`
+	Status GRPCListenerStatus `json:"status,omitempty"`
 }
 
 // GRPCListenerSpec defines the desired state of Service
@@ -48,19 +48,56 @@ type GRPCListenerSpec struct {
 	// Retry indicates a retry policy to be applied on every route of this listener.
 	// +optional
 	Retry *RetryPolicy `json:"retry,omitempty"`
+	// Aliases are other names of the listener in its namespace, a client can dial `xds:///<namespace>/<alias>`
+	// to reach it. A GRPCListener named after an alias takes precedence over the alias.
+	// +optional
+	Aliases []string `json:"aliases,omitempty"`
+	// VirtualHosts are route sets dedicated to some names of the listener.
+	// Calls to a name not served by any virtual host are routed with Routes.
+	// +optional
+	VirtualHosts []VirtualHost `json:"virtualHosts,omitempty"`
 	// Routes lists all the routes defined for an GRPCListener.
 	Routes []Route `json:"routes,omitempty"`
 }
 
+// AllRoutes returns the routes of the listener, followed by the routes of its virtual hosts.
+func (s *GRPCListenerSpec) AllRoutes() []Route {
+	routes := append([]Route{}, s.Routes...)
+
+	for _, vhost := range s.VirtualHosts {
+		routes = append(routes, vhost.Routes...)
+	}
+
+	return routes
+}
+
+// VirtualHost is a route set serving calls made to some names of a listener.
+type VirtualHost struct {
+	// Names lists the names served by this virtual host, among the name and the aliases of the listener.
+	// +kubebuilder:validation:MinItems=1
+	Names []string `json:"names"`
+	// Routes lists the routes of this virtual host.
+	Routes []Route `json:"routes,omitempty"`
+}
+
 // Route allows to match an outoing request to a specific cluster, it allows to do HTTP level manipulation on the outgoing requests as well as matching.
 type Route struct {
 	// Matcher define a way of matching a specific route.
 	Matcher *RouteMatcher `json:"matcher,omitempty"`
 
+	// Matchers define several ways of matching the route, a call matching any of them is matched.
+	// All the matchers share the same backends. It can't be set along with Matcher.
+	// +optional
+	Matchers []RouteMatcher `json:"matchers,omitempty"`
+
 	// Interceptors are a list of interceptor overrides to apply to this route.
 	// Note that the interceptors defined here must me also defined at the listener level.
 	Interceptors []Interceptor `json:"interceptors,omitempty"`
 
+	// NodeSelector only serves this route to the clients it selects, other clients fall through the next routes.
+	// +optional
+	NodeSelector *NodeSelector `json:"nodeSelector,omitempty"`
+
 	// HashPolicy are a list of heuristics to apply to obtain a hash for a given request.
 	// Multiple policies result are combined.
 	HashPolicy []HashPolicy `json:"hashPolicy,omitempty"`
@@ -83,18 +120,102 @@ type Route struct {
 
 	// Backends is the list of all backends serving that route.
 	Backends []Backend `json:"backends,omitempty"`
+
+	// RouteLookup routes calls to the targets picked by an external Route Lookup Service (RLS), instead of Backends.
+	// Clients must import `google.golang.org/grpc/balancer/rls` to support it.
+	// +optional
+	RouteLookup *RouteLookup `json:"routeLookup,omitempty"`
+}
+
+// RouteLookup configures the gRPC RLS cluster specifier plugin of a route.
+// The lookup service answers with the cluster names of the targets, `<namespace>/<listener>/target/<target>`.
+type RouteLookup struct {
+	// LookupService is the gRPC target URI of the Route Lookup Service, for example `dns:///rls.default.svc.cluster.local:8080`.
+	LookupService string `json:"lookupService"`
+	// LookupServiceTimeout is the timeout of lookup calls, 10s if not set.
+	// +optional
+	LookupServiceTimeout *metav1.Duration `json:"lookupServiceTimeout,omitempty"`
+	// MaxAge is the duration a lookup response is cached by clients, at most and by default 5m.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+	// StaleAge is the duration after which a cached lookup response is refreshed in the background.
+	// It requires MaxAge, and is ignored if greater.
+	// +optional
+	StaleAge *metav1.Duration `json:"staleAge,omitempty"`
+	// CacheSizeBytes is the size of the lookup cache of clients, capped at 5MiB by clients.
+	// +optional
+	// +kubebuilder:default:=1048576
+	// +kubebuilder:validation:Minimum:=1
+	CacheSizeBytes int64 `json:"cacheSizeBytes,omitempty"`
+	// KeyBuilders build the keys of the lookup requests of calls.
+	// +kubebuilder:validation:MinItems=1
+	KeyBuilders []RouteLookupKeyBuilder `json:"keyBuilders"`
+	// Targets are the backends the lookup service can pick. Their names are unique within a listener.
+	// +kubebuilder:validation:MinItems=1
+	Targets []RouteLookupTarget `json:"targets"`
+	// DefaultTarget is the name of the target of calls when the lookup fails.
+	// +optional
+	DefaultTarget string `json:"defaultTarget,omitempty"`
+}
+
+// RouteLookupKeyBuilder builds the keys of the lookup requests of calls to some methods.
+type RouteLookupKeyBuilder struct {
+	// Methods lists the methods this key builder applies to. A method left empty stands for all the methods of the service.
+	// +kubebuilder:validation:MinItems=1
+	Methods []MethodMatcher `json:"methods"`
+	// Metadata lists the keys built from the call metadata.
+	// +optional
+	Metadata []RouteLookupMetadataKey `json:"metadata,omitempty"`
+	// ConstantKeys are keys added to all the lookup requests.
+	// +optional
+	ConstantKeys map[string]string `json:"constantKeys,omitempty"`
+	// HostKey is the key holding the host the call was made to, if set.
+	// +optional
+	HostKey string `json:"hostKey,omitempty"`
+	// ServiceKey is the key holding the service of the call, if set.
+	// +optional
+	ServiceKey string `json:"serviceKey,omitempty"`
+	// MethodKey is the key holding the method of the call, if set.
+	// +optional
+	MethodKey string `json:"methodKey,omitempty"`
+}
+
+// RouteLookupMetadataKey builds a key of the lookup requests from the call metadata.
+type RouteLookupMetadataKey struct {
+	// Key is the name of the key in the lookup request.
+	Key string `json:"key"`
+	// Names are the names of the metadata read, the value of the first one present is used.
+	// +kubebuilder:validation:MinItems=1
+	Names []string `json:"names"`
+}
+
+// RouteLookupTarget is a backend the lookup service can route calls to.
+type RouteLookupTarget struct {
+	// Name is the name of the target.
+	Name string `json:"name"`
+	// Backend serves the calls routed to this target. Its weight is ignored.
+	Backend Backend `json:"backend"`
 }
 
 type RouteMatcher struct {
 	// Method allows to match a specific method of a grpc service.
 	Method *MethodMatcher `json:"method,omitempty"`
 
+	// Methods allows to match any of the given methods.
+	Methods []MethodMatcher `json:"methods,omitempty"`
+
 	// Service allows to match a specific service.
 	Service *ServiceMatcher `json:"service,omitempty"`
 
-	// Namespace allows to match a specific namespace.
+	// Services allows to match any of the given services.
+	Services []ServiceMatcher `json:"services,omitempty"`
+
+	// Namespace allows to match a specific namespace, that is a protobuf package.
 	Namespace *string `json:"namespace,omitempty"`
 
+	// Path allows to match the path of a call with a prefix, an exact path or a regex.
+	Path *PathMatcher `json:"path,omitempty"`
+
 	// Metadata allows to match on a specific set of call metadata.
 	Metadata []MetadataMatcher `json:"metadata,omitempty"`
 
@@ -129,7 +250,7 @@ type MethodMatcher struct {
 
 func (mm *MethodMatcher) Path() string {
 	return "/" + path.Join(
-		mm.Namespace+"."+mm.Service,
+		fullServiceName(mm.Namespace, mm.Service),
 		mm.Method,
 	)
 }
@@ -139,8 +260,52 @@ type ServiceMatcher struct {
 	Service   string `json:"service,omitempty"`
 }
 
+// Prefix returns the prefix of the paths of the service, ending with a slash to not match other services sharing the same prefix.
 func (sm *ServiceMatcher) Prefix() string {
-	return "/" + sm.Namespace + "." + sm.Service
+	return "/" + sm.FullName() + "/"
+}
+
+// FullName returns the name of the service qualified by its namespace.
+func (sm *ServiceMatcher) FullName() string {
+	return fullServiceName(sm.Namespace, sm.Service)
+}
+
+// NamespacePrefix returns the prefix of the paths of the services of a namespace, ending with a dot to not match other namespaces
+// sharing the same prefix.
+func NamespacePrefix(namespace string) string {
+	return "/" + namespace + "."
+}
+
+func fullServiceName(namespace, service string) string {
+	if namespace == "" {
+		return service
+	}
+
+	return namespace + "." + service
+}
+
+// NodeSelector selects xDS clients by the node they send to gTC. A client is selected if it matches all of the set fields.
+type NodeSelector struct {
+	// ID is a glob matched against the whole node ID of the client, `*` matching any sequence of characters, `/` included.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// Metadata lists string values the node metadata of the client must hold, by key.
+	// +optional
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// Locality the client must be in, empty fields matching any value. The region and the zone of a client identifying its pod
+	// are those of the node of its pod, as used to prioritize endpoints, the locality sent by the client is used otherwise.
+	// +optional
+	Locality *LocalitySelector `json:"locality,omitempty"`
+}
+
+// LocalitySelector matches the locality of a client node.
+type LocalitySelector struct {
+	// +optional
+	Region string `json:"region,omitempty"`
+	// +optional
+	Zone string `json:"zone,omitempty"`
+	// +optional
+	SubZone string `json:"subZone,omitempty"`
 }
 
 // Backend is a group of backend servers serving the same services.
@@ -172,6 +337,46 @@ type Backend struct {
 	// Localities is a list of prioritized and weighted localities for a backend.
 	// +optional
 	Localities []Locality `json:"localities,omitempty"`
+	// Pods selects the backend servers directly by their labels, for workloads not exposed by a Service.
+	// +optional
+	Pods *PodsRef `json:"pods,omitempty"`
+	// ServiceImport is a reference to a multi-cluster ServiceImport of the Multi-Cluster Services API.
+	// +optional
+	ServiceImport *ServiceImportRef `json:"serviceImport,omitempty"`
+
+	// PublishNotReady serves the endpoints that are not ready with the UNHEALTHY health status, instead of leaving them out.
+	// Terminating endpoints that are still serving are always served with the DRAINING health status.
+	// +optional
+	PublishNotReady bool `json:"publishNotReady,omitempty"`
+
+	// SameNode makes clients prefer the endpoints running on their own kubernetes node.
+	// It applies to service and pods backends only.
+	// +optional
+	SameNode *SameNodePolicy `json:"sameNode,omitempty"`
+
+	// Subset restricts the backend to the pods behind its services matching this label selector.
+	// This allows a single Service to back multiple backends, for instance a stable and a canary version.
+	// +optional
+	Subset *metav1.LabelSelector `json:"subset,omitempty"`
+}
+
+const (
+	// SameNodeFallbackAll falls back to the endpoints of the same zone, then of the same region, then to all endpoints.
+	SameNodeFallbackAll = "All"
+	// SameNodeFallbackZone falls back to the endpoints of the same zone only.
+	SameNodeFallbackZone = "Zone"
+	// SameNodeFallbackNone never falls back, similarly to a service with the Local internal traffic policy.
+	SameNodeFallbackNone = "None"
+)
+
+// SameNodePolicy prioritizes the endpoints running on the node of the client.
+// The node of a client is read from the gtc.dev/node-name key of its xDS node metadata.
+type SameNodePolicy struct {
+	// Fallback tells which endpoints to use when none is running on the node of the client.
+	// +optional
+	// +kubebuilder:validation:Enum:=All;Zone;None
+	// +kubebuilder:default:=All
+	Fallback string `json:"fallback,omitempty"`
 }
 
 type RingHashConfig struct {
@@ -183,6 +388,28 @@ type RingHashConfig struct {
 	// to further constrain resource use.
 	// +kubebuilder:default:=838860
 	MaxRingSize uint64 `json:"maxRingSize,omitempty"`
+	// EndpointHashKey places endpoints on the ring using a stable key taken from their pods instead of their addresses,
+	// so that keys don't move when pods are rescheduled. Endpoints without a key are placed using their addresses.
+	// It requires clients implementing gRFC A76, such as grpc-go v1.72.0 or later run with GRPC_XDS_ENDPOINT_HASH_KEY_BACKWARD_COMPAT=false,
+	// other clients place all the endpoints using their addresses.
+	// +optional
+	EndpointHashKey *EndpointHashKey `json:"endpointHashKey,omitempty"`
+	// RequestHashHeader hashes calls on the value of this metadata, instead of using the hash policies of the route.
+	// Calls without this metadata are hashed randomly. It requires clients implementing gRFC A76, such as grpc-go v1.72.0 or later
+	// run with GRPC_EXPERIMENTAL_RING_HASH_SET_REQUEST_HASH_KEY=true, other clients fall back on the hash policies of the route.
+	// +optional
+	RequestHashHeader string `json:"requestHashHeader,omitempty"`
+}
+
+// EndpointHashKey selects where the hash key of an endpoint is taken from: a label or an annotation of its pod,
+// or the name of its pod if neither is set.
+type EndpointHashKey struct {
+	// Label of the pod holding the hash key.
+	// +optional
+	Label string `json:"label,omitempty"`
+	// Annotation of the pod holding the hash key.
+	// +optional
+	Annotation string `json:"annotation,omitempty"`
 }
 
 // Locality is a weighted and prioritized locality for a backend.
@@ -208,12 +435,54 @@ type PortRef struct {
 	Name string `json:"name,omitempty"`
 }
 
+// PodsRef is a reference to a set of kubernetes pods.
+type PodsRef struct {
+	// Namespace of the pods, defaults to the namespace of the GRPCListener.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Selector selects the pods by labels.
+	Selector metav1.LabelSelector `json:"selector"`
+	// Port of the pods, either a port number or the name of a container port.
+	Port PortRef `json:"port,omitempty"`
+}
+
+const (
+	// AddressFamilyIPv4 prefers the IPv4 address of dual-stack endpoints.
+	AddressFamilyIPv4 = "IPv4"
+	// AddressFamilyIPv6 prefers the IPv6 address of dual-stack endpoints.
+	AddressFamilyIPv6 = "IPv6"
+)
+
 // ServiceRef is a reference to kubernetes service.
 type ServiceRef struct {
 	Name string `json:"name,omitempty"`
 	// +optional
 	Namespace string  `json:"namespace,omitempty"`
 	Port      PortRef `json:"port,omitempty"`
+	// AddressFamily is the preferred address family of the endpoints of a dual-stack service.
+	// Endpoints of all address families targeting the same pod are served as a single endpoint,
+	// its address being of the preferred family, and the other ones being additional addresses.
+	// +optional
+	// +kubebuilder:validation:Enum=IPv4;IPv6
+	// +kubebuilder:default:=IPv4
+	AddressFamily string `json:"addressFamily,omitempty"`
+	// Cluster is the name of the remote cluster the service lives in, its EndpointSlices being read from this cluster.
+	// Pods and Nodes of remote clusters are not looked up: the zone of the endpoints is read from the EndpointSlices,
+	// and subsets are not supported.
+	// If empty, the service lives in the cluster gTC runs in.
+	// +optional
+	Cluster string `json:"cluster,omitempty"`
+}
+
+// ServiceImportRef is a reference to a multi-cluster ServiceImport, its endpoints being the EndpointSlices
+// labelled with multicluster.kubernetes.io/service-name.
+type ServiceImportRef struct {
+	ServiceRef `json:",inline"`
+	// SourceClusters groups the endpoints by source cluster in separate localities, prioritized in the given order:
+	// endpoints of the first cluster get the highest priority. Endpoints of unlisted clusters share the lowest priority.
+	// If empty, endpoints are prioritized by topology as for a Service.
+	// +optional
+	SourceClusters []string `json:"sourceClusters,omitempty"`
 }
 
 // RetryPolicy indicates a retry policy.
@@ -245,12 +514,36 @@ type RetryBackoff struct {
 type HashPolicy struct {
 	// Metadata indicates rpc metadata call value to obtain a hash.
 	Metadata string `json:"metadata,omitempty"`
+	// RegexRewrite rewrites the metadata value before hashing it, for instance to only hash a part of it.
+	// +optional
+	RegexRewrite *RegexRewrite `json:"regexRewrite,omitempty"`
 	// Channel indicates to use the chanel_id to obtain a hash.
 	Channel *bool `json:"channel,omitempty"`
 	// Terminal tells to stop the hashing process if this policy is successful.
 	Terminal bool `json:"terminal,omitempty"`
 }
 
+const (
+	// GRPCListenerConditionResolvedRefs tells if all the resources referenced by the backends of a GRPCListener
+	// can be resolved.
+	GRPCListenerConditionResolvedRefs = "ResolvedRefs"
+
+	// GRPCListenerReasonResolvedRefs is the reason of a true ResolvedRefs condition.
+	GRPCListenerReasonResolvedRefs = "ResolvedRefs"
+	// GRPCListenerReasonRefNotPermitted is the reason of a false ResolvedRefs condition, set when a backend references
+	// a resource of another namespace without a matching ReferenceGrant.
+	GRPCListenerReasonRefNotPermitted = "RefNotPermitted"
+)
+
+// GRPCListenerStatus is the observed state of a GRPCListener.
+type GRPCListenerStatus struct {
+	// Conditions of the listener.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
 // GRPCListenerList contains a list of GRPCListener
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type GRPCListenerList struct {
@@ -37,7 +37,7 @@ func (in *Backend) DeepCopyInto(out *Backend) {
 	if in.RingHashConfig != nil {
 		in, out := &in.RingHashConfig, &out.RingHashConfig
 		*out = new(RingHashConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Interceptors != nil {
 		in, out := &in.Interceptors, &out.Interceptors
@@ -58,6 +58,26 @@ func (in *Backend) DeepCopyInto(out *Backend) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = new(PodsRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceImport != nil {
+		in, out := &in.ServiceImport, &out.ServiceImport
+		*out = new(ServiceImportRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SameNode != nil {
+		in, out := &in.SameNode, &out.SameNode
+		*out = new(SameNodePolicy)
+		**out = **in
+	}
+	if in.Subset != nil {
+		in, out := &in.Subset, &out.Subset
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -71,6 +91,22 @@ func (in *Backend) DeepCopy() *Backend {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointHashKey) DeepCopyInto(out *EndpointHashKey) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointHashKey.
+func (in *EndpointHashKey) DeepCopy() *EndpointHashKey {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointHashKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FaultAbort) DeepCopyInto(out *FaultAbort) {
 	*out = *in
@@ -133,6 +169,132 @@ func (in *FaultDelay) DeepCopy() *FaultDelay {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FaultExperiment) DeepCopyInto(out *FaultExperiment) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FaultExperiment.
+func (in *FaultExperiment) DeepCopy() *FaultExperiment {
+	if in == nil {
+		return nil
+	}
+	out := new(FaultExperiment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FaultExperiment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FaultExperimentList) DeepCopyInto(out *FaultExperimentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FaultExperiment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FaultExperimentList.
+func (in *FaultExperimentList) DeepCopy() *FaultExperimentList {
+	if in == nil {
+		return nil
+	}
+	out := new(FaultExperimentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FaultExperimentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FaultExperimentSpec) DeepCopyInto(out *FaultExperimentSpec) {
+	*out = *in
+	in.Target.DeepCopyInto(&out.Target)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	out.Duration = in.Duration
+	in.Fault.DeepCopyInto(&out.Fault)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FaultExperimentSpec.
+func (in *FaultExperimentSpec) DeepCopy() *FaultExperimentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FaultExperimentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FaultExperimentStatus) DeepCopyInto(out *FaultExperimentStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FaultExperimentStatus.
+func (in *FaultExperimentStatus) DeepCopy() *FaultExperimentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FaultExperimentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FaultExperimentTarget) DeepCopyInto(out *FaultExperimentTarget) {
+	*out = *in
+	if in.Route != nil {
+		in, out := &in.Route, &out.Route
+		*out = new(int)
+		**out = **in
+	}
+	if in.Backend != nil {
+		in, out := &in.Backend, &out.Backend
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FaultExperimentTarget.
+func (in *FaultExperimentTarget) DeepCopy() *FaultExperimentTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(FaultExperimentTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FaultInterceptor) DeepCopyInto(out *FaultInterceptor) {
 	*out = *in
@@ -193,6 +355,7 @@ func (in *GRPCListener) DeepCopyInto(out *GRPCListener) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -267,6 +430,18 @@ func (in *GRPCListenerSpec) DeepCopyInto(out *GRPCListenerSpec) {
 		*out = new(RetryPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Aliases != nil {
+		in, out := &in.Aliases, &out.Aliases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VirtualHosts != nil {
+		in, out := &in.VirtualHosts, &out.VirtualHosts
+		*out = make([]VirtualHost, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Routes != nil {
 		in, out := &in.Routes, &out.Routes
 		*out = make([]Route, len(*in))
@@ -287,9 +462,37 @@ func (in *GRPCListenerSpec) DeepCopy() *GRPCListenerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCListenerStatus) DeepCopyInto(out *GRPCListenerStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCListenerStatus.
+func (in *GRPCListenerStatus) DeepCopy() *GRPCListenerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCListenerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HashPolicy) DeepCopyInto(out *HashPolicy) {
 	*out = *in
+	if in.RegexRewrite != nil {
+		in, out := &in.RegexRewrite, &out.RegexRewrite
+		*out = new(RegexRewrite)
+		**out = **in
+	}
 	if in.Channel != nil {
 		in, out := &in.Channel, &out.Channel
 		*out = new(bool)
@@ -362,6 +565,11 @@ func (in *Interceptor) DeepCopyInto(out *Interceptor) {
 		*out = new(FaultInterceptor)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(NodeSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -396,6 +604,22 @@ func (in *Locality) DeepCopy() *Locality {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalitySelector) DeepCopyInto(out *LocalitySelector) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalitySelector.
+func (in *LocalitySelector) DeepCopy() *LocalitySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalitySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetadataFault) DeepCopyInto(out *MetadataFault) {
 	*out = *in
@@ -474,6 +698,34 @@ func (in *MethodMatcher) DeepCopy() *MethodMatcher {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSelector) DeepCopyInto(out *NodeSelector) {
+	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Locality != nil {
+		in, out := &in.Locality, &out.Locality
+		*out = new(LocalitySelector)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSelector.
+func (in *NodeSelector) DeepCopy() *NodeSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PathMatcher) DeepCopyInto(out *PathMatcher) {
 	*out = *in
@@ -482,6 +734,11 @@ func (in *PathMatcher) DeepCopyInto(out *PathMatcher) {
 		*out = new(RegexMatcher)
 		**out = **in
 	}
+	if in.CaseSensitive != nil {
+		in, out := &in.CaseSensitive, &out.CaseSensitive
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -495,6 +752,24 @@ func (in *PathMatcher) DeepCopy() *PathMatcher {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodsRef) DeepCopyInto(out *PodsRef) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	out.Port = in.Port
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodsRef.
+func (in *PodsRef) DeepCopy() *PodsRef {
+	if in == nil {
+		return nil
+	}
+	out := new(PodsRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PortRef) DeepCopyInto(out *PortRef) {
 	*out = *in
@@ -527,6 +802,124 @@ func (in *RangeMatcher) DeepCopy() *RangeMatcher {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReferenceGrant) DeepCopyInto(out *ReferenceGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceGrant.
+func (in *ReferenceGrant) DeepCopy() *ReferenceGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(ReferenceGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReferenceGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReferenceGrantFrom) DeepCopyInto(out *ReferenceGrantFrom) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceGrantFrom.
+func (in *ReferenceGrantFrom) DeepCopy() *ReferenceGrantFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(ReferenceGrantFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReferenceGrantList) DeepCopyInto(out *ReferenceGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ReferenceGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceGrantList.
+func (in *ReferenceGrantList) DeepCopy() *ReferenceGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(ReferenceGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReferenceGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReferenceGrantSpec) DeepCopyInto(out *ReferenceGrantSpec) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]ReferenceGrantFrom, len(*in))
+		copy(*out, *in)
+	}
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		*out = make([]ReferenceGrantTo, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceGrantSpec.
+func (in *ReferenceGrantSpec) DeepCopy() *ReferenceGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReferenceGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReferenceGrantTo) DeepCopyInto(out *ReferenceGrantTo) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceGrantTo.
+func (in *ReferenceGrantTo) DeepCopy() *ReferenceGrantTo {
+	if in == nil {
+		return nil
+	}
+	out := new(ReferenceGrantTo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegexMatcher) DeepCopyInto(out *RegexMatcher) {
 	*out = *in
@@ -543,6 +936,23 @@ func (in *RegexMatcher) DeepCopy() *RegexMatcher {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegexRewrite) DeepCopyInto(out *RegexRewrite) {
+	*out = *in
+	out.Pattern = in.Pattern
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegexRewrite.
+func (in *RegexRewrite) DeepCopy() *RegexRewrite {
+	if in == nil {
+		return nil
+	}
+	out := new(RegexRewrite)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RetryBackoff) DeepCopyInto(out *RetryBackoff) {
 	*out = *in
@@ -599,6 +1009,11 @@ func (in *RetryPolicy) DeepCopy() *RetryPolicy {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RingHashConfig) DeepCopyInto(out *RingHashConfig) {
 	*out = *in
+	if in.EndpointHashKey != nil {
+		in, out := &in.EndpointHashKey, &out.EndpointHashKey
+		*out = new(EndpointHashKey)
+		**out = **in
+	}
 	return
 }
 
@@ -620,6 +1035,13 @@ func (in *Route) DeepCopyInto(out *Route) {
 		*out = new(RouteMatcher)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Matchers != nil {
+		in, out := &in.Matchers, &out.Matchers
+		*out = make([]RouteMatcher, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Interceptors != nil {
 		in, out := &in.Interceptors, &out.Interceptors
 		*out = make([]Interceptor, len(*in))
@@ -627,6 +1049,11 @@ func (in *Route) DeepCopyInto(out *Route) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(NodeSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.HashPolicy != nil {
 		in, out := &in.HashPolicy, &out.HashPolicy
 		*out = make([]HashPolicy, len(*in))
@@ -656,6 +1083,11 @@ func (in *Route) DeepCopyInto(out *Route) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RouteLookup != nil {
+		in, out := &in.RouteLookup, &out.RouteLookup
+		*out = new(RouteLookup)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -669,6 +1101,124 @@ func (in *Route) DeepCopy() *Route {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteLookup) DeepCopyInto(out *RouteLookup) {
+	*out = *in
+	if in.LookupServiceTimeout != nil {
+		in, out := &in.LookupServiceTimeout, &out.LookupServiceTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.StaleAge != nil {
+		in, out := &in.StaleAge, &out.StaleAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.KeyBuilders != nil {
+		in, out := &in.KeyBuilders, &out.KeyBuilders
+		*out = make([]RouteLookupKeyBuilder, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]RouteLookupTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteLookup.
+func (in *RouteLookup) DeepCopy() *RouteLookup {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteLookup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteLookupKeyBuilder) DeepCopyInto(out *RouteLookupKeyBuilder) {
+	*out = *in
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]MethodMatcher, len(*in))
+		copy(*out, *in)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make([]RouteLookupMetadataKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConstantKeys != nil {
+		in, out := &in.ConstantKeys, &out.ConstantKeys
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteLookupKeyBuilder.
+func (in *RouteLookupKeyBuilder) DeepCopy() *RouteLookupKeyBuilder {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteLookupKeyBuilder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteLookupMetadataKey) DeepCopyInto(out *RouteLookupMetadataKey) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteLookupMetadataKey.
+func (in *RouteLookupMetadataKey) DeepCopy() *RouteLookupMetadataKey {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteLookupMetadataKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteLookupTarget) DeepCopyInto(out *RouteLookupTarget) {
+	*out = *in
+	in.Backend.DeepCopyInto(&out.Backend)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteLookupTarget.
+func (in *RouteLookupTarget) DeepCopy() *RouteLookupTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteLookupTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteMatcher) DeepCopyInto(out *RouteMatcher) {
 	*out = *in
@@ -677,16 +1227,31 @@ func (in *RouteMatcher) DeepCopyInto(out *RouteMatcher) {
 		*out = new(MethodMatcher)
 		**out = **in
 	}
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]MethodMatcher, len(*in))
+		copy(*out, *in)
+	}
 	if in.Service != nil {
 		in, out := &in.Service, &out.Service
 		*out = new(ServiceMatcher)
 		**out = **in
 	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]ServiceMatcher, len(*in))
+		copy(*out, *in)
+	}
 	if in.Namespace != nil {
 		in, out := &in.Namespace, &out.Namespace
 		*out = new(string)
 		**out = **in
 	}
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(PathMatcher)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Metadata != nil {
 		in, out := &in.Metadata, &out.Metadata
 		*out = make([]MetadataMatcher, len(*in))
@@ -712,6 +1277,44 @@ func (in *RouteMatcher) DeepCopy() *RouteMatcher {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SameNodePolicy) DeepCopyInto(out *SameNodePolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SameNodePolicy.
+func (in *SameNodePolicy) DeepCopy() *SameNodePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SameNodePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceImportRef) DeepCopyInto(out *ServiceImportRef) {
+	*out = *in
+	out.ServiceRef = in.ServiceRef
+	if in.SourceClusters != nil {
+		in, out := &in.SourceClusters, &out.SourceClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceImportRef.
+func (in *ServiceImportRef) DeepCopy() *ServiceImportRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceImportRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceMatcher) DeepCopyInto(out *ServiceMatcher) {
 	*out = *in
@@ -744,3 +1347,31 @@ func (in *ServiceRef) DeepCopy() *ServiceRef {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualHost) DeepCopyInto(out *VirtualHost) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]Route, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualHost.
+func (in *VirtualHost) DeepCopy() *VirtualHost {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualHost)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -1,5 +1,15 @@
 package bootstrap
 
+// MetadataNodeName is the key of the node metadata holding the name of the kubernetes node running the client.
+const MetadataNodeName = "gtc.dev/node-name"
+
+// MetadataPodNamespace and MetadataPodName are the keys of the node metadata holding the namespace and the name of the pod
+// running the client, letting gTC look up the locality and the node of the client.
+const (
+	MetadataPodNamespace = "gtc.dev/pod-namespace"
+	MetadataPodName      = "gtc.dev/pod-name"
+)
+
 type BootstrapConfig struct {
 	XDSServers []XDSServer `json:"xds_servers"`
 	Node       Node        `json:"node"`
@@ -12,14 +22,31 @@ type XDSServer struct {
 }
 
 type Cred struct {
-	Type string `json:"type"`
+	Type   string      `json:"type"`
+	Config *CredConfig `json:"config,omitempty"`
+}
+
+// CredConfig is the configuration of the gTC channel credentials.
+// See package xdscreds to register them in a gRPC client.
+type CredConfig struct {
+	// CACertificateFile is the path to the CA bundle used to verify the xDS server certificate.
+	// If empty, system roots are used.
+	CACertificateFile string `json:"ca_certificate_file,omitempty"`
+	// CertificateFile is the path to the client certificate, if the client authenticates using a certificate.
+	CertificateFile string `json:"certificate_file,omitempty"`
+	// PrivateKeyFile is the path to the private key of the client certificate.
+	PrivateKeyFile string `json:"private_key_file,omitempty"`
+	// TokenFile is the path to a projected ServiceAccount token, if the client authenticates using a token.
+	TokenFile string `json:"token_file,omitempty"`
 }
 
 type Node struct {
-	ID       string   `json:"id"`
-	Locality Locality `json:"locality,omitempty"`
+	ID       string            `json:"id"`
+	Locality Locality          `json:"locality,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 type Locality struct {
-	Zone string `json:"zone"`
+	Region string `json:"region,omitempty"`
+	Zone   string `json:"zone"`
 }
@@ -7,25 +7,21 @@ import (
 
 type envProvider struct{}
 
-func (e *envProvider) Provide(_ context.Context, serverURI string) (*BootstrapConfig, error) {
+func (e *envProvider) Provide(_ context.Context, server ServerConfig) (*BootstrapConfig, error) {
 	nodeID, err := getNodeID()
 	if err != nil {
 		return nil, err
 	}
 
 	return &BootstrapConfig{
-		XDSServers: []XDSServer{
-			{
-				URI:      serverURI,
-				Features: []string{"xds_v3"},
-				Creds:    []Cred{{Type: "insecure"}},
-			},
-		},
+		XDSServers: []XDSServer{server.XDSServer()},
 		Node: Node{
 			ID: nodeID,
 			Locality: Locality{
-				Zone: os.Getenv("GTC_ZONE"),
+				Region: os.Getenv("GTC_REGION"),
+				Zone:   os.Getenv("GTC_ZONE"),
 			},
+			Metadata: getNodeMetadata(),
 		},
 	}, nil
 }
@@ -39,3 +35,26 @@ func getNodeID() (string, error) {
 
 	return os.Hostname()
 }
+
+// getNodeMetadata reads the name of the kubernetes node running the client from the GTC_NODE_NAME environment variable,
+// and the namespace and the name of the pod of the client from the GTC_POD_NAMESPACE and GTC_POD_NAME environment variables,
+// usually set using the downward API.
+func getNodeMetadata() map[string]string {
+	metadata := make(map[string]string)
+
+	for key, env := range map[string]string{
+		MetadataNodeName:     "GTC_NODE_NAME",
+		MetadataPodNamespace: "GTC_POD_NAMESPACE",
+		MetadataPodName:      "GTC_POD_NAME",
+	} {
+		if value := os.Getenv(env); value != "" {
+			metadata[key] = value
+		}
+	}
+
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	return metadata
+}
@@ -3,6 +3,7 @@ package bootstrap
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"cloud.google.com/go/compute/metadata"
 )
@@ -11,7 +12,7 @@ var ErrNotRunningOnGCE = errors.New("not running on GCE")
 
 type gcloudProvider struct{}
 
-func (e *gcloudProvider) Provide(_ context.Context, serverURI string) (*BootstrapConfig, error) {
+func (e *gcloudProvider) Provide(_ context.Context, server ServerConfig) (*BootstrapConfig, error) {
 	if !metadata.OnGCE() {
 		return nil, ErrNotRunningOnGCE
 	}
@@ -27,18 +28,24 @@ func (e *gcloudProvider) Provide(_ context.Context, serverURI string) (*Bootstra
 	}
 
 	return &BootstrapConfig{
-		XDSServers: []XDSServer{
-			{
-				URI:      serverURI,
-				Features: []string{"xds_v3"},
-				Creds:    []Cred{{Type: "insecure"}},
-			},
-		},
+		XDSServers: []XDSServer{server.XDSServer()},
 		Node: Node{
 			ID: nodeID,
 			Locality: Locality{
-				Zone: zone,
+				Region: gceRegion(zone),
+				Zone:   zone,
 			},
+			Metadata: getNodeMetadata(),
 		},
 	}, nil
 }
+
+// gceRegion returns the region of a GCE zone, us-central1 for us-central1-a.
+func gceRegion(zone string) string {
+	idx := strings.LastIndex(zone, "-")
+	if idx < 0 {
+		return ""
+	}
+
+	return zone[:idx]
+}
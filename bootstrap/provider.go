@@ -19,7 +19,7 @@ func (u UnknownProviderError) Error() string {
 
 // ConfigProvider allows to retrieve a BootstrapConfig.
 type ConfigProvider interface {
-	Provide(ctx context.Context, serverURI string) (*BootstrapConfig, error)
+	Provide(ctx context.Context, server ServerConfig) (*BootstrapConfig, error)
 }
 
 func BuildConfigProvider(_ context.Context, providerType string) (ConfigProvider, error) {
@@ -0,0 +1,46 @@
+package bootstrap
+
+const (
+	CredTypeInsecure = "insecure"
+	// CredTypeGTC are TLS channel credentials, optionally carrying a ServiceAccount token.
+	CredTypeGTC = "gtc"
+)
+
+// ServerConfig describes how to reach and authenticate to the xDS server.
+type ServerConfig struct {
+	URI string
+	// CACertificateFile enables TLS and verifies the server using the given CA bundle.
+	CACertificateFile string
+	// CertificateFile and PrivateKeyFile enable client certificate authentication.
+	CertificateFile string
+	PrivateKeyFile  string
+	// TokenFile enables ServiceAccount token authentication.
+	TokenFile string
+}
+
+func (c ServerConfig) secure() bool {
+	return c.CACertificateFile != "" || c.CertificateFile != "" || c.TokenFile != ""
+}
+
+// XDSServer returns the bootstrap entry of the xDS server.
+func (c ServerConfig) XDSServer() XDSServer {
+	cred := Cred{Type: CredTypeInsecure}
+
+	if c.secure() {
+		cred = Cred{
+			Type: CredTypeGTC,
+			Config: &CredConfig{
+				CACertificateFile: c.CACertificateFile,
+				CertificateFile:   c.CertificateFile,
+				PrivateKeyFile:    c.PrivateKeyFile,
+				TokenFile:         c.TokenFile,
+			},
+		}
+	}
+
+	return XDSServer{
+		URI:      c.URI,
+		Features: []string{"xds_v3"},
+		Creds:    []Cred{cred},
+	}
+}
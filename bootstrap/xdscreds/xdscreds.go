@@ -0,0 +1,125 @@
+// Package xdscreds registers the gTC channel credentials in the gRPC xDS client.
+// Import it for side effects in any client using a bootstrap file generated with TLS or token authentication.
+//
+//	import _ "github.com/jlevesy/grpc-traffic-controller/bootstrap/xdscreds"
+package xdscreds
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+	xdsbootstrap "google.golang.org/grpc/xds/bootstrap"
+
+	"github.com/jlevesy/grpc-traffic-controller/bootstrap"
+)
+
+func init() {
+	xdsbootstrap.RegisterCredentials(builder{})
+}
+
+type builder struct{}
+
+func (builder) Name() string {
+	return bootstrap.CredTypeGTC
+}
+
+func (builder) Build(rawConfig json.RawMessage) (credentials.Bundle, func(), error) {
+	var cfg bootstrap.CredConfig
+
+	if len(rawConfig) > 0 {
+		if err := json.Unmarshal(rawConfig, &cfg); err != nil {
+			return nil, nil, fmt.Errorf("malformed %s credentials config: %w", bootstrap.CredTypeGTC, err)
+		}
+	}
+
+	tlsConfig, err := buildTLSConfig(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b := bundle{transport: credentials.NewTLS(tlsConfig)}
+
+	if cfg.TokenFile != "" {
+		b.perRPC = tokenFileCredentials(cfg.TokenFile)
+	}
+
+	return &b, func() {}, nil
+}
+
+func buildTLSConfig(cfg bootstrap.CredConfig) (*tls.Config, error) {
+	tlsConfig := tls.Config{MinVersion: tls.VersionTLS12}
+
+	if cfg.CACertificateFile != "" {
+		pem, err := os.ReadFile(cfg.CACertificateFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in %q", cfg.CACertificateFile)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.CertificateFile != "" || cfg.PrivateKeyFile != "" {
+		if cfg.CertificateFile == "" || cfg.PrivateKeyFile == "" {
+			return nil, errors.New("both a certificate and a private key are required for client certificate authentication")
+		}
+
+		// Reload the certificate on every handshake, to support rotated certificates.
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, err := tls.LoadX509KeyPair(cfg.CertificateFile, cfg.PrivateKeyFile)
+			if err != nil {
+				return nil, err
+			}
+
+			return &cert, nil
+		}
+	}
+
+	return &tlsConfig, nil
+}
+
+type bundle struct {
+	transport credentials.TransportCredentials
+	perRPC    credentials.PerRPCCredentials
+}
+
+func (b *bundle) TransportCredentials() credentials.TransportCredentials {
+	return b.transport
+}
+
+func (b *bundle) PerRPCCredentials() credentials.PerRPCCredentials {
+	return b.perRPC
+}
+
+func (b *bundle) NewWithMode(string) (credentials.Bundle, error) {
+	return nil, errors.New("unsupported")
+}
+
+// tokenFileCredentials reads the token on every call, projected ServiceAccount tokens being rotated by the kubelet.
+type tokenFileCredentials string
+
+func (t tokenFileCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	token, err := os.ReadFile(string(t))
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"authorization": "Bearer " + strings.TrimSpace(string(token)),
+	}, nil
+}
+
+func (t tokenFileCredentials) RequireTransportSecurity() bool {
+	return true
+}
@@ -18,16 +18,25 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // BackendApplyConfiguration represents an declarative configuration of the Backend type for use
 // with apply.
 type BackendApplyConfiguration struct {
-	Weight         *uint32                           `json:"weight,omitempty"`
-	MaxRequests    *uint32                           `json:"maxRequests,omitempty"`
-	LBPolicy       *string                           `json:"lbPolicy,omitempty"`
-	RingHashConfig *RingHashConfigApplyConfiguration `json:"ringHashConfig,omitempty"`
-	Interceptors   []InterceptorApplyConfiguration   `json:"interceptors,omitempty"`
-	Service        *ServiceRefApplyConfiguration     `json:"service,omitempty"`
-	Localities     []LocalityApplyConfiguration      `json:"localities,omitempty"`
+	Weight          *uint32                             `json:"weight,omitempty"`
+	MaxRequests     *uint32                             `json:"maxRequests,omitempty"`
+	LBPolicy        *string                             `json:"lbPolicy,omitempty"`
+	RingHashConfig  *RingHashConfigApplyConfiguration   `json:"ringHashConfig,omitempty"`
+	Interceptors    []InterceptorApplyConfiguration     `json:"interceptors,omitempty"`
+	Service         *ServiceRefApplyConfiguration       `json:"service,omitempty"`
+	Localities      []LocalityApplyConfiguration        `json:"localities,omitempty"`
+	Pods            *PodsRefApplyConfiguration          `json:"pods,omitempty"`
+	ServiceImport   *ServiceImportRefApplyConfiguration `json:"serviceImport,omitempty"`
+	PublishNotReady *bool                               `json:"publishNotReady,omitempty"`
+	SameNode        *SameNodePolicyApplyConfiguration   `json:"sameNode,omitempty"`
+	Subset          *v1.LabelSelector                   `json:"subset,omitempty"`
 }
 
 // BackendApplyConfiguration constructs an declarative configuration of the Backend type for use with
@@ -101,3 +110,43 @@ func (b *BackendApplyConfiguration) WithLocalities(values ...*LocalityApplyConfi
 	}
 	return b
 }
+
+// WithPods sets the Pods field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Pods field is set to the value of the last call.
+func (b *BackendApplyConfiguration) WithPods(value *PodsRefApplyConfiguration) *BackendApplyConfiguration {
+	b.Pods = value
+	return b
+}
+
+// WithServiceImport sets the ServiceImport field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceImport field is set to the value of the last call.
+func (b *BackendApplyConfiguration) WithServiceImport(value *ServiceImportRefApplyConfiguration) *BackendApplyConfiguration {
+	b.ServiceImport = value
+	return b
+}
+
+// WithPublishNotReady sets the PublishNotReady field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PublishNotReady field is set to the value of the last call.
+func (b *BackendApplyConfiguration) WithPublishNotReady(value bool) *BackendApplyConfiguration {
+	b.PublishNotReady = &value
+	return b
+}
+
+// WithSameNode sets the SameNode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SameNode field is set to the value of the last call.
+func (b *BackendApplyConfiguration) WithSameNode(value *SameNodePolicyApplyConfiguration) *BackendApplyConfiguration {
+	b.SameNode = value
+	return b
+}
+
+// WithSubset sets the Subset field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Subset field is set to the value of the last call.
+func (b *BackendApplyConfiguration) WithSubset(value v1.LabelSelector) *BackendApplyConfiguration {
+	b.Subset = &value
+	return b
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// EndpointHashKeyApplyConfiguration represents an declarative configuration of the EndpointHashKey type for use
+// with apply.
+type EndpointHashKeyApplyConfiguration struct {
+	Label      *string `json:"label,omitempty"`
+	Annotation *string `json:"annotation,omitempty"`
+}
+
+// EndpointHashKeyApplyConfiguration constructs an declarative configuration of the EndpointHashKey type for use with
+// apply.
+func EndpointHashKey() *EndpointHashKeyApplyConfiguration {
+	return &EndpointHashKeyApplyConfiguration{}
+}
+
+// WithLabel sets the Label field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Label field is set to the value of the last call.
+func (b *EndpointHashKeyApplyConfiguration) WithLabel(value string) *EndpointHashKeyApplyConfiguration {
+	b.Label = &value
+	return b
+}
+
+// WithAnnotation sets the Annotation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Annotation field is set to the value of the last call.
+func (b *EndpointHashKeyApplyConfiguration) WithAnnotation(value string) *EndpointHashKeyApplyConfiguration {
+	b.Annotation = &value
+	return b
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FaultExperimentSpecApplyConfiguration represents an declarative configuration of the FaultExperimentSpec type for use
+// with apply.
+type FaultExperimentSpecApplyConfiguration struct {
+	Target    *FaultExperimentTargetApplyConfiguration `json:"target,omitempty"`
+	StartTime *v1.Time                                 `json:"startTime,omitempty"`
+	Duration  *v1.Duration                             `json:"duration,omitempty"`
+	Fault     *FaultInterceptorApplyConfiguration      `json:"fault,omitempty"`
+}
+
+// FaultExperimentSpecApplyConfiguration constructs an declarative configuration of the FaultExperimentSpec type for use with
+// apply.
+func FaultExperimentSpec() *FaultExperimentSpecApplyConfiguration {
+	return &FaultExperimentSpecApplyConfiguration{}
+}
+
+// WithTarget sets the Target field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Target field is set to the value of the last call.
+func (b *FaultExperimentSpecApplyConfiguration) WithTarget(value *FaultExperimentTargetApplyConfiguration) *FaultExperimentSpecApplyConfiguration {
+	b.Target = value
+	return b
+}
+
+// WithStartTime sets the StartTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StartTime field is set to the value of the last call.
+func (b *FaultExperimentSpecApplyConfiguration) WithStartTime(value v1.Time) *FaultExperimentSpecApplyConfiguration {
+	b.StartTime = &value
+	return b
+}
+
+// WithDuration sets the Duration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Duration field is set to the value of the last call.
+func (b *FaultExperimentSpecApplyConfiguration) WithDuration(value v1.Duration) *FaultExperimentSpecApplyConfiguration {
+	b.Duration = &value
+	return b
+}
+
+// WithFault sets the Fault field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Fault field is set to the value of the last call.
+func (b *FaultExperimentSpecApplyConfiguration) WithFault(value *FaultInterceptorApplyConfiguration) *FaultExperimentSpecApplyConfiguration {
+	b.Fault = value
+	return b
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// FaultExperimentStatusApplyConfiguration represents an declarative configuration of the FaultExperimentStatus type for use
+// with apply.
+type FaultExperimentStatusApplyConfiguration struct {
+	Phase   *string `json:"phase,omitempty"`
+	Message *string `json:"message,omitempty"`
+}
+
+// FaultExperimentStatusApplyConfiguration constructs an declarative configuration of the FaultExperimentStatus type for use with
+// apply.
+func FaultExperimentStatus() *FaultExperimentStatusApplyConfiguration {
+	return &FaultExperimentStatusApplyConfiguration{}
+}
+
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *FaultExperimentStatusApplyConfiguration) WithPhase(value string) *FaultExperimentStatusApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *FaultExperimentStatusApplyConfiguration) WithMessage(value string) *FaultExperimentStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// FaultExperimentTargetApplyConfiguration represents an declarative configuration of the FaultExperimentTarget type for use
+// with apply.
+type FaultExperimentTargetApplyConfiguration struct {
+	Listener *string `json:"listener,omitempty"`
+	Route    *int    `json:"route,omitempty"`
+	Backend  *int    `json:"backend,omitempty"`
+}
+
+// FaultExperimentTargetApplyConfiguration constructs an declarative configuration of the FaultExperimentTarget type for use with
+// apply.
+func FaultExperimentTarget() *FaultExperimentTargetApplyConfiguration {
+	return &FaultExperimentTargetApplyConfiguration{}
+}
+
+// WithListener sets the Listener field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Listener field is set to the value of the last call.
+func (b *FaultExperimentTargetApplyConfiguration) WithListener(value string) *FaultExperimentTargetApplyConfiguration {
+	b.Listener = &value
+	return b
+}
+
+// WithRoute sets the Route field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Route field is set to the value of the last call.
+func (b *FaultExperimentTargetApplyConfiguration) WithRoute(value int) *FaultExperimentTargetApplyConfiguration {
+	b.Route = &value
+	return b
+}
+
+// WithBackend sets the Backend field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Backend field is set to the value of the last call.
+func (b *FaultExperimentTargetApplyConfiguration) WithBackend(value int) *FaultExperimentTargetApplyConfiguration {
+	b.Backend = &value
+	return b
+}
@@ -29,7 +29,8 @@ import (
 type GRPCListenerApplyConfiguration struct {
 	v1.TypeMetaApplyConfiguration    `json:",inline"`
 	*v1.ObjectMetaApplyConfiguration `json:"metadata,omitempty"`
-	Spec                             *GRPCListenerSpecApplyConfiguration `json:"spec,omitempty"`
+	Spec                             *GRPCListenerSpecApplyConfiguration   `json:"spec,omitempty"`
+	Status                           *GRPCListenerStatusApplyConfiguration `json:"status,omitempty"`
 }
 
 // GRPCListener constructs an declarative configuration of the GRPCListener type for use with
@@ -208,3 +209,11 @@ func (b *GRPCListenerApplyConfiguration) WithSpec(value *GRPCListenerSpecApplyCo
 	b.Spec = value
 	return b
 }
+
+// WithStatus sets the Status field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Status field is set to the value of the last call.
+func (b *GRPCListenerApplyConfiguration) WithStatus(value *GRPCListenerStatusApplyConfiguration) *GRPCListenerApplyConfiguration {
+	b.Status = value
+	return b
+}
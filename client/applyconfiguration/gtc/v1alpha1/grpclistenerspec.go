@@ -28,6 +28,8 @@ type GRPCListenerSpecApplyConfiguration struct {
 	MaxStreamDuration *v1.Duration                    `json:"maxStreamDuration,omitempty"`
 	Interceptors      []InterceptorApplyConfiguration `json:"interceptors,omitempty"`
 	Retry             *RetryPolicyApplyConfiguration  `json:"retry,omitempty"`
+	Aliases           []string                        `json:"aliases,omitempty"`
+	VirtualHosts      []VirtualHostApplyConfiguration `json:"virtualHosts,omitempty"`
 	Routes            []RouteApplyConfiguration       `json:"routes,omitempty"`
 }
 
@@ -66,6 +68,29 @@ func (b *GRPCListenerSpecApplyConfiguration) WithRetry(value *RetryPolicyApplyCo
 	return b
 }
 
+// WithAliases adds the given value to the Aliases field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Aliases field.
+func (b *GRPCListenerSpecApplyConfiguration) WithAliases(values ...string) *GRPCListenerSpecApplyConfiguration {
+	for i := range values {
+		b.Aliases = append(b.Aliases, values[i])
+	}
+	return b
+}
+
+// WithVirtualHosts adds the given value to the VirtualHosts field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the VirtualHosts field.
+func (b *GRPCListenerSpecApplyConfiguration) WithVirtualHosts(values ...*VirtualHostApplyConfiguration) *GRPCListenerSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithVirtualHosts")
+		}
+		b.VirtualHosts = append(b.VirtualHosts, *values[i])
+	}
+	return b
+}
+
 // WithRoutes adds the given value to the Routes field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Routes field.
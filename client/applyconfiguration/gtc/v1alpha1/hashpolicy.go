@@ -21,9 +21,10 @@ package v1alpha1
 // HashPolicyApplyConfiguration represents an declarative configuration of the HashPolicy type for use
 // with apply.
 type HashPolicyApplyConfiguration struct {
-	Metadata *string `json:"metadata,omitempty"`
-	Channel  *bool   `json:"channel,omitempty"`
-	Terminal *bool   `json:"terminal,omitempty"`
+	Metadata     *string                         `json:"metadata,omitempty"`
+	RegexRewrite *RegexRewriteApplyConfiguration `json:"regexRewrite,omitempty"`
+	Channel      *bool                           `json:"channel,omitempty"`
+	Terminal     *bool                           `json:"terminal,omitempty"`
 }
 
 // HashPolicyApplyConfiguration constructs an declarative configuration of the HashPolicy type for use with
@@ -40,6 +41,14 @@ func (b *HashPolicyApplyConfiguration) WithMetadata(value string) *HashPolicyApp
 	return b
 }
 
+// WithRegexRewrite sets the RegexRewrite field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RegexRewrite field is set to the value of the last call.
+func (b *HashPolicyApplyConfiguration) WithRegexRewrite(value *RegexRewriteApplyConfiguration) *HashPolicyApplyConfiguration {
+	b.RegexRewrite = value
+	return b
+}
+
 // WithChannel sets the Channel field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Channel field is set to the value of the last call.
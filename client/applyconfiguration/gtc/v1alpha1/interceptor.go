@@ -21,7 +21,8 @@ package v1alpha1
 // InterceptorApplyConfiguration represents an declarative configuration of the Interceptor type for use
 // with apply.
 type InterceptorApplyConfiguration struct {
-	Fault *FaultInterceptorApplyConfiguration `json:"fault,omitempty"`
+	Fault        *FaultInterceptorApplyConfiguration `json:"fault,omitempty"`
+	NodeSelector *NodeSelectorApplyConfiguration     `json:"nodeSelector,omitempty"`
 }
 
 // InterceptorApplyConfiguration constructs an declarative configuration of the Interceptor type for use with
@@ -37,3 +38,11 @@ func (b *InterceptorApplyConfiguration) WithFault(value *FaultInterceptorApplyCo
 	b.Fault = value
 	return b
 }
+
+// WithNodeSelector sets the NodeSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeSelector field is set to the value of the last call.
+func (b *InterceptorApplyConfiguration) WithNodeSelector(value *NodeSelectorApplyConfiguration) *InterceptorApplyConfiguration {
+	b.NodeSelector = value
+	return b
+}
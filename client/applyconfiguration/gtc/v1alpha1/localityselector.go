@@ -0,0 +1,57 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// LocalitySelectorApplyConfiguration represents an declarative configuration of the LocalitySelector type for use
+// with apply.
+type LocalitySelectorApplyConfiguration struct {
+	Region  *string `json:"region,omitempty"`
+	Zone    *string `json:"zone,omitempty"`
+	SubZone *string `json:"subZone,omitempty"`
+}
+
+// LocalitySelectorApplyConfiguration constructs an declarative configuration of the LocalitySelector type for use with
+// apply.
+func LocalitySelector() *LocalitySelectorApplyConfiguration {
+	return &LocalitySelectorApplyConfiguration{}
+}
+
+// WithRegion sets the Region field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Region field is set to the value of the last call.
+func (b *LocalitySelectorApplyConfiguration) WithRegion(value string) *LocalitySelectorApplyConfiguration {
+	b.Region = &value
+	return b
+}
+
+// WithZone sets the Zone field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Zone field is set to the value of the last call.
+func (b *LocalitySelectorApplyConfiguration) WithZone(value string) *LocalitySelectorApplyConfiguration {
+	b.Zone = &value
+	return b
+}
+
+// WithSubZone sets the SubZone field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SubZone field is set to the value of the last call.
+func (b *LocalitySelectorApplyConfiguration) WithSubZone(value string) *LocalitySelectorApplyConfiguration {
+	b.SubZone = &value
+	return b
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// NodeSelectorApplyConfiguration represents an declarative configuration of the NodeSelector type for use
+// with apply.
+type NodeSelectorApplyConfiguration struct {
+	ID       *string                             `json:"id,omitempty"`
+	Metadata map[string]string                   `json:"metadata,omitempty"`
+	Locality *LocalitySelectorApplyConfiguration `json:"locality,omitempty"`
+}
+
+// NodeSelectorApplyConfiguration constructs an declarative configuration of the NodeSelector type for use with
+// apply.
+func NodeSelector() *NodeSelectorApplyConfiguration {
+	return &NodeSelectorApplyConfiguration{}
+}
+
+// WithID sets the ID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ID field is set to the value of the last call.
+func (b *NodeSelectorApplyConfiguration) WithID(value string) *NodeSelectorApplyConfiguration {
+	b.ID = &value
+	return b
+}
+
+// WithMetadata puts the entries into the Metadata field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Metadata field,
+// overwriting an existing map entries in Metadata field with the same key.
+func (b *NodeSelectorApplyConfiguration) WithMetadata(entries map[string]string) *NodeSelectorApplyConfiguration {
+	if b.Metadata == nil && len(entries) > 0 {
+		b.Metadata = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Metadata[k] = v
+	}
+	return b
+}
+
+// WithLocality sets the Locality field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Locality field is set to the value of the last call.
+func (b *NodeSelectorApplyConfiguration) WithLocality(value *LocalitySelectorApplyConfiguration) *NodeSelectorApplyConfiguration {
+	b.Locality = value
+	return b
+}
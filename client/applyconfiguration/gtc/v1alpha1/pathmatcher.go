@@ -21,9 +21,10 @@ package v1alpha1
 // PathMatcherApplyConfiguration represents an declarative configuration of the PathMatcher type for use
 // with apply.
 type PathMatcherApplyConfiguration struct {
-	Prefix *string                         `json:"prefix,omitempty"`
-	Path   *string                         `json:"path,omitempty"`
-	Regex  *RegexMatcherApplyConfiguration `json:"regex,omitempty"`
+	Prefix        *string                         `json:"prefix,omitempty"`
+	Path          *string                         `json:"path,omitempty"`
+	Regex         *RegexMatcherApplyConfiguration `json:"regex,omitempty"`
+	CaseSensitive *bool                           `json:"caseSensitive,omitempty"`
 }
 
 // PathMatcherApplyConfiguration constructs an declarative configuration of the PathMatcher type for use with
@@ -55,3 +56,11 @@ func (b *PathMatcherApplyConfiguration) WithRegex(value *RegexMatcherApplyConfig
 	b.Regex = value
 	return b
 }
+
+// WithCaseSensitive sets the CaseSensitive field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CaseSensitive field is set to the value of the last call.
+func (b *PathMatcherApplyConfiguration) WithCaseSensitive(value bool) *PathMatcherApplyConfiguration {
+	b.CaseSensitive = &value
+	return b
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodsRefApplyConfiguration represents an declarative configuration of the PodsRef type for use
+// with apply.
+type PodsRefApplyConfiguration struct {
+	Namespace *string                    `json:"namespace,omitempty"`
+	Selector  *v1.LabelSelector          `json:"selector,omitempty"`
+	Port      *PortRefApplyConfiguration `json:"port,omitempty"`
+}
+
+// PodsRefApplyConfiguration constructs an declarative configuration of the PodsRef type for use with
+// apply.
+func PodsRef() *PodsRefApplyConfiguration {
+	return &PodsRefApplyConfiguration{}
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *PodsRefApplyConfiguration) WithNamespace(value string) *PodsRefApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithSelector sets the Selector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Selector field is set to the value of the last call.
+func (b *PodsRefApplyConfiguration) WithSelector(value v1.LabelSelector) *PodsRefApplyConfiguration {
+	b.Selector = &value
+	return b
+}
+
+// WithPort sets the Port field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Port field is set to the value of the last call.
+func (b *PodsRefApplyConfiguration) WithPort(value *PortRefApplyConfiguration) *PodsRefApplyConfiguration {
+	b.Port = value
+	return b
+}
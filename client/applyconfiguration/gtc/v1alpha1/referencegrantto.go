@@ -0,0 +1,48 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ReferenceGrantToApplyConfiguration represents an declarative configuration of the ReferenceGrantTo type for use
+// with apply.
+type ReferenceGrantToApplyConfiguration struct {
+	Kind *string `json:"kind,omitempty"`
+	Name *string `json:"name,omitempty"`
+}
+
+// ReferenceGrantToApplyConfiguration constructs an declarative configuration of the ReferenceGrantTo type for use with
+// apply.
+func ReferenceGrantTo() *ReferenceGrantToApplyConfiguration {
+	return &ReferenceGrantToApplyConfiguration{}
+}
+
+// WithKind sets the Kind field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Kind field is set to the value of the last call.
+func (b *ReferenceGrantToApplyConfiguration) WithKind(value string) *ReferenceGrantToApplyConfiguration {
+	b.Kind = &value
+	return b
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ReferenceGrantToApplyConfiguration) WithName(value string) *ReferenceGrantToApplyConfiguration {
+	b.Name = &value
+	return b
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// RegexRewriteApplyConfiguration represents an declarative configuration of the RegexRewrite type for use
+// with apply.
+type RegexRewriteApplyConfiguration struct {
+	Pattern      *RegexMatcherApplyConfiguration `json:"pattern,omitempty"`
+	Substitution *string                         `json:"substitution,omitempty"`
+}
+
+// RegexRewriteApplyConfiguration constructs an declarative configuration of the RegexRewrite type for use with
+// apply.
+func RegexRewrite() *RegexRewriteApplyConfiguration {
+	return &RegexRewriteApplyConfiguration{}
+}
+
+// WithPattern sets the Pattern field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Pattern field is set to the value of the last call.
+func (b *RegexRewriteApplyConfiguration) WithPattern(value *RegexMatcherApplyConfiguration) *RegexRewriteApplyConfiguration {
+	b.Pattern = value
+	return b
+}
+
+// WithSubstitution sets the Substitution field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Substitution field is set to the value of the last call.
+func (b *RegexRewriteApplyConfiguration) WithSubstitution(value string) *RegexRewriteApplyConfiguration {
+	b.Substitution = &value
+	return b
+}
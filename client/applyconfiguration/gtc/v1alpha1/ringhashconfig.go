@@ -21,8 +21,10 @@ package v1alpha1
 // RingHashConfigApplyConfiguration represents an declarative configuration of the RingHashConfig type for use
 // with apply.
 type RingHashConfigApplyConfiguration struct {
-	MinRingSize *uint64 `json:"minRingSize,omitempty"`
-	MaxRingSize *uint64 `json:"maxRingSize,omitempty"`
+	MinRingSize       *uint64                            `json:"minRingSize,omitempty"`
+	MaxRingSize       *uint64                            `json:"maxRingSize,omitempty"`
+	EndpointHashKey   *EndpointHashKeyApplyConfiguration `json:"endpointHashKey,omitempty"`
+	RequestHashHeader *string                            `json:"requestHashHeader,omitempty"`
 }
 
 // RingHashConfigApplyConfiguration constructs an declarative configuration of the RingHashConfig type for use with
@@ -46,3 +48,19 @@ func (b *RingHashConfigApplyConfiguration) WithMaxRingSize(value uint64) *RingHa
 	b.MaxRingSize = &value
 	return b
 }
+
+// WithEndpointHashKey sets the EndpointHashKey field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EndpointHashKey field is set to the value of the last call.
+func (b *RingHashConfigApplyConfiguration) WithEndpointHashKey(value *EndpointHashKeyApplyConfiguration) *RingHashConfigApplyConfiguration {
+	b.EndpointHashKey = value
+	return b
+}
+
+// WithRequestHashHeader sets the RequestHashHeader field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RequestHashHeader field is set to the value of the last call.
+func (b *RingHashConfigApplyConfiguration) WithRequestHashHeader(value string) *RingHashConfigApplyConfiguration {
+	b.RequestHashHeader = &value
+	return b
+}
@@ -25,13 +25,16 @@ import (
 // RouteApplyConfiguration represents an declarative configuration of the Route type for use
 // with apply.
 type RouteApplyConfiguration struct {
-	Matcher              *RouteMatcherApplyConfiguration `json:"matcher,omitempty"`
-	Interceptors         []InterceptorApplyConfiguration `json:"interceptors,omitempty"`
-	HashPolicy           []HashPolicyApplyConfiguration  `json:"hashPolicy,omitempty"`
-	MaxStreamDuration    *v1.Duration                    `json:"maxStreamDuration,omitempty"`
-	GrpcTimeoutHeaderMax *v1.Duration                    `json:"grpcTimeoutHeaderMax,omitempty"`
-	Retry                *RetryPolicyApplyConfiguration  `json:"retry,omitempty"`
-	Backends             []BackendApplyConfiguration     `json:"backends,omitempty"`
+	Matcher              *RouteMatcherApplyConfiguration  `json:"matcher,omitempty"`
+	Matchers             []RouteMatcherApplyConfiguration `json:"matchers,omitempty"`
+	Interceptors         []InterceptorApplyConfiguration  `json:"interceptors,omitempty"`
+	NodeSelector         *NodeSelectorApplyConfiguration  `json:"nodeSelector,omitempty"`
+	HashPolicy           []HashPolicyApplyConfiguration   `json:"hashPolicy,omitempty"`
+	MaxStreamDuration    *v1.Duration                     `json:"maxStreamDuration,omitempty"`
+	GrpcTimeoutHeaderMax *v1.Duration                     `json:"grpcTimeoutHeaderMax,omitempty"`
+	Retry                *RetryPolicyApplyConfiguration   `json:"retry,omitempty"`
+	Backends             []BackendApplyConfiguration      `json:"backends,omitempty"`
+	RouteLookup          *RouteLookupApplyConfiguration   `json:"routeLookup,omitempty"`
 }
 
 // RouteApplyConfiguration constructs an declarative configuration of the Route type for use with
@@ -48,6 +51,19 @@ func (b *RouteApplyConfiguration) WithMatcher(value *RouteMatcherApplyConfigurat
 	return b
 }
 
+// WithMatchers adds the given value to the Matchers field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Matchers field.
+func (b *RouteApplyConfiguration) WithMatchers(values ...*RouteMatcherApplyConfiguration) *RouteApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithMatchers")
+		}
+		b.Matchers = append(b.Matchers, *values[i])
+	}
+	return b
+}
+
 // WithInterceptors adds the given value to the Interceptors field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Interceptors field.
@@ -61,6 +77,14 @@ func (b *RouteApplyConfiguration) WithInterceptors(values ...*InterceptorApplyCo
 	return b
 }
 
+// WithNodeSelector sets the NodeSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeSelector field is set to the value of the last call.
+func (b *RouteApplyConfiguration) WithNodeSelector(value *NodeSelectorApplyConfiguration) *RouteApplyConfiguration {
+	b.NodeSelector = value
+	return b
+}
+
 // WithHashPolicy adds the given value to the HashPolicy field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the HashPolicy field.
@@ -110,3 +134,11 @@ func (b *RouteApplyConfiguration) WithBackends(values ...*BackendApplyConfigurat
 	}
 	return b
 }
+
+// WithRouteLookup sets the RouteLookup field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RouteLookup field is set to the value of the last call.
+func (b *RouteApplyConfiguration) WithRouteLookup(value *RouteLookupApplyConfiguration) *RouteApplyConfiguration {
+	b.RouteLookup = value
+	return b
+}
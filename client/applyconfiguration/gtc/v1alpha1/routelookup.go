@@ -0,0 +1,116 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RouteLookupApplyConfiguration represents an declarative configuration of the RouteLookup type for use
+// with apply.
+type RouteLookupApplyConfiguration struct {
+	LookupService        *string                                   `json:"lookupService,omitempty"`
+	LookupServiceTimeout *v1.Duration                              `json:"lookupServiceTimeout,omitempty"`
+	MaxAge               *v1.Duration                              `json:"maxAge,omitempty"`
+	StaleAge             *v1.Duration                              `json:"staleAge,omitempty"`
+	CacheSizeBytes       *int64                                    `json:"cacheSizeBytes,omitempty"`
+	KeyBuilders          []RouteLookupKeyBuilderApplyConfiguration `json:"keyBuilders,omitempty"`
+	Targets              []RouteLookupTargetApplyConfiguration     `json:"targets,omitempty"`
+	DefaultTarget        *string                                   `json:"defaultTarget,omitempty"`
+}
+
+// RouteLookupApplyConfiguration constructs an declarative configuration of the RouteLookup type for use with
+// apply.
+func RouteLookup() *RouteLookupApplyConfiguration {
+	return &RouteLookupApplyConfiguration{}
+}
+
+// WithLookupService sets the LookupService field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LookupService field is set to the value of the last call.
+func (b *RouteLookupApplyConfiguration) WithLookupService(value string) *RouteLookupApplyConfiguration {
+	b.LookupService = &value
+	return b
+}
+
+// WithLookupServiceTimeout sets the LookupServiceTimeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LookupServiceTimeout field is set to the value of the last call.
+func (b *RouteLookupApplyConfiguration) WithLookupServiceTimeout(value v1.Duration) *RouteLookupApplyConfiguration {
+	b.LookupServiceTimeout = &value
+	return b
+}
+
+// WithMaxAge sets the MaxAge field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxAge field is set to the value of the last call.
+func (b *RouteLookupApplyConfiguration) WithMaxAge(value v1.Duration) *RouteLookupApplyConfiguration {
+	b.MaxAge = &value
+	return b
+}
+
+// WithStaleAge sets the StaleAge field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StaleAge field is set to the value of the last call.
+func (b *RouteLookupApplyConfiguration) WithStaleAge(value v1.Duration) *RouteLookupApplyConfiguration {
+	b.StaleAge = &value
+	return b
+}
+
+// WithCacheSizeBytes sets the CacheSizeBytes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CacheSizeBytes field is set to the value of the last call.
+func (b *RouteLookupApplyConfiguration) WithCacheSizeBytes(value int64) *RouteLookupApplyConfiguration {
+	b.CacheSizeBytes = &value
+	return b
+}
+
+// WithKeyBuilders adds the given value to the KeyBuilders field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the KeyBuilders field.
+func (b *RouteLookupApplyConfiguration) WithKeyBuilders(values ...*RouteLookupKeyBuilderApplyConfiguration) *RouteLookupApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithKeyBuilders")
+		}
+		b.KeyBuilders = append(b.KeyBuilders, *values[i])
+	}
+	return b
+}
+
+// WithTargets adds the given value to the Targets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Targets field.
+func (b *RouteLookupApplyConfiguration) WithTargets(values ...*RouteLookupTargetApplyConfiguration) *RouteLookupApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithTargets")
+		}
+		b.Targets = append(b.Targets, *values[i])
+	}
+	return b
+}
+
+// WithDefaultTarget sets the DefaultTarget field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultTarget field is set to the value of the last call.
+func (b *RouteLookupApplyConfiguration) WithDefaultTarget(value string) *RouteLookupApplyConfiguration {
+	b.DefaultTarget = &value
+	return b
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// RouteLookupKeyBuilderApplyConfiguration represents an declarative configuration of the RouteLookupKeyBuilder type for use
+// with apply.
+type RouteLookupKeyBuilderApplyConfiguration struct {
+	Methods      []MethodMatcherApplyConfiguration          `json:"methods,omitempty"`
+	Metadata     []RouteLookupMetadataKeyApplyConfiguration `json:"metadata,omitempty"`
+	ConstantKeys map[string]string                          `json:"constantKeys,omitempty"`
+	HostKey      *string                                    `json:"hostKey,omitempty"`
+	ServiceKey   *string                                    `json:"serviceKey,omitempty"`
+	MethodKey    *string                                    `json:"methodKey,omitempty"`
+}
+
+// RouteLookupKeyBuilderApplyConfiguration constructs an declarative configuration of the RouteLookupKeyBuilder type for use with
+// apply.
+func RouteLookupKeyBuilder() *RouteLookupKeyBuilderApplyConfiguration {
+	return &RouteLookupKeyBuilderApplyConfiguration{}
+}
+
+// WithMethods adds the given value to the Methods field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Methods field.
+func (b *RouteLookupKeyBuilderApplyConfiguration) WithMethods(values ...*MethodMatcherApplyConfiguration) *RouteLookupKeyBuilderApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithMethods")
+		}
+		b.Methods = append(b.Methods, *values[i])
+	}
+	return b
+}
+
+// WithMetadata adds the given value to the Metadata field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Metadata field.
+func (b *RouteLookupKeyBuilderApplyConfiguration) WithMetadata(values ...*RouteLookupMetadataKeyApplyConfiguration) *RouteLookupKeyBuilderApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithMetadata")
+		}
+		b.Metadata = append(b.Metadata, *values[i])
+	}
+	return b
+}
+
+// WithConstantKeys puts the entries into the ConstantKeys field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the ConstantKeys field,
+// overwriting an existing map entries in ConstantKeys field with the same key.
+func (b *RouteLookupKeyBuilderApplyConfiguration) WithConstantKeys(entries map[string]string) *RouteLookupKeyBuilderApplyConfiguration {
+	if b.ConstantKeys == nil && len(entries) > 0 {
+		b.ConstantKeys = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.ConstantKeys[k] = v
+	}
+	return b
+}
+
+// WithHostKey sets the HostKey field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HostKey field is set to the value of the last call.
+func (b *RouteLookupKeyBuilderApplyConfiguration) WithHostKey(value string) *RouteLookupKeyBuilderApplyConfiguration {
+	b.HostKey = &value
+	return b
+}
+
+// WithServiceKey sets the ServiceKey field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceKey field is set to the value of the last call.
+func (b *RouteLookupKeyBuilderApplyConfiguration) WithServiceKey(value string) *RouteLookupKeyBuilderApplyConfiguration {
+	b.ServiceKey = &value
+	return b
+}
+
+// WithMethodKey sets the MethodKey field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MethodKey field is set to the value of the last call.
+func (b *RouteLookupKeyBuilderApplyConfiguration) WithMethodKey(value string) *RouteLookupKeyBuilderApplyConfiguration {
+	b.MethodKey = &value
+	return b
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// RouteLookupMetadataKeyApplyConfiguration represents an declarative configuration of the RouteLookupMetadataKey type for use
+// with apply.
+type RouteLookupMetadataKeyApplyConfiguration struct {
+	Key   *string  `json:"key,omitempty"`
+	Names []string `json:"names,omitempty"`
+}
+
+// RouteLookupMetadataKeyApplyConfiguration constructs an declarative configuration of the RouteLookupMetadataKey type for use with
+// apply.
+func RouteLookupMetadataKey() *RouteLookupMetadataKeyApplyConfiguration {
+	return &RouteLookupMetadataKeyApplyConfiguration{}
+}
+
+// WithKey sets the Key field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Key field is set to the value of the last call.
+func (b *RouteLookupMetadataKeyApplyConfiguration) WithKey(value string) *RouteLookupMetadataKeyApplyConfiguration {
+	b.Key = &value
+	return b
+}
+
+// WithNames adds the given value to the Names field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Names field.
+func (b *RouteLookupMetadataKeyApplyConfiguration) WithNames(values ...string) *RouteLookupMetadataKeyApplyConfiguration {
+	for i := range values {
+		b.Names = append(b.Names, values[i])
+	}
+	return b
+}
@@ -22,8 +22,11 @@ package v1alpha1
 // with apply.
 type RouteMatcherApplyConfiguration struct {
 	Method    *MethodMatcherApplyConfiguration    `json:"method,omitempty"`
+	Methods   []MethodMatcherApplyConfiguration   `json:"methods,omitempty"`
 	Service   *ServiceMatcherApplyConfiguration   `json:"service,omitempty"`
+	Services  []ServiceMatcherApplyConfiguration  `json:"services,omitempty"`
 	Namespace *string                             `json:"namespace,omitempty"`
+	Path      *PathMatcherApplyConfiguration      `json:"path,omitempty"`
 	Metadata  []MetadataMatcherApplyConfiguration `json:"metadata,omitempty"`
 	Fraction  *FractionApplyConfiguration         `json:"fraction,omitempty"`
 }
@@ -42,6 +45,19 @@ func (b *RouteMatcherApplyConfiguration) WithMethod(value *MethodMatcherApplyCon
 	return b
 }
 
+// WithMethods adds the given value to the Methods field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Methods field.
+func (b *RouteMatcherApplyConfiguration) WithMethods(values ...*MethodMatcherApplyConfiguration) *RouteMatcherApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithMethods")
+		}
+		b.Methods = append(b.Methods, *values[i])
+	}
+	return b
+}
+
 // WithService sets the Service field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Service field is set to the value of the last call.
@@ -50,6 +66,19 @@ func (b *RouteMatcherApplyConfiguration) WithService(value *ServiceMatcherApplyC
 	return b
 }
 
+// WithServices adds the given value to the Services field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Services field.
+func (b *RouteMatcherApplyConfiguration) WithServices(values ...*ServiceMatcherApplyConfiguration) *RouteMatcherApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithServices")
+		}
+		b.Services = append(b.Services, *values[i])
+	}
+	return b
+}
+
 // WithNamespace sets the Namespace field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Namespace field is set to the value of the last call.
@@ -58,6 +87,14 @@ func (b *RouteMatcherApplyConfiguration) WithNamespace(value string) *RouteMatch
 	return b
 }
 
+// WithPath sets the Path field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Path field is set to the value of the last call.
+func (b *RouteMatcherApplyConfiguration) WithPath(value *PathMatcherApplyConfiguration) *RouteMatcherApplyConfiguration {
+	b.Path = value
+	return b
+}
+
 // WithMetadata adds the given value to the Metadata field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Metadata field.
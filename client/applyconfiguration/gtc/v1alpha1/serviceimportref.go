@@ -0,0 +1,82 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ServiceImportRefApplyConfiguration represents an declarative configuration of the ServiceImportRef type for use
+// with apply.
+type ServiceImportRefApplyConfiguration struct {
+	ServiceRefApplyConfiguration `json:",inline"`
+	SourceClusters               []string `json:"sourceClusters,omitempty"`
+}
+
+// ServiceImportRefApplyConfiguration constructs an declarative configuration of the ServiceImportRef type for use with
+// apply.
+func ServiceImportRef() *ServiceImportRefApplyConfiguration {
+	return &ServiceImportRefApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ServiceImportRefApplyConfiguration) WithName(value string) *ServiceImportRefApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *ServiceImportRefApplyConfiguration) WithNamespace(value string) *ServiceImportRefApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithPort sets the Port field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Port field is set to the value of the last call.
+func (b *ServiceImportRefApplyConfiguration) WithPort(value *PortRefApplyConfiguration) *ServiceImportRefApplyConfiguration {
+	b.Port = value
+	return b
+}
+
+// WithAddressFamily sets the AddressFamily field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AddressFamily field is set to the value of the last call.
+func (b *ServiceImportRefApplyConfiguration) WithAddressFamily(value string) *ServiceImportRefApplyConfiguration {
+	b.AddressFamily = &value
+	return b
+}
+
+// WithCluster sets the Cluster field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cluster field is set to the value of the last call.
+func (b *ServiceImportRefApplyConfiguration) WithCluster(value string) *ServiceImportRefApplyConfiguration {
+	b.Cluster = &value
+	return b
+}
+
+// WithSourceClusters adds the given value to the SourceClusters field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the SourceClusters field.
+func (b *ServiceImportRefApplyConfiguration) WithSourceClusters(values ...string) *ServiceImportRefApplyConfiguration {
+	for i := range values {
+		b.SourceClusters = append(b.SourceClusters, values[i])
+	}
+	return b
+}
@@ -21,9 +21,11 @@ package v1alpha1
 // ServiceRefApplyConfiguration represents an declarative configuration of the ServiceRef type for use
 // with apply.
 type ServiceRefApplyConfiguration struct {
-	Name      *string                    `json:"name,omitempty"`
-	Namespace *string                    `json:"namespace,omitempty"`
-	Port      *PortRefApplyConfiguration `json:"port,omitempty"`
+	Name          *string                    `json:"name,omitempty"`
+	Namespace     *string                    `json:"namespace,omitempty"`
+	Port          *PortRefApplyConfiguration `json:"port,omitempty"`
+	AddressFamily *string                    `json:"addressFamily,omitempty"`
+	Cluster       *string                    `json:"cluster,omitempty"`
 }
 
 // ServiceRefApplyConfiguration constructs an declarative configuration of the ServiceRef type for use with
@@ -55,3 +57,19 @@ func (b *ServiceRefApplyConfiguration) WithPort(value *PortRefApplyConfiguration
 	b.Port = value
 	return b
 }
+
+// WithAddressFamily sets the AddressFamily field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AddressFamily field is set to the value of the last call.
+func (b *ServiceRefApplyConfiguration) WithAddressFamily(value string) *ServiceRefApplyConfiguration {
+	b.AddressFamily = &value
+	return b
+}
+
+// WithCluster sets the Cluster field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cluster field is set to the value of the last call.
+func (b *ServiceRefApplyConfiguration) WithCluster(value string) *ServiceRefApplyConfiguration {
+	b.Cluster = &value
+	return b
+}
@@ -31,10 +31,20 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 	// Group=api.gtc.dev, Version=v1alpha1
 	case v1alpha1.SchemeGroupVersion.WithKind("Backend"):
 		return &gtcv1alpha1.BackendApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("EndpointHashKey"):
+		return &gtcv1alpha1.EndpointHashKeyApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("FaultAbort"):
 		return &gtcv1alpha1.FaultAbortApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("FaultDelay"):
 		return &gtcv1alpha1.FaultDelayApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FaultExperiment"):
+		return &gtcv1alpha1.FaultExperimentApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FaultExperimentSpec"):
+		return &gtcv1alpha1.FaultExperimentSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FaultExperimentStatus"):
+		return &gtcv1alpha1.FaultExperimentStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FaultExperimentTarget"):
+		return &gtcv1alpha1.FaultExperimentTargetApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("FaultInterceptor"):
 		return &gtcv1alpha1.FaultInterceptorApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Fraction"):
@@ -43,6 +53,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &gtcv1alpha1.GRPCListenerApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("GRPCListenerSpec"):
 		return &gtcv1alpha1.GRPCListenerSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("GRPCListenerStatus"):
+		return &gtcv1alpha1.GRPCListenerStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("HashPolicy"):
 		return &gtcv1alpha1.HashPolicyApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("HeaderMatcher"):
@@ -51,16 +63,34 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &gtcv1alpha1.InterceptorApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Locality"):
 		return &gtcv1alpha1.LocalityApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("LocalitySelector"):
+		return &gtcv1alpha1.LocalitySelectorApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("MetadataMatcher"):
 		return &gtcv1alpha1.MetadataMatcherApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("MethodMatcher"):
 		return &gtcv1alpha1.MethodMatcherApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NodeSelector"):
+		return &gtcv1alpha1.NodeSelectorApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PathMatcher"):
+		return &gtcv1alpha1.PathMatcherApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PodsRef"):
+		return &gtcv1alpha1.PodsRefApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PortRef"):
 		return &gtcv1alpha1.PortRefApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RangeMatcher"):
 		return &gtcv1alpha1.RangeMatcherApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ReferenceGrant"):
+		return &gtcv1alpha1.ReferenceGrantApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ReferenceGrantFrom"):
+		return &gtcv1alpha1.ReferenceGrantFromApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ReferenceGrantSpec"):
+		return &gtcv1alpha1.ReferenceGrantSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ReferenceGrantTo"):
+		return &gtcv1alpha1.ReferenceGrantToApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RegexMatcher"):
 		return &gtcv1alpha1.RegexMatcherApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RegexRewrite"):
+		return &gtcv1alpha1.RegexRewriteApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RetryBackoff"):
 		return &gtcv1alpha1.RetryBackoffApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RetryPolicy"):
@@ -69,12 +99,26 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &gtcv1alpha1.RingHashConfigApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Route"):
 		return &gtcv1alpha1.RouteApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RouteLookup"):
+		return &gtcv1alpha1.RouteLookupApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RouteLookupKeyBuilder"):
+		return &gtcv1alpha1.RouteLookupKeyBuilderApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RouteLookupMetadataKey"):
+		return &gtcv1alpha1.RouteLookupMetadataKeyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RouteLookupTarget"):
+		return &gtcv1alpha1.RouteLookupTargetApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RouteMatcher"):
 		return &gtcv1alpha1.RouteMatcherApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SameNodePolicy"):
+		return &gtcv1alpha1.SameNodePolicyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ServiceImportRef"):
+		return &gtcv1alpha1.ServiceImportRefApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ServiceMatcher"):
 		return &gtcv1alpha1.ServiceMatcherApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ServiceRef"):
 		return &gtcv1alpha1.ServiceRefApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualHost"):
+		return &gtcv1alpha1.VirtualHostApplyConfiguration{}
 
 	}
 	return nil
@@ -0,0 +1,189 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v1alpha1 "github.com/jlevesy/grpc-traffic-controller/api/gtc/v1alpha1"
+	gtcv1alpha1 "github.com/jlevesy/grpc-traffic-controller/client/applyconfiguration/gtc/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeFaultExperiments implements FaultExperimentInterface
+type FakeFaultExperiments struct {
+	Fake *FakeApiV1alpha1
+	ns   string
+}
+
+var faultexperimentsResource = v1alpha1.SchemeGroupVersion.WithResource("faultexperiments")
+
+var faultexperimentsKind = v1alpha1.SchemeGroupVersion.WithKind("FaultExperiment")
+
+// Get takes name of the faultExperiment, and returns the corresponding faultExperiment object, and an error if there is any.
+func (c *FakeFaultExperiments) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.FaultExperiment, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(faultexperimentsResource, c.ns, name), &v1alpha1.FaultExperiment{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.FaultExperiment), err
+}
+
+// List takes label and field selectors, and returns the list of FaultExperiments that match those selectors.
+func (c *FakeFaultExperiments) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.FaultExperimentList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(faultexperimentsResource, faultexperimentsKind, c.ns, opts), &v1alpha1.FaultExperimentList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.FaultExperimentList{ListMeta: obj.(*v1alpha1.FaultExperimentList).ListMeta}
+	for _, item := range obj.(*v1alpha1.FaultExperimentList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested faultExperiments.
+func (c *FakeFaultExperiments) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(faultexperimentsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a faultExperiment and creates it.  Returns the server's representation of the faultExperiment, and an error, if there is any.
+func (c *FakeFaultExperiments) Create(ctx context.Context, faultExperiment *v1alpha1.FaultExperiment, opts v1.CreateOptions) (result *v1alpha1.FaultExperiment, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(faultexperimentsResource, c.ns, faultExperiment), &v1alpha1.FaultExperiment{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.FaultExperiment), err
+}
+
+// Update takes the representation of a faultExperiment and updates it. Returns the server's representation of the faultExperiment, and an error, if there is any.
+func (c *FakeFaultExperiments) Update(ctx context.Context, faultExperiment *v1alpha1.FaultExperiment, opts v1.UpdateOptions) (result *v1alpha1.FaultExperiment, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(faultexperimentsResource, c.ns, faultExperiment), &v1alpha1.FaultExperiment{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.FaultExperiment), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeFaultExperiments) UpdateStatus(ctx context.Context, faultExperiment *v1alpha1.FaultExperiment, opts v1.UpdateOptions) (*v1alpha1.FaultExperiment, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(faultexperimentsResource, "status", c.ns, faultExperiment), &v1alpha1.FaultExperiment{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.FaultExperiment), err
+}
+
+// Delete takes name of the faultExperiment and deletes it. Returns an error if one occurs.
+func (c *FakeFaultExperiments) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(faultexperimentsResource, c.ns, name, opts), &v1alpha1.FaultExperiment{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeFaultExperiments) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(faultexperimentsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.FaultExperimentList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched faultExperiment.
+func (c *FakeFaultExperiments) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.FaultExperiment, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(faultexperimentsResource, c.ns, name, pt, data, subresources...), &v1alpha1.FaultExperiment{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.FaultExperiment), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied faultExperiment.
+func (c *FakeFaultExperiments) Apply(ctx context.Context, faultExperiment *gtcv1alpha1.FaultExperimentApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.FaultExperiment, err error) {
+	if faultExperiment == nil {
+		return nil, fmt.Errorf("faultExperiment provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(faultExperiment)
+	if err != nil {
+		return nil, err
+	}
+	name := faultExperiment.Name
+	if name == nil {
+		return nil, fmt.Errorf("faultExperiment.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(faultexperimentsResource, c.ns, *name, types.ApplyPatchType, data), &v1alpha1.FaultExperiment{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.FaultExperiment), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeFaultExperiments) ApplyStatus(ctx context.Context, faultExperiment *gtcv1alpha1.FaultExperimentApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.FaultExperiment, err error) {
+	if faultExperiment == nil {
+		return nil, fmt.Errorf("faultExperiment provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(faultExperiment)
+	if err != nil {
+		return nil, err
+	}
+	name := faultExperiment.Name
+	if name == nil {
+		return nil, fmt.Errorf("faultExperiment.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(faultexperimentsResource, c.ns, *name, types.ApplyPatchType, data, "status"), &v1alpha1.FaultExperiment{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.FaultExperiment), err
+}
@@ -104,6 +104,18 @@ func (c *FakeGRPCListeners) Update(ctx context.Context, gRPCListener *v1alpha1.G
 	return obj.(*v1alpha1.GRPCListener), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeGRPCListeners) UpdateStatus(ctx context.Context, gRPCListener *v1alpha1.GRPCListener, opts v1.UpdateOptions) (*v1alpha1.GRPCListener, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(grpclistenersResource, "status", c.ns, gRPCListener), &v1alpha1.GRPCListener{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.GRPCListener), err
+}
+
 // Delete takes name of the gRPCListener and deletes it. Returns an error if one occurs.
 func (c *FakeGRPCListeners) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
@@ -152,3 +164,26 @@ func (c *FakeGRPCListeners) Apply(ctx context.Context, gRPCListener *gtcv1alpha1
 	}
 	return obj.(*v1alpha1.GRPCListener), err
 }
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeGRPCListeners) ApplyStatus(ctx context.Context, gRPCListener *gtcv1alpha1.GRPCListenerApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.GRPCListener, err error) {
+	if gRPCListener == nil {
+		return nil, fmt.Errorf("gRPCListener provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(gRPCListener)
+	if err != nil {
+		return nil, err
+	}
+	name := gRPCListener.Name
+	if name == nil {
+		return nil, fmt.Errorf("gRPCListener.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(grpclistenersResource, c.ns, *name, types.ApplyPatchType, data, "status"), &v1alpha1.GRPCListener{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.GRPCListener), err
+}
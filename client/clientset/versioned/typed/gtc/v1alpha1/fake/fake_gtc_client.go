@@ -28,10 +28,18 @@ type FakeApiV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeApiV1alpha1) FaultExperiments(namespace string) v1alpha1.FaultExperimentInterface {
+	return &FakeFaultExperiments{c, namespace}
+}
+
 func (c *FakeApiV1alpha1) GRPCListeners(namespace string) v1alpha1.GRPCListenerInterface {
 	return &FakeGRPCListeners{c, namespace}
 }
 
+func (c *FakeApiV1alpha1) ReferenceGrants(namespace string) v1alpha1.ReferenceGrantInterface {
+	return &FakeReferenceGrants{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeApiV1alpha1) RESTClient() rest.Interface {
@@ -0,0 +1,256 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+	"time"
+
+	v1alpha1 "github.com/jlevesy/grpc-traffic-controller/api/gtc/v1alpha1"
+	gtcv1alpha1 "github.com/jlevesy/grpc-traffic-controller/client/applyconfiguration/gtc/v1alpha1"
+	scheme "github.com/jlevesy/grpc-traffic-controller/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// FaultExperimentsGetter has a method to return a FaultExperimentInterface.
+// A group's client should implement this interface.
+type FaultExperimentsGetter interface {
+	FaultExperiments(namespace string) FaultExperimentInterface
+}
+
+// FaultExperimentInterface has methods to work with FaultExperiment resources.
+type FaultExperimentInterface interface {
+	Create(ctx context.Context, faultExperiment *v1alpha1.FaultExperiment, opts v1.CreateOptions) (*v1alpha1.FaultExperiment, error)
+	Update(ctx context.Context, faultExperiment *v1alpha1.FaultExperiment, opts v1.UpdateOptions) (*v1alpha1.FaultExperiment, error)
+	UpdateStatus(ctx context.Context, faultExperiment *v1alpha1.FaultExperiment, opts v1.UpdateOptions) (*v1alpha1.FaultExperiment, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.FaultExperiment, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.FaultExperimentList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.FaultExperiment, err error)
+	Apply(ctx context.Context, faultExperiment *gtcv1alpha1.FaultExperimentApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.FaultExperiment, err error)
+	ApplyStatus(ctx context.Context, faultExperiment *gtcv1alpha1.FaultExperimentApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.FaultExperiment, err error)
+	FaultExperimentExpansion
+}
+
+// faultExperiments implements FaultExperimentInterface
+type faultExperiments struct {
+	client rest.Interface
+	ns     string
+}
+
+// newFaultExperiments returns a FaultExperiments
+func newFaultExperiments(c *ApiV1alpha1Client, namespace string) *faultExperiments {
+	return &faultExperiments{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the faultExperiment, and returns the corresponding faultExperiment object, and an error if there is any.
+func (c *faultExperiments) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.FaultExperiment, err error) {
+	result = &v1alpha1.FaultExperiment{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("faultexperiments").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of FaultExperiments that match those selectors.
+func (c *faultExperiments) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.FaultExperimentList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.FaultExperimentList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("faultexperiments").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested faultExperiments.
+func (c *faultExperiments) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("faultexperiments").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a faultExperiment and creates it.  Returns the server's representation of the faultExperiment, and an error, if there is any.
+func (c *faultExperiments) Create(ctx context.Context, faultExperiment *v1alpha1.FaultExperiment, opts v1.CreateOptions) (result *v1alpha1.FaultExperiment, err error) {
+	result = &v1alpha1.FaultExperiment{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("faultexperiments").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(faultExperiment).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a faultExperiment and updates it. Returns the server's representation of the faultExperiment, and an error, if there is any.
+func (c *faultExperiments) Update(ctx context.Context, faultExperiment *v1alpha1.FaultExperiment, opts v1.UpdateOptions) (result *v1alpha1.FaultExperiment, err error) {
+	result = &v1alpha1.FaultExperiment{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("faultexperiments").
+		Name(faultExperiment.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(faultExperiment).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *faultExperiments) UpdateStatus(ctx context.Context, faultExperiment *v1alpha1.FaultExperiment, opts v1.UpdateOptions) (result *v1alpha1.FaultExperiment, err error) {
+	result = &v1alpha1.FaultExperiment{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("faultexperiments").
+		Name(faultExperiment.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(faultExperiment).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the faultExperiment and deletes it. Returns an error if one occurs.
+func (c *faultExperiments) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("faultexperiments").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *faultExperiments) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("faultexperiments").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched faultExperiment.
+func (c *faultExperiments) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.FaultExperiment, err error) {
+	result = &v1alpha1.FaultExperiment{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("faultexperiments").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied faultExperiment.
+func (c *faultExperiments) Apply(ctx context.Context, faultExperiment *gtcv1alpha1.FaultExperimentApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.FaultExperiment, err error) {
+	if faultExperiment == nil {
+		return nil, fmt.Errorf("faultExperiment provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(faultExperiment)
+	if err != nil {
+		return nil, err
+	}
+	name := faultExperiment.Name
+	if name == nil {
+		return nil, fmt.Errorf("faultExperiment.Name must be provided to Apply")
+	}
+	result = &v1alpha1.FaultExperiment{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("faultexperiments").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *faultExperiments) ApplyStatus(ctx context.Context, faultExperiment *gtcv1alpha1.FaultExperimentApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.FaultExperiment, err error) {
+	if faultExperiment == nil {
+		return nil, fmt.Errorf("faultExperiment provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(faultExperiment)
+	if err != nil {
+		return nil, err
+	}
+
+	name := faultExperiment.Name
+	if name == nil {
+		return nil, fmt.Errorf("faultExperiment.Name must be provided to Apply")
+	}
+
+	result = &v1alpha1.FaultExperiment{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("faultexperiments").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -18,4 +18,8 @@ limitations under the License.
 
 package v1alpha1
 
+type FaultExperimentExpansion interface{}
+
 type GRPCListenerExpansion interface{}
+
+type ReferenceGrantExpansion interface{}
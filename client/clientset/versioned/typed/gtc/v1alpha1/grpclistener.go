@@ -43,6 +43,7 @@ type GRPCListenersGetter interface {
 type GRPCListenerInterface interface {
 	Create(ctx context.Context, gRPCListener *v1alpha1.GRPCListener, opts v1.CreateOptions) (*v1alpha1.GRPCListener, error)
 	Update(ctx context.Context, gRPCListener *v1alpha1.GRPCListener, opts v1.UpdateOptions) (*v1alpha1.GRPCListener, error)
+	UpdateStatus(ctx context.Context, gRPCListener *v1alpha1.GRPCListener, opts v1.UpdateOptions) (*v1alpha1.GRPCListener, error)
 	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
 	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
 	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.GRPCListener, error)
@@ -50,6 +51,7 @@ type GRPCListenerInterface interface {
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.GRPCListener, err error)
 	Apply(ctx context.Context, gRPCListener *gtcv1alpha1.GRPCListenerApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.GRPCListener, err error)
+	ApplyStatus(ctx context.Context, gRPCListener *gtcv1alpha1.GRPCListenerApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.GRPCListener, err error)
 	GRPCListenerExpansion
 }
 
@@ -139,6 +141,22 @@ func (c *gRPCListeners) Update(ctx context.Context, gRPCListener *v1alpha1.GRPCL
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *gRPCListeners) UpdateStatus(ctx context.Context, gRPCListener *v1alpha1.GRPCListener, opts v1.UpdateOptions) (result *v1alpha1.GRPCListener, err error) {
+	result = &v1alpha1.GRPCListener{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("grpclisteners").
+		Name(gRPCListener.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(gRPCListener).
+		Do(ctx).
+		Into(result)
+	return
+}
+
 // Delete takes name of the gRPCListener and deletes it. Returns an error if one occurs.
 func (c *gRPCListeners) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	return c.client.Delete().
@@ -206,3 +224,33 @@ func (c *gRPCListeners) Apply(ctx context.Context, gRPCListener *gtcv1alpha1.GRP
 		Into(result)
 	return
 }
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *gRPCListeners) ApplyStatus(ctx context.Context, gRPCListener *gtcv1alpha1.GRPCListenerApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.GRPCListener, err error) {
+	if gRPCListener == nil {
+		return nil, fmt.Errorf("gRPCListener provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(gRPCListener)
+	if err != nil {
+		return nil, err
+	}
+
+	name := gRPCListener.Name
+	if name == nil {
+		return nil, fmt.Errorf("gRPCListener.Name must be provided to Apply")
+	}
+
+	result = &v1alpha1.GRPCListener{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("grpclisteners").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -28,7 +28,9 @@ import (
 
 type ApiV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	FaultExperimentsGetter
 	GRPCListenersGetter
+	ReferenceGrantsGetter
 }
 
 // ApiV1alpha1Client is used to interact with features provided by the api.gtc.dev group.
@@ -36,10 +38,18 @@ type ApiV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *ApiV1alpha1Client) FaultExperiments(namespace string) FaultExperimentInterface {
+	return newFaultExperiments(c, namespace)
+}
+
 func (c *ApiV1alpha1Client) GRPCListeners(namespace string) GRPCListenerInterface {
 	return newGRPCListeners(c, namespace)
 }
 
+func (c *ApiV1alpha1Client) ReferenceGrants(namespace string) ReferenceGrantInterface {
+	return newReferenceGrants(c, namespace)
+}
+
 // NewForConfig creates a new ApiV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
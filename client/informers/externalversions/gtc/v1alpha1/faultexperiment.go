@@ -0,0 +1,90 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	gtcv1alpha1 "github.com/jlevesy/grpc-traffic-controller/api/gtc/v1alpha1"
+	versioned "github.com/jlevesy/grpc-traffic-controller/client/clientset/versioned"
+	internalinterfaces "github.com/jlevesy/grpc-traffic-controller/client/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/jlevesy/grpc-traffic-controller/client/listers/gtc/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// FaultExperimentInformer provides access to a shared informer and lister for
+// FaultExperiments.
+type FaultExperimentInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.FaultExperimentLister
+}
+
+type faultExperimentInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewFaultExperimentInformer constructs a new informer for FaultExperiment type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFaultExperimentInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredFaultExperimentInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredFaultExperimentInformer constructs a new informer for FaultExperiment type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredFaultExperimentInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ApiV1alpha1().FaultExperiments(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ApiV1alpha1().FaultExperiments(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&gtcv1alpha1.FaultExperiment{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *faultExperimentInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredFaultExperimentInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *faultExperimentInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&gtcv1alpha1.FaultExperiment{}, f.defaultInformer)
+}
+
+func (f *faultExperimentInformer) Lister() v1alpha1.FaultExperimentLister {
+	return v1alpha1.NewFaultExperimentLister(f.Informer().GetIndexer())
+}
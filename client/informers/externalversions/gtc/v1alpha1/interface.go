@@ -24,8 +24,12 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// FaultExperiments returns a FaultExperimentInformer.
+	FaultExperiments() FaultExperimentInformer
 	// GRPCListeners returns a GRPCListenerInformer.
 	GRPCListeners() GRPCListenerInformer
+	// ReferenceGrants returns a ReferenceGrantInformer.
+	ReferenceGrants() ReferenceGrantInformer
 }
 
 type version struct {
@@ -39,7 +43,17 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// FaultExperiments returns a FaultExperimentInformer.
+func (v *version) FaultExperiments() FaultExperimentInformer {
+	return &faultExperimentInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // GRPCListeners returns a GRPCListenerInformer.
 func (v *version) GRPCListeners() GRPCListenerInformer {
 	return &gRPCListenerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// ReferenceGrants returns a ReferenceGrantInformer.
+func (v *version) ReferenceGrants() ReferenceGrantInformer {
+	return &referenceGrantInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -18,6 +18,14 @@ limitations under the License.
 
 package v1alpha1
 
+// FaultExperimentListerExpansion allows custom methods to be added to
+// FaultExperimentLister.
+type FaultExperimentListerExpansion interface{}
+
+// FaultExperimentNamespaceListerExpansion allows custom methods to be added to
+// FaultExperimentNamespaceLister.
+type FaultExperimentNamespaceListerExpansion interface{}
+
 // GRPCListenerListerExpansion allows custom methods to be added to
 // GRPCListenerLister.
 type GRPCListenerListerExpansion interface{}
@@ -25,3 +33,11 @@ type GRPCListenerListerExpansion interface{}
 // GRPCListenerNamespaceListerExpansion allows custom methods to be added to
 // GRPCListenerNamespaceLister.
 type GRPCListenerNamespaceListerExpansion interface{}
+
+// ReferenceGrantListerExpansion allows custom methods to be added to
+// ReferenceGrantLister.
+type ReferenceGrantListerExpansion interface{}
+
+// ReferenceGrantNamespaceListerExpansion allows custom methods to be added to
+// ReferenceGrantNamespaceLister.
+type ReferenceGrantNamespaceListerExpansion interface{}
@@ -0,0 +1,99 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/jlevesy/grpc-traffic-controller/api/gtc/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// FaultExperimentLister helps list FaultExperiments.
+// All objects returned here must be treated as read-only.
+type FaultExperimentLister interface {
+	// List lists all FaultExperiments in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.FaultExperiment, err error)
+	// FaultExperiments returns an object that can list and get FaultExperiments.
+	FaultExperiments(namespace string) FaultExperimentNamespaceLister
+	FaultExperimentListerExpansion
+}
+
+// faultExperimentLister implements the FaultExperimentLister interface.
+type faultExperimentLister struct {
+	indexer cache.Indexer
+}
+
+// NewFaultExperimentLister returns a new FaultExperimentLister.
+func NewFaultExperimentLister(indexer cache.Indexer) FaultExperimentLister {
+	return &faultExperimentLister{indexer: indexer}
+}
+
+// List lists all FaultExperiments in the indexer.
+func (s *faultExperimentLister) List(selector labels.Selector) (ret []*v1alpha1.FaultExperiment, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.FaultExperiment))
+	})
+	return ret, err
+}
+
+// FaultExperiments returns an object that can list and get FaultExperiments.
+func (s *faultExperimentLister) FaultExperiments(namespace string) FaultExperimentNamespaceLister {
+	return faultExperimentNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// FaultExperimentNamespaceLister helps list and get FaultExperiments.
+// All objects returned here must be treated as read-only.
+type FaultExperimentNamespaceLister interface {
+	// List lists all FaultExperiments in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.FaultExperiment, err error)
+	// Get retrieves the FaultExperiment from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.FaultExperiment, error)
+	FaultExperimentNamespaceListerExpansion
+}
+
+// faultExperimentNamespaceLister implements the FaultExperimentNamespaceLister
+// interface.
+type faultExperimentNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all FaultExperiments in the indexer for a given namespace.
+func (s faultExperimentNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.FaultExperiment, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.FaultExperiment))
+	})
+	return ret, err
+}
+
+// Get retrieves the FaultExperiment from the indexer for a given namespace and name.
+func (s faultExperimentNamespaceLister) Get(name string) (*v1alpha1.FaultExperiment, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("faultexperiment"), name)
+	}
+	return obj.(*v1alpha1.FaultExperiment), nil
+}
@@ -14,17 +14,21 @@ import (
 
 func main() {
 	var (
-		out       string
-		serverURI string
-		provider  string
+		out      string
+		server   bootstrap.ServerConfig
+		provider string
 	)
 
-	flag.StringVar(&serverURI, "server-uri", "", "uri of the xds server")
+	flag.StringVar(&server.URI, "server-uri", "", "uri of the xds server")
+	flag.StringVar(&server.CACertificateFile, "ca-file", "", "path to the CA bundle verifying the xds server, enables TLS")
+	flag.StringVar(&server.CertificateFile, "cert-file", "", "path to the client certificate, enables certificate authentication")
+	flag.StringVar(&server.PrivateKeyFile, "key-file", "", "path to the client certificate private key")
+	flag.StringVar(&server.TokenFile, "token-file", "", "path to a projected ServiceAccount token, enables token authentication")
 	flag.StringVar(&out, "out", "./bootstrap.json", "path to write the generated config")
 	flag.StringVar(&provider, "provider", bootstrap.ProviderTypeEnv, "provider to use")
 	flag.Parse()
 
-	if serverURI == "" {
+	if server.URI == "" {
 		log.Fatal("please provide a server-uri")
 	}
 
@@ -36,7 +40,7 @@ func main() {
 		log.Fatal("unable to build config provider", err)
 	}
 
-	cfg, err := configProvider.Provide(ctx, serverURI)
+	cfg, err := configProvider.Provide(ctx, server)
 	if err != nil {
 		log.Fatal("unable to retrieve bootstrap config", err)
 	}
@@ -18,21 +18,28 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"flag"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	gtcinformers "github.com/jlevesy/grpc-traffic-controller/client/informers/externalversions"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 
 	gtcapi "github.com/jlevesy/grpc-traffic-controller/client/clientset/versioned"
 	"github.com/jlevesy/grpc-traffic-controller/gtc"
@@ -43,11 +50,29 @@ func main() {
 		xdsAddr  string
 		httpAddr string
 		logLevel string
+
+		tlsCertFile     string
+		tlsKeyFile      string
+		tlsClientCAFile string
+		requireAuth     bool
+		tokenAuth       bool
+		tokenAudiences  string
+		authorization   bool
+
+		remoteClusterSecrets string
 	)
 
 	flag.StringVar(&xdsAddr, "xds-bind-address", ":18000", "The address the xds server binds to.")
 	flag.StringVar(&httpAddr, "http-bind-address", ":8081", "The address the http server binds to.")
 	flag.StringVar(&logLevel, "log-level", "info", "Log Level")
+	flag.StringVar(&tlsCertFile, "tls-cert-file", "", "Path to the xds server certificate, enables TLS.")
+	flag.StringVar(&tlsKeyFile, "tls-key-file", "", "Path to the xds server certificate private key.")
+	flag.StringVar(&tlsClientCAFile, "tls-client-ca-file", "", "Path to the CA bundle used to verify client certificates, enables client certificate authentication.")
+	flag.BoolVar(&requireAuth, "require-client-auth", false, "Reject xds clients that can't be authenticated.")
+	flag.BoolVar(&tokenAuth, "token-auth", false, "Authenticate xds clients using ServiceAccount tokens.")
+	flag.StringVar(&tokenAudiences, "token-audiences", "", "Comma separated list of audiences ServiceAccount tokens must be issued for.")
+	flag.BoolVar(&authorization, "authorization", false, "Restrict authenticated xds clients to the GRPCListeners of their namespace, or allowing their namespace. Rejects unauthenticated clients.")
+	flag.StringVar(&remoteClusterSecrets, "remote-cluster-secrets", "", "Comma separated list of namespace/name of Secrets holding the kubeconfig of a remote cluster under the kubeconfig key. Clusters are named after their Secret.")
 	flag.Parse()
 
 	logger := zap.Must(newLogger(logLevel))
@@ -73,6 +98,22 @@ func main() {
 		return
 	}
 
+	tlsConfig, err := newTLSConfig(tlsCertFile, tlsKeyFile, tlsClientCAFile)
+	if err != nil {
+		logger.Error("Can't build TLS config", zap.Error(err))
+		return
+	}
+
+	authConfig := gtc.AuthenticationConfig{Required: requireAuth}
+
+	if tokenAuth {
+		authConfig.TokenReviews = kubeClient.AuthenticationV1().TokenReviews()
+	}
+
+	if tokenAudiences != "" {
+		authConfig.Audiences = strings.Split(tokenAudiences, ",")
+	}
+
 	var (
 		kubeInformerFactory = kubeinformers.NewSharedInformerFactory(
 			kubeClient,
@@ -84,12 +125,23 @@ func main() {
 		)
 	)
 
+	remoteClusters, err := newRemoteClusters(ctx, kubeClient, remoteClusterSecrets)
+	if err != nil {
+		logger.Error("Can't configure remote clusters", zap.Error(err))
+		return
+	}
+
 	server, err := gtc.NewXDSServer(
 		ctx,
 		gtc.XDSServerConfig{
-			BindAddr:     xdsAddr,
-			K8sInformers: kubeInformerFactory,
-			GTCInformers: gtcInformerFactory,
+			BindAddr:       xdsAddr,
+			TLS:            tlsConfig,
+			Authentication: authConfig,
+			Authorization:  gtc.AuthorizationConfig{Enabled: authorization},
+			K8sInformers:   kubeInformerFactory,
+			GTCInformers:   gtcInformerFactory,
+			GTCClient:      gtcClient,
+			RemoteClusters: remoteClusters,
 		},
 		logger,
 	)
@@ -105,6 +157,10 @@ func main() {
 	gtcInformerFactory.Start(ctx.Done())
 	kubeInformerFactory.Start(ctx.Done())
 
+	for _, informerFactory := range remoteClusters {
+		informerFactory.Start(ctx.Done())
+	}
+
 	group.Go(func() error {
 		return server.Run(ctx)
 	})
@@ -144,6 +200,8 @@ func runWebserver(ctx context.Context, addr string, logger *zap.Logger) error {
 		_, _ = rw.Write([]byte("ok"))
 	})
 
+	serveMux.Handle("/metrics", promhttp.Handler())
+
 	srv := &http.Server{
 		Addr:           addr,
 		Handler:        serveMux,
@@ -173,6 +231,87 @@ func runWebserver(ctx context.Context, addr string, logger *zap.Logger) error {
 	return nil
 }
 
+func newTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	if certFile == "" && keyFile == "" {
+		if clientCAFile != "" {
+			return nil, errors.New("client certificate authentication requires TLS")
+		}
+
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in %q", clientCAFile)
+		}
+
+		tlsConfig.ClientCAs = pool
+		// Clients might authenticate using a token instead.
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return &tlsConfig, nil
+}
+
+// remoteClusterKubeconfigKey is the key of the kubeconfig in the Secret of a remote cluster.
+const remoteClusterKubeconfigKey = "kubeconfig"
+
+// newRemoteClusters builds the informers of the remote clusters which kubeconfig is held by the given namespace/name Secrets.
+func newRemoteClusters(ctx context.Context, kubeClient kubernetes.Interface, secretRefs string) (map[string]kubeinformers.SharedInformerFactory, error) {
+	if secretRefs == "" {
+		return nil, nil
+	}
+
+	remoteClusters := make(map[string]kubeinformers.SharedInformerFactory)
+
+	for _, secretRef := range strings.Split(secretRefs, ",") {
+		namespace, name, ok := strings.Cut(secretRef, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid secret reference %q, expected namespace/name", secretRef)
+		}
+
+		secret, err := kubeClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		kubeconfig, ok := secret.Data[remoteClusterKubeconfigKey]
+		if !ok {
+			return nil, fmt.Errorf("secret %q has no %q key", secretRef, remoteClusterKubeconfigKey)
+		}
+
+		restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kubeconfig in secret %q: %w", secretRef, err)
+		}
+
+		remoteClient, err := kubernetes.NewForConfig(restConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		remoteClusters[name] = kubeinformers.NewSharedInformerFactory(remoteClient, 60*time.Minute)
+	}
+
+	return remoteClusters, nil
+}
+
 func newLogger(lvl string) (*zap.Logger, error) {
 	if lvl == "debug" {
 		return zap.NewDevelopment()
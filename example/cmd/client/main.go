@@ -14,6 +14,7 @@ import (
 	"google.golang.org/grpc/status"
 	_ "google.golang.org/grpc/xds"
 
+	_ "github.com/jlevesy/grpc-traffic-controller/bootstrap/xdscreds"
 	echo "github.com/jlevesy/grpc-traffic-controller/pkg/echoserver/proto"
 )
 
@@ -0,0 +1,225 @@
+package gtc
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	authenticationv1client "k8s.io/client-go/kubernetes/typed/authentication/v1"
+)
+
+const (
+	identitySourceCertificate    = "certificate"
+	identitySourceServiceAccount = "serviceaccount"
+
+	serviceAccountUsernamePrefix = "system:serviceaccount:"
+)
+
+// AuthenticationConfig configures how the xDS server authenticates its clients.
+type AuthenticationConfig struct {
+	// Required rejects any stream that can't be authenticated either by a client certificate or a ServiceAccount token.
+	// If false, clients presenting no credentials are let through, but clients presenting invalid credentials are still rejected.
+	Required bool
+	// TokenReviews allows to authenticate clients presenting a ServiceAccount token. Tokens are rejected if nil.
+	TokenReviews authenticationv1client.TokenReviewInterface
+	// Audiences are the audiences a ServiceAccount token must be issued for.
+	// If empty, the audience of the kubernetes API server is assumed.
+	Audiences []string
+}
+
+func (c AuthenticationConfig) enabled() bool {
+	return c.Required || c.TokenReviews != nil
+}
+
+// clientIdentity is the identity of an authenticated xDS client.
+type clientIdentity struct {
+	source    string
+	namespace string
+	name      string
+}
+
+func (i clientIdentity) String() string {
+	if i.namespace == "" {
+		return i.source + ":" + i.name
+	}
+
+	return i.source + ":" + i.namespace + "/" + i.name
+}
+
+type identityKey struct{}
+
+func withClientIdentity(ctx context.Context, id clientIdentity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+func clientIdentityFromContext(ctx context.Context) (clientIdentity, bool) {
+	id, ok := ctx.Value(identityKey{}).(clientIdentity)
+	return id, ok
+}
+
+type authenticator struct {
+	cfg    AuthenticationConfig
+	logger *zap.Logger
+}
+
+func (a *authenticator) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	id, ok, err := a.authenticate(ss.Context())
+	if err != nil {
+		a.logger.Info("Rejecting xDS stream", zap.Error(err), zap.String("method", info.FullMethod))
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if !ok {
+		if a.cfg.Required {
+			a.logger.Info("Rejecting unauthenticated xDS stream", zap.String("method", info.FullMethod))
+			return status.Error(codes.Unauthenticated, "no client credentials provided")
+		}
+
+		return handler(srv, ss)
+	}
+
+	a.logger.Debug("Authenticated xDS client", zap.Stringer("identity", id))
+
+	return handler(srv, &identifiedStream{
+		ServerStream: ss,
+		ctx:          withClientIdentity(ss.Context(), id),
+	})
+}
+
+// authenticate returns the identity of the client, false if the client didn't provide any credentials
+// or an error if the provided credentials are invalid.
+func (a *authenticator) authenticate(ctx context.Context) (clientIdentity, bool, error) {
+	if token, ok := bearerToken(ctx); ok {
+		id, err := a.reviewToken(ctx, token)
+		return id, err == nil, err
+	}
+
+	if cert, ok := peerCertificate(ctx); ok {
+		id, err := certificateIdentity(cert)
+		return id, err == nil, err
+	}
+
+	return clientIdentity{}, false, nil
+}
+
+func (a *authenticator) reviewToken(ctx context.Context, token string) (clientIdentity, error) {
+	if a.cfg.TokenReviews == nil {
+		return clientIdentity{}, errors.New("token authentication is not enabled")
+	}
+
+	review, err := a.cfg.TokenReviews.Create(
+		ctx,
+		&authenticationv1.TokenReview{
+			Spec: authenticationv1.TokenReviewSpec{
+				Token:     token,
+				Audiences: a.cfg.Audiences,
+			},
+		},
+		metav1.CreateOptions{},
+	)
+	if err != nil {
+		return clientIdentity{}, fmt.Errorf("unable to review token: %w", err)
+	}
+
+	if !review.Status.Authenticated {
+		return clientIdentity{}, fmt.Errorf("invalid token: %s", review.Status.Error)
+	}
+
+	username := review.Status.User.Username
+	if !strings.HasPrefix(username, serviceAccountUsernamePrefix) {
+		return clientIdentity{}, fmt.Errorf("token does not belong to a ServiceAccount: %q", username)
+	}
+
+	namespace, name, ok := strings.Cut(strings.TrimPrefix(username, serviceAccountUsernamePrefix), ":")
+	if !ok {
+		return clientIdentity{}, fmt.Errorf("malformed ServiceAccount username %q", username)
+	}
+
+	return clientIdentity{
+		source:    identitySourceServiceAccount,
+		namespace: namespace,
+		name:      name,
+	}, nil
+}
+
+// certificateIdentity extracts an identity from a verified client certificate.
+// It understands SPIFFE IDs in the form spiffe://<trust-domain>/ns/<namespace>/sa/<name>,
+// and falls back on the common name of the certificate.
+func certificateIdentity(cert *x509.Certificate) (clientIdentity, error) {
+	for _, uri := range cert.URIs {
+		if uri.Scheme != "spiffe" {
+			continue
+		}
+
+		sp := strings.Split(strings.TrimPrefix(uri.Path, "/"), "/")
+		if len(sp) != 4 || sp[0] != "ns" || sp[2] != "sa" {
+			return clientIdentity{}, fmt.Errorf("unsupported SPIFFE ID %q", uri.String())
+		}
+
+		return clientIdentity{
+			source:    identitySourceCertificate,
+			namespace: sp[1],
+			name:      sp[3],
+		}, nil
+	}
+
+	if cert.Subject.CommonName == "" {
+		return clientIdentity{}, errors.New("client certificate has no SPIFFE ID nor common name")
+	}
+
+	return clientIdentity{
+		source: identitySourceCertificate,
+		name:   cert.Subject.CommonName,
+	}, nil
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	for _, v := range md.Get("authorization") {
+		if token, ok := strings.CutPrefix(v, "Bearer "); ok && token != "" {
+			return token, true
+		}
+	}
+
+	return "", false
+}
+
+func peerCertificate(ctx context.Context) (*x509.Certificate, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+
+	return tlsInfo.State.VerifiedChains[0][0], true
+}
+
+// identifiedStream carries the identity of the client in its context.
+type identifiedStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s *identifiedStream) Context() context.Context {
+	return s.ctx
+}
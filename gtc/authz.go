@@ -0,0 +1,235 @@
+package gtc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcesv3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+
+	gtcv1alpha1 "github.com/jlevesy/grpc-traffic-controller/api/gtc/v1alpha1"
+	gtclisters "github.com/jlevesy/grpc-traffic-controller/client/listers/gtc/v1alpha1"
+)
+
+// AuthorizationConfig configures which GRPCListeners a client is allowed to subscribe to.
+type AuthorizationConfig struct {
+	// Enabled restricts clients to the GRPCListeners of their own namespace and to the ones allowing
+	// their namespace using the gtc.dev/allowed-client-namespaces annotation. Unauthenticated clients are rejected.
+	// If false, any client can subscribe to any GRPCListener.
+	Enabled bool
+}
+
+// authorizer leaves the resources of GRPCListeners a client is not allowed to read out of the responses sent to it,
+// the client sees them as missing while its other subscriptions keep being served on the same stream.
+// The namespace of a client is taken from its authenticated identity, streams of unauthenticated clients are rejected:
+// the node ID of a client is chosen by the client itself, it can't be trusted.
+type authorizer struct {
+	grpcListeners gtclisters.GRPCListenerLister
+	logger        *zap.Logger
+
+	mu      sync.Mutex
+	streams map[int64]*authorizedStream
+}
+
+// authorizedStream is the state of an authenticated stream.
+type authorizedStream struct {
+	identity clientIdentity
+	// denied holds the subscriptions denied by the last request of each type, for them to be reported only once.
+	denied map[string]map[string]struct{}
+}
+
+func newAuthorizer(grpcListeners gtclisters.GRPCListenerLister, logger *zap.Logger) *authorizer {
+	return &authorizer{
+		grpcListeners: grpcListeners,
+		logger:        logger.With(zap.String("component", "authorizer")),
+		streams:       make(map[int64]*authorizedStream),
+	}
+}
+
+func (a *authorizer) OnStreamOpen(ctx context.Context, id int64, _ string) error {
+	identity, ok := clientIdentityFromContext(ctx)
+	if !ok {
+		a.logger.Info("Denied unauthenticated stream", zap.Int64("stream_id", id))
+
+		return status.Error(codes.Unauthenticated, "authorization requires an authenticated client")
+	}
+
+	a.mu.Lock()
+	a.streams[id] = &authorizedStream{identity: identity, denied: make(map[string]map[string]struct{})}
+	a.mu.Unlock()
+
+	return nil
+}
+
+func (a *authorizer) OnStreamClosed(id int64, _ *corev3.Node) {
+	a.mu.Lock()
+	delete(a.streams, id)
+	a.mu.Unlock()
+}
+
+// OnStreamRequest reports the subscriptions denied by a request, their resources are left out of the responses.
+func (a *authorizer) OnStreamRequest(id int64, req *discoveryv3.DiscoveryRequest) error {
+	clientNamespace := a.clientNamespace(id)
+	denied := make(map[string]struct{})
+
+	for _, resourceName := range req.ResourceNames {
+		listener, err := a.subscribedListener(req.TypeUrl, resourceName, req.Node)
+		if err != nil {
+			return err
+		}
+
+		if listener == nil || allowsClient(listener, clientNamespace) {
+			continue
+		}
+
+		denied[resourceName] = struct{}{}
+
+		// Requests acknowledging a response repeat the subscriptions of the stream.
+		if a.wasDenied(id, req.TypeUrl, resourceName) {
+			continue
+		}
+
+		deniedSubscriptionsTotal.WithLabelValues(req.TypeUrl, listener.Namespace).Inc()
+
+		a.logger.Info(
+			"Denied subscription",
+			zap.Int64("stream_id", id),
+			zap.String("client_namespace", clientNamespace),
+			zap.String("type", req.TypeUrl),
+			zap.String("resource_name", resourceName),
+		)
+	}
+
+	a.mu.Lock()
+	if stream, ok := a.streams[id]; ok {
+		stream.denied[req.TypeUrl] = denied
+	}
+	a.mu.Unlock()
+
+	return nil
+}
+
+// OnStreamResponse leaves out of the response the resources the client is not allowed to read.
+// They are checked again at each response, as the GRPCListener allowing the client can change.
+func (a *authorizer) OnStreamResponse(_ context.Context, id int64, req *discoveryv3.DiscoveryRequest, resp *discoveryv3.DiscoveryResponse) {
+	var (
+		clientNamespace = a.clientNamespace(id)
+		allowed         = make([]*anypb.Any, 0, len(resp.Resources))
+	)
+
+	for _, res := range resp.Resources {
+		msg, err := res.UnmarshalNew()
+		if err != nil {
+			a.logger.Error("Could not decode resource", zap.Error(err), zap.String("type", resp.TypeUrl))
+			continue
+		}
+
+		listener, err := a.subscribedListener(resp.TypeUrl, cache.GetResourceName(msg), req.Node)
+		if err != nil {
+			a.logger.Error("Could not authorize resource", zap.Error(err), zap.String("type", resp.TypeUrl))
+			continue
+		}
+
+		if listener == nil || allowsClient(listener, clientNamespace) {
+			allowed = append(allowed, res)
+		}
+	}
+
+	resp.Resources = allowed
+}
+
+func (a *authorizer) clientNamespace(id int64) string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	stream, ok := a.streams[id]
+	if !ok {
+		return ""
+	}
+
+	return stream.identity.namespace
+}
+
+// wasDenied returns true if the previous request of the given type on the stream was denied the given subscription.
+func (a *authorizer) wasDenied(id int64, typeURL, resourceName string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	stream, ok := a.streams[id]
+	if !ok {
+		return false
+	}
+
+	_, ok = stream.denied[typeURL][resourceName]
+
+	return ok
+}
+
+// allowsClient returns true if a client of the given namespace can read the listener.
+func allowsClient(listener *gtcv1alpha1.GRPCListener, clientNamespace string) bool {
+	if clientNamespace != "" && clientNamespace == listener.Namespace {
+		return true
+	}
+
+	return allowsClientNamespace(listener, clientNamespace)
+}
+
+func allowsClientNamespace(listener *gtcv1alpha1.GRPCListener, clientNamespace string) bool {
+	allowedNamespaces, ok := listener.Annotations[gtcv1alpha1.AnnotationAllowedClientNamespaces]
+	if !ok {
+		return false
+	}
+
+	for _, ns := range strings.Split(allowedNamespaces, ",") {
+		ns = strings.TrimSpace(ns)
+
+		if ns == "*" || (clientNamespace != "" && ns == clientNamespace) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// subscribedListener returns the GRPCListener backing an xDS resource, resolved as it would be for the given node.
+// It returns nil if there is none, letting the resolution report it.
+func (a *authorizer) subscribedListener(typeURL, resourceName string, node *corev3.Node) (*gtcv1alpha1.GRPCListener, error) {
+	var (
+		listener *gtcv1alpha1.GRPCListener
+		err      error
+	)
+
+	switch typeURL {
+	case resourcesv3.ListenerType:
+		listener, err = resolveListener(a.grpcListeners, resourceName, node)
+	case resourcesv3.ClusterType, resourcesv3.EndpointType:
+		backendRef, parseErr := parseBackendName(resourceName)
+		if parseErr != nil {
+			return nil, nil
+		}
+
+		listener, err = a.grpcListeners.GRPCListeners(backendRef.Namespace).Get(backendRef.ListenerName)
+	default:
+		return nil, nil
+	}
+
+	var malformedErr malformedListenerResourceNameError
+
+	switch {
+	case kerrors.IsNotFound(err), errors.As(err, &malformedErr):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return listener, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/envoyproxy/go-control-plane/pkg/server/stream/v3"
 	gtclisters "github.com/jlevesy/grpc-traffic-controller/client/listers/gtc/v1alpha1"
 	"go.uber.org/zap"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	discoveryv1listers "k8s.io/client-go/listers/discovery/v1"
 )
 
@@ -19,16 +20,25 @@ type configWatcher struct {
 	logger *zap.Logger
 }
 
-func newConfigWatcher(endpointSlicesLister discoveryv1listers.EndpointSliceLister, grpcListenersLister gtclisters.GRPCListenerLister, watches watchBuilder, logger *zap.Logger) *configWatcher {
+func newConfigWatcher(endpointSlicesLister discoveryv1listers.EndpointSliceLister, remoteEndpointSlicesListers map[string]discoveryv1listers.EndpointSliceLister, podsLister corev1listers.PodLister, nodesLister corev1listers.NodeLister, grpcListenersLister gtclisters.GRPCListenerLister, referenceGrantsLister gtclisters.ReferenceGrantLister, faultExperimentsLister gtclisters.FaultExperimentLister, watches watchBuilder, logger *zap.Logger) *configWatcher {
+	clientTopologies := topologies{pods: podsLister, nodes: nodesLister}
+
 	return &configWatcher{
 		logger:       logger.With(zap.String("component", "config_watcher")),
 		watchBuilder: watches,
 		resolver: resourceTypeResolver{
-			resourcesv3.ListenerType: &listenerHandler{grpcListeners: grpcListenersLister},
-			resourcesv3.ClusterType:  &clusterHandler{grpcListeners: grpcListenersLister},
+			resourcesv3.ListenerType: &listenerHandler{
+				grpcListeners:    grpcListenersLister,
+				faultExperiments: faultExperiments{lister: faultExperimentsLister},
+				topologies:       clientTopologies,
+			},
+			resourcesv3.ClusterType: &clusterHandler{grpcListeners: grpcListenersLister},
 			resourcesv3.EndpointType: &endpointHandler{
-				grpcListeners:  grpcListenersLister,
-				endpointSlices: endpointSlicesLister,
+				grpcListeners:        grpcListenersLister,
+				endpointSlices:       endpointSlicesLister,
+				remoteEndpointSlices: remoteEndpointSlicesListers,
+				referenceGrants:      referenceGrants{lister: referenceGrantsLister},
+				topologies:           clientTopologies,
 			},
 		},
 	}
@@ -0,0 +1,44 @@
+package gtc
+
+import (
+	"context"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	sotwv3 "github.com/envoyproxy/go-control-plane/pkg/server/sotw/v3"
+)
+
+// callbacks calls each of its callbacks in order, stopping at the first error.
+type callbacks []sotwv3.Callbacks
+
+func (cs callbacks) OnStreamOpen(ctx context.Context, id int64, typ string) error {
+	for _, c := range cs {
+		if err := c.OnStreamOpen(ctx, id, typ); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cs callbacks) OnStreamClosed(id int64, n *corev3.Node) {
+	for _, c := range cs {
+		c.OnStreamClosed(id, n)
+	}
+}
+
+func (cs callbacks) OnStreamRequest(id int64, req *discoveryv3.DiscoveryRequest) error {
+	for _, c := range cs {
+		if err := c.OnStreamRequest(id, req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cs callbacks) OnStreamResponse(ctx context.Context, id int64, req *discoveryv3.DiscoveryRequest, resp *discoveryv3.DiscoveryResponse) {
+	for _, c := range cs {
+		c.OnStreamResponse(ctx, id, req, resp)
+	}
+}
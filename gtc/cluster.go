@@ -4,11 +4,15 @@ import (
 	"fmt"
 	"strings"
 
+	xdstypev3 "github.com/cncf/xds/go/xds/type/v3"
 	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	ringhashv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/load_balancing_policies/ring_hash/v3"
 	resourcesv3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	gtcv1alpha1 "github.com/jlevesy/grpc-traffic-controller/api/gtc/v1alpha1"
 	gtclisters "github.com/jlevesy/grpc-traffic-controller/client/listers/gtc/v1alpha1"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
@@ -35,9 +39,14 @@ func (h *clusterHandler) resolveResource(req resolveRequest) (*resolveResponse,
 			return nil, err
 		}
 
+		xdsCluster, err := makeCluster(resourceName, backend)
+		if err != nil {
+			return nil, err
+		}
+
 		response.resources[i], err = encodeResource(
 			req.typeUrl,
-			makeCluster(resourceName, backend),
+			xdsCluster,
 		)
 		if err != nil {
 			return nil, err
@@ -51,7 +60,10 @@ func (h *clusterHandler) resolveResource(req resolveRequest) (*resolveResponse,
 	return response, nil
 }
 
-func makeCluster(clusterName string, spec gtcv1alpha1.Backend) *cluster.Cluster {
+// grpcRingHashBalancerName is the name of the ring hash balancer registered by gRPC.
+const grpcRingHashBalancerName = "ring_hash_experimental"
+
+func makeCluster(clusterName string, spec gtcv1alpha1.Backend) (*cluster.Cluster, error) {
 	c := cluster.Cluster{
 		Name:                 clusterName,
 		LbPolicy:             makeLBPolicy(spec.LBPolicy),
@@ -87,30 +99,85 @@ func makeCluster(clusterName string, spec gtcv1alpha1.Backend) *cluster.Cluster
 		}
 	}
 
-	return &c
+	if spec.RingHashConfig != nil && spec.RingHashConfig.RequestHashHeader != "" {
+		lbPolicy, err := makeRequestHashHeaderPolicy(*spec.RingHashConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		c.LoadBalancingPolicy = lbPolicy
+	}
+
+	return &c, nil
 }
 
-var emptyBackend gtcv1alpha1.Backend
+// makeRequestHashHeaderPolicy configures the gRPC ring hash balancer directly, as xDS has no way to express the request hash header of gRFC A76.
+// The regular ring hash policy comes next, for clients not knowing about the gRPC balancer.
+func makeRequestHashHeaderPolicy(config gtcv1alpha1.RingHashConfig) (*cluster.LoadBalancingPolicy, error) {
+	grpcConfig, err := structpb.NewStruct(
+		map[string]any{
+			"minRingSize":       config.MinRingSize,
+			"maxRingSize":       config.MaxRingSize,
+			"requestHashHeader": config.RequestHashHeader,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
 
-func findBackendSpec(backendRef parsedBackendName, listener *gtcv1alpha1.GRPCListener) (gtcv1alpha1.Backend, error) {
-	if backendRef.RouteID > len(listener.Spec.Routes)-1 {
-		return emptyBackend, &routeNotFoundError{
-			wantRouteID: backendRef.RouteID,
-			listener:    listener,
-		}
+	grpcPolicy, err := anypb.New(
+		&xdstypev3.TypedStruct{
+			TypeUrl: "type.googleapis.com/" + grpcRingHashBalancerName,
+			Value:   grpcConfig,
+		},
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	route := listener.Spec.Routes[backendRef.RouteID]
+	ringHashPolicy, err := anypb.New(
+		&ringhashv3.RingHash{
+			HashFunction:    ringhashv3.RingHash_XX_HASH,
+			MinimumRingSize: wrapperspb.UInt64(config.MinRingSize),
+			MaximumRingSize: wrapperspb.UInt64(config.MaxRingSize),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cluster.LoadBalancingPolicy{
+		Policies: []*cluster.LoadBalancingPolicy_Policy{
+			{
+				TypedExtensionConfig: &core.TypedExtensionConfig{
+					Name:        grpcRingHashBalancerName,
+					TypedConfig: grpcPolicy,
+				},
+			},
+			{
+				TypedExtensionConfig: &core.TypedExtensionConfig{
+					Name:        "envoy.load_balancing_policies.ring_hash",
+					TypedConfig: ringHashPolicy,
+				},
+			},
+		},
+	}, nil
+}
+
+var emptyBackend gtcv1alpha1.Backend
 
-	if backendRef.BackendID > len(route.Backends)-1 {
-		return emptyBackend, &backendNotFoundError{
-			routeID:       backendRef.RouteID,
-			wantBackendID: backendRef.BackendID,
-			listener:      listener,
+// findBackendSpec returns the spec of the first backend of the listener with the given cluster name.
+func findBackendSpec(backendRef parsedBackendName, listener *gtcv1alpha1.GRPCListener) (gtcv1alpha1.Backend, error) {
+	for _, backend := range listenerBackends(listener) {
+		if backend.name == backendRef.String() {
+			return backend.spec, nil
 		}
 	}
 
-	return route.Backends[backendRef.BackendID], nil
+	return emptyBackend, &backendNotFoundError{
+		backendRef: backendRef,
+		listener:   listener,
+	}
 }
 
 func makeLBPolicy(p string) cluster.Cluster_LbPolicy {
@@ -125,31 +192,16 @@ func makeLBPolicy(p string) cluster.Cluster_LbPolicy {
 	}
 }
 
-type routeNotFoundError struct {
-	wantRouteID int
-	listener    *gtcv1alpha1.GRPCListener
-}
-
-func (c *routeNotFoundError) Error() string {
-	return fmt.Sprintf(
-		"route %d does not exist on GRPCListener %s/%s",
-		c.wantRouteID,
-		c.listener.Namespace,
-		c.listener.Name,
-	)
-}
-
 type backendNotFoundError struct {
-	routeID       int
-	wantBackendID int
-	listener      *gtcv1alpha1.GRPCListener
+	backendRef parsedBackendName
+	listener   *gtcv1alpha1.GRPCListener
 }
 
 func (c *backendNotFoundError) Error() string {
 	return fmt.Sprintf(
-		"backend with ID %d does not exist under the route %d of the GRPCListener %s/%s",
-		c.wantBackendID,
-		c.routeID,
+		"%s %s does not exist on the GRPCListener %s/%s",
+		c.backendRef.Kind,
+		c.backendRef.ID,
 		c.listener.Namespace,
 		c.listener.Name,
 	)
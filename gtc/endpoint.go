@@ -3,6 +3,9 @@ package gtc
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 
 	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
@@ -11,8 +14,12 @@ import (
 	resourcesv3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	gtcv1alpha1 "github.com/jlevesy/grpc-traffic-controller/api/gtc/v1alpha1"
 	gtclisters "github.com/jlevesy/grpc-traffic-controller/client/listers/gtc/v1alpha1"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
+	corev1 "k8s.io/api/core/v1"
 	kdiscoveryv1 "k8s.io/api/discovery/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
 	discoveryv1listers "k8s.io/client-go/listers/discovery/v1"
@@ -21,6 +28,11 @@ import (
 type endpointHandler struct {
 	grpcListeners  gtclisters.GRPCListenerLister
 	endpointSlices discoveryv1listers.EndpointSliceLister
+	// remoteEndpointSlices are the EndpointSlices listers of the remote clusters, by cluster name.
+	remoteEndpointSlices map[string]discoveryv1listers.EndpointSliceLister
+	referenceGrants      referenceGrants
+
+	topologies
 }
 
 func (h *endpointHandler) resolveResource(req resolveRequest) (*resolveResponse, error) {
@@ -72,6 +84,10 @@ func (h *endpointHandler) makeLoadAssignment(node *v3.Node, backendRef parsedBac
 		return h.makeServiceLoadAssignment(node, backendRef, listener, backendSpec)
 	case len(backendSpec.Localities) > 0:
 		return h.makeLocalitiesLoadAssignment(backendRef, listener, backendSpec)
+	case backendSpec.Pods != nil:
+		return h.makePodsLoadAssignment(node, backendRef, listener, backendSpec)
+	case backendSpec.ServiceImport != nil:
+		return h.makeServiceImportLoadAssignment(node, backendRef, listener, backendSpec)
 	default:
 		return nil, nil, errors.New("unsupported non k8s service locality")
 	}
@@ -88,32 +104,22 @@ func (h *endpointHandler) makeLocalitiesLoadAssignment(backendRef parsedBackendN
 	)
 
 	for i, loc := range clusterSpec.Localities {
-		ns := loc.Service.Namespace
-		if ns == "" {
-			ns = listener.Namespace
-		}
-
-		req, err := labels.NewRequirement(
-			"kubernetes.io/service-name",
-			selection.Equals,
-			[]string{loc.Service.Name},
-		)
+		endpointSlices, grantVersions, err := h.listEndpointSlices(listener, serviceReference(listener, *loc.Service), clusterSpec.Subset)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		endpointSlices, err := h.endpointSlices.EndpointSlices(ns).List(
-			labels.NewSelector().Add(*req),
-		)
-		if err != nil {
-			return nil, nil, err
-		}
+		versions = append(versions, grantVersions...)
+
+		var podVersions []string
 
-		result.Endpoints[i], err = makeFlatLocalityLbEndpoints(*loc.Service, endpointSlices, loc.Weight, loc.Priority)
+		result.Endpoints[i], podVersions, err = h.makeFlatLocalityLbEndpoints(*loc.Service, endpointSlices, loc.Weight, loc.Priority, clusterSpec)
 		if err != nil {
 			return nil, nil, err
 		}
 
+		versions = append(versions, podVersions...)
+
 		for _, s := range endpointSlices {
 			versions = append(versions, s.ResourceVersion)
 		}
@@ -127,212 +133,787 @@ func (h *endpointHandler) makeServiceLoadAssignment(node *v3.Node, backendRef pa
 		ClusterName: backendRef.String(),
 	}
 
-	ns := clusterSpec.Service.Namespace
-	if ns == "" {
-		ns = listener.Namespace
+	endpointSlices, versions, err := h.listEndpointSlices(listener, serviceReference(listener, *clusterSpec.Service), clusterSpec.Subset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	endpoints, endpointVersions, err := h.makeServiceEndpoints(node, clusterSpec, *clusterSpec.Service, endpointSlices)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result.Endpoints = endpoints
+	versions = append(versions, endpointVersions...)
+
+	for _, s := range endpointSlices {
+		versions = append(versions, s.ResourceVersion)
+	}
+
+	return &result, versions, nil
+}
+
+// listEndpointSlices returns the endpoint slices of a service or a service import referenced by a listener, restricted to the given subset,
+// alongside the versions of the ReferenceGrants and Pods involved.
+// No endpoint slices are returned if the listener is not allowed to reference the service.
+func (h *endpointHandler) listEndpointSlices(listener *gtcv1alpha1.GRPCListener, ref reference, subset *metav1.LabelSelector) ([]*kdiscoveryv1.EndpointSlice, []string, error) {
+	allowed, grantVersions, err := h.referenceGrants.allows(listener, ref)
+	if err != nil || !allowed {
+		return nil, grantVersions, err
 	}
 
 	req, err := labels.NewRequirement(
-		"kubernetes.io/service-name",
+		serviceNameLabel(ref.kind),
 		selection.Equals,
-		[]string{clusterSpec.Service.Name},
+		[]string{ref.name},
 	)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	endpointSlices, err := h.endpointSlices.EndpointSlices(ns).List(
+	if ref.cluster != "" && subset != nil {
+		return nil, nil, errors.New("subsets are not supported for services of remote clusters")
+	}
+
+	lister, err := h.endpointSlicesLister(ref.cluster)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	endpointSlices, err := lister.EndpointSlices(ref.namespace).List(
 		labels.NewSelector().Add(*req),
 	)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	result.Endpoints, err = makeServiceEndpoints(node, *clusterSpec.Service, endpointSlices)
+	// Listers don't guarantee any order, sort the endpoint slices to keep a stable version.
+	sort.Slice(endpointSlices, func(i, j int) bool { return endpointSlices[i].Name < endpointSlices[j].Name })
+
+	endpointSlices, podVersions, err := h.selectSubset(subset, endpointSlices)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	versions := make([]string, len(endpointSlices))
+	return endpointSlices, append(grantVersions, podVersions...), nil
+}
 
-	for i, s := range endpointSlices {
-		versions[i] = s.ResourceVersion
+// endpointSlicesLister returns the EndpointSlices lister of the given cluster, the local one if empty.
+func (h *endpointHandler) endpointSlicesLister(cluster string) (discoveryv1listers.EndpointSliceLister, error) {
+	if cluster == "" {
+		return h.endpointSlices, nil
 	}
 
-	return &result, versions, nil
+	lister, ok := h.remoteEndpointSlices[cluster]
+	if !ok {
+		return nil, fmt.Errorf("unknown cluster %q", cluster)
+	}
+
+	return lister, nil
 }
 
-type endpointGroup struct {
-	zone string
+const (
+	// labelMCSServiceName is the label of the EndpointSlices of a ServiceImport, set to its name.
+	labelMCSServiceName = "multicluster.kubernetes.io/service-name"
+	// labelMCSSourceCluster is the label of the EndpointSlices of a ServiceImport, set to the cluster its endpoints come from.
+	labelMCSSourceCluster = "multicluster.kubernetes.io/source-cluster"
+)
 
-	endpoints []endpointData
-}
+// serviceNameLabel returns the label holding the name of the service of an EndpointSlice, for the given kind of service.
+func serviceNameLabel(kind string) string {
+	if kind == gtcv1alpha1.ReferenceKindServiceImport {
+		return labelMCSServiceName
+	}
 
-type endpointData struct {
-	port     uint32
-	endpoint kdiscoveryv1.Endpoint
+	return kdiscoveryv1.LabelServiceName
 }
 
-func makeServiceEndpoints(node *v3.Node, serviceRef gtcv1alpha1.ServiceRef, epSlices []*kdiscoveryv1.EndpointSlice) ([]*endpointv3.LocalityLbEndpoints, error) {
-	var currentZone string
+func (h *endpointHandler) makeServiceImportLoadAssignment(node *v3.Node, backendRef parsedBackendName, listener *gtcv1alpha1.GRPCListener, backendSpec gtcv1alpha1.Backend) (*endpointv3.ClusterLoadAssignment, []string, error) {
+	var (
+		result = endpointv3.ClusterLoadAssignment{
+			ClusterName: backendRef.String(),
+		}
+		serviceImportRef = *backendSpec.ServiceImport
+	)
+
+	endpointSlices, versions, err := h.listEndpointSlices(listener, serviceImportReference(listener, serviceImportRef), nil)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	if node.Locality != nil {
-		currentZone = node.Locality.Zone
+	for _, s := range endpointSlices {
+		versions = append(versions, s.ResourceVersion)
 	}
 
-	localityEndpointsByPrio := [2]*endpointGroup{}
+	var endpointVersions []string
+
+	if len(serviceImportRef.SourceClusters) > 0 {
+		result.Endpoints, endpointVersions, err = h.makeSourceClusterLocalities(backendSpec, serviceImportRef, endpointSlices)
+	} else {
+		result.Endpoints, endpointVersions, err = h.makeServiceEndpoints(node, backendSpec, serviceImportRef.ServiceRef, endpointSlices)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &result, append(versions, endpointVersions...), nil
+}
+
+// makeSourceClusterLocalities groups the endpoints of a service import in one locality per source cluster, prioritized in the order of the
+// source clusters of the reference. Unlisted clusters share the lowest priority, and empty priorities are skipped to avoid gaps.
+func (h *endpointHandler) makeSourceClusterLocalities(backendSpec gtcv1alpha1.Backend, serviceImportRef gtcv1alpha1.ServiceImportRef, epSlices []*kdiscoveryv1.EndpointSlice) ([]*endpointv3.LocalityLbEndpoints, []string, error) {
+	var (
+		clusters         []string
+		slicesByClusters = make(map[string][]*kdiscoveryv1.EndpointSlice)
+	)
 
 	for _, epSlice := range epSlices {
-		port, ok := lookupK8sPort(serviceRef.Port, epSlice.Ports)
-		if !ok {
-			return nil, errors.New("no desired port found on the k8s endpoint slice")
+		cluster := epSlice.Labels[labelMCSSourceCluster]
+
+		if _, ok := slicesByClusters[cluster]; !ok {
+			clusters = append(clusters, cluster)
+		}
+
+		slicesByClusters[cluster] = append(slicesByClusters[cluster], epSlice)
+	}
+
+	clusterPriority := func(cluster string) int {
+		if i := slices.Index(serviceImportRef.SourceClusters, cluster); i >= 0 {
+			return i
 		}
 
+		return len(serviceImportRef.SourceClusters)
+	}
+
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return clusterPriority(clusters[i]) < clusterPriority(clusters[j])
+	})
+
+	var (
+		result       []*endpointv3.LocalityLbEndpoints
+		versions     []string
+		priority     uint32
+		lastPriority = -1
+	)
+
+	for _, cluster := range clusters {
+		locality, podVersions, err := h.makeFlatLocalityLbEndpoints(serviceImportRef.ServiceRef, slicesByClusters[cluster], 0, 0, backendSpec)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(locality.LbEndpoints) == 0 {
+			continue
+		}
+
+		if lastPriority != -1 && clusterPriority(cluster) != lastPriority {
+			priority++
+		}
+
+		lastPriority = clusterPriority(cluster)
+
+		locality.Locality = &core.Locality{SubZone: cluster}
+		locality.Priority = priority
+		locality.LoadBalancingWeight = wrapperspb.UInt32(localityWeight(locality.LbEndpoints))
+
+		result = append(result, locality)
+		versions = append(versions, podVersions...)
+	}
+
+	return result, versions, nil
+}
+
+// selectSubset only keeps the endpoints targeting a pod matching the subset selector, alongside the versions of the pods looked up.
+// Endpoint slices are left untouched if no subset is given.
+func (h *endpointHandler) selectSubset(subset *metav1.LabelSelector, endpointSlices []*kdiscoveryv1.EndpointSlice) ([]*kdiscoveryv1.EndpointSlice, []string, error) {
+	if subset == nil {
+		return endpointSlices, nil, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(subset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		result   = make([]*kdiscoveryv1.EndpointSlice, len(endpointSlices))
+		versions []string
+	)
+
+	for i, epSlice := range endpointSlices {
+		// Shallow copy, we must not mutate the informer cache.
+		selected := *epSlice
+		selected.Endpoints = nil
+
 		for _, ep := range epSlice.Endpoints {
-			if !derefBool(ep.Conditions.Ready) {
+			if ep.TargetRef == nil || ep.TargetRef.Kind != "Pod" {
 				continue
 			}
 
-			priority := 1
-
-			if ep.Hints != nil && containsZone(ep.Hints.ForZones, currentZone) {
-				priority = 0
+			pod, err := h.pods.Pods(epSlice.Namespace).Get(ep.TargetRef.Name)
+			switch {
+			case kerrors.IsNotFound(err):
+				continue
+			case err != nil:
+				return nil, nil, err
 			}
 
-			prioGroup := localityEndpointsByPrio[priority]
-			if prioGroup == nil {
-				prioGroup = &endpointGroup{}
-			}
+			versions = append(versions, pod.ResourceVersion)
 
-			// loose capturing of the zone for the endpoint group.
-			// not great, not terrible, that's metadata anyway.
-			if prioGroup.zone == "" && ep.Zone != nil {
-				prioGroup.zone = *ep.Zone
+			if selector.Matches(labels.Set(pod.Labels)) {
+				selected.Endpoints = append(selected.Endpoints, ep)
 			}
+		}
 
-			prioGroup.endpoints = append(
-				prioGroup.endpoints,
-				endpointData{
-					port:     port,
-					endpoint: ep,
-				},
-			)
+		result[i] = &selected
+	}
 
-			localityEndpointsByPrio[priority] = prioGroup
+	return result, versions, nil
+}
+
+func (h *endpointHandler) makePodsLoadAssignment(node *v3.Node, backendRef parsedBackendName, listener *gtcv1alpha1.GRPCListener, backendSpec gtcv1alpha1.Backend) (*endpointv3.ClusterLoadAssignment, []string, error) {
+	var (
+		result = endpointv3.ClusterLoadAssignment{
+			ClusterName: backendRef.String(),
 		}
+		podsRef = backendSpec.Pods
+		ref     = podsReference(listener, *podsRef)
+	)
+
+	allowed, versions, err := h.referenceGrants.allows(listener, ref)
+	if err != nil || !allowed {
+		return &result, versions, err
 	}
 
-	var (
-		result []*endpointv3.LocalityLbEndpoints
+	selector, err := metav1.LabelSelectorAsSelector(&podsRef.Selector)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		// We consider that the distribution by prio should be accounted for
-		// if we have at least one endpoint that was hinted for the zone we're
-		// currently running in. Which means that we have at least one endpoint
-		// in the priority 0 endpointGroup.
-		enableZonePrio = localityEndpointsByPrio[0] != nil && len(localityEndpointsByPrio[0].endpoints) > 0
-	)
+	pods, err := h.pods.Pods(ref.namespace).List(selector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Listers don't guarantee any order, sort the pods to keep a stable version.
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+
+	client, clientNode, err := h.clientTopology(node)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The topology of the client might be looked up from its pod.
+	versions = append(versions, client.String(), clientNode)
+
+	var endpoints []prioritizedEndpoint
+
+	for _, pod := range pods {
+		port, ok := lookupPodPort(podsRef.Port, pod)
+		if !ok {
+			continue
+		}
 
-	for prio, prioGroup := range localityEndpointsByPrio {
-		if prioGroup == nil || len(prioGroup.endpoints) == 0 {
+		health, ok := podHealthStatus(pod, backendSpec.PublishNotReady)
+		if !ok {
 			continue
 		}
 
-		// Force priority zero if we detect that no hints are presents on all concerned endpoints.
-		var appliedPrio uint32
+		podTopology, err := h.nodeTopology(pod.Spec.NodeName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Nodes are updated way too often to account for their versions, only the topology of the pods matters.
+		versions = append(versions, pod.ResourceVersion, podTopology.String())
 
-		if enableZonePrio {
-			appliedPrio = uint32(prio)
+		proximity, ok := sameNodeProximity(backendSpec.SameNode, clientNode, pod.Spec.NodeName, client.proximity(podTopology))
+		if !ok {
+			continue
 		}
 
-		localityLbEndpoints := endpointv3.LocalityLbEndpoints{
-			Locality: &core.Locality{
-				Zone: prioGroup.zone,
-				SubZone: fmt.Sprintf(
-					"%s-%d",
-					serviceRef.Name,
-					appliedPrio,
-				),
-			},
-			LoadBalancingWeight: wrapperspb.UInt32(1),
-			Priority:            appliedPrio,
+		if len(pod.Status.PodIPs) == 0 {
+			continue
 		}
 
-		for _, ep := range prioGroup.endpoints {
-			localityLbEndpoints.LbEndpoints = append(
-				localityLbEndpoints.LbEndpoints,
-				makeLbEndpoints(ep.endpoint, ep.port)...,
-			)
+		// A dual-stack pod is a single endpoint, its primary IP being its address.
+		addrs := make([]*core.SocketAddress, len(pod.Status.PodIPs))
+
+		for i, podIP := range pod.Status.PodIPs {
+			addrs[i] = makeSocketAddress(podIP.IP, port)
 		}
 
-		result = append(result, &localityLbEndpoints)
+		endpoints = append(
+			endpoints,
+			prioritizedEndpoint{
+				topology:  podTopology,
+				proximity: proximity,
+				endpoint:  makeLbEndpoint(addrs, health, makePodAttributes(pod, backendSpec)),
+			},
+		)
 	}
 
-	return result, nil
+	result.Endpoints = makePrioritizedLocalities("", endpoints)
+
+	return &result, versions, nil
 }
 
-func makeFlatLocalityLbEndpoints(serviceRef gtcv1alpha1.ServiceRef, epSlices []*kdiscoveryv1.EndpointSlice, weight, priority uint32) (*endpointv3.LocalityLbEndpoints, error) {
-	var xdsEndpoints []*endpointv3.LbEndpoint
+// podHealthStatus returns the health status of a pod, and false if it should not be served at all.
+// Ready pods being deleted are draining, pods that are not ready are unhealthy if publishNotReady is set.
+func podHealthStatus(pod *corev1.Pod, publishNotReady bool) (core.HealthStatus, bool) {
+	if pod.Status.Phase != corev1.PodRunning {
+		return core.HealthStatus_UNKNOWN, false
+	}
 
-	for _, epSlice := range epSlices {
-		port, ok := lookupK8sPort(serviceRef.Port, epSlice.Ports)
+	ready := podReady(pod)
+
+	switch {
+	case ready && pod.DeletionTimestamp != nil:
+		return core.HealthStatus_DRAINING, true
+	case ready:
+		return core.HealthStatus_UNKNOWN, true
+	case publishNotReady:
+		return core.HealthStatus_UNHEALTHY, true
+	default:
+		return core.HealthStatus_UNKNOWN, false
+	}
+}
+
+// podReady returns true if the ready condition of a pod is true.
+func podReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func lookupPodPort(portRef gtcv1alpha1.PortRef, pod *corev1.Pod) (uint32, bool) {
+	if portRef.Name == "" {
+		return uint32(portRef.Number), portRef.Number > 0
+	}
+
+	for _, container := range pod.Spec.Containers {
+		for _, p := range container.Ports {
+			if p.Name == portRef.Name {
+				return uint32(p.ContainerPort), true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// makeServiceEndpoints prioritizes the endpoints of a service by proximity with the client: same zone first,
+// then same region, then anywhere. It also returns the topologies and weights of the endpoints, to be accounted for in the version.
+// If the service uses topology aware routing, endpoints hinted for the zone of the client are considered as in the same zone.
+// If the backend has a same node policy, endpoints running on the node of the client come first.
+func (h *endpointHandler) makeServiceEndpoints(node *v3.Node, backendSpec gtcv1alpha1.Backend, serviceRef gtcv1alpha1.ServiceRef, epSlices []*kdiscoveryv1.EndpointSlice) ([]*endpointv3.LocalityLbEndpoints, []string, error) {
+	client, clientNode, err := h.clientTopology(node)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		useHints  = hasHints(epSlices)
+		endpoints []prioritizedEndpoint
+		// The topology of the client might be looked up from its pod.
+		versions = []string{client.String(), clientNode}
+	)
+
+	serviceEndpoints, err := mergeServiceEndpoints(serviceRef, epSlices)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, ep := range serviceEndpoints {
+		health, ok := endpointHealthStatus(ep.Conditions, backendSpec.PublishNotReady)
 		if !ok {
-			return nil, errors.New("no desired port found on the k8s endpoint slice")
+			continue
+		}
+
+		epTopology, err := h.endpointTopology(ep)
+		if err != nil {
+			return nil, nil, err
 		}
 
+		versions = append(versions, epTopology.String())
+
+		proximity := client.proximity(epTopology)
+
+		if useHints {
+			switch {
+			case ep.Hints != nil && containsZone(ep.Hints.ForZones, client.zone):
+				proximity = proximityZone
+			case proximity == proximityZone:
+				// Hinted for another zone, deprioritize it.
+				proximity = proximityRegion
+			}
+		}
+
+		var epNode string
+
+		if ep.NodeName != nil {
+			epNode = *ep.NodeName
+		}
+
+		proximity, ok = sameNodeProximity(backendSpec.SameNode, clientNode, epNode, proximity)
+		if !ok {
+			continue
+		}
+
+		pod, err := h.endpointPod(ep)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		attrs := makePodAttributes(pod, backendSpec)
+		if !attrs.isZero() {
+			versions = append(versions, attrs.version(pod.Name))
+		}
+
+		endpoints = append(
+			endpoints,
+			prioritizedEndpoint{
+				topology:  epTopology,
+				proximity: proximity,
+				endpoint:  makeLbEndpoint(ep.addresses, health, attrs),
+			},
+		)
+	}
+
+	return makePrioritizedLocalities(serviceSubZone(serviceRef), endpoints), versions, nil
+}
+
+// endpointTopology returns the topology of the node of an endpoint, falling back on the zone of the endpoint.
+// Nodes of remote clusters are unknown, their endpoints only have a zone.
+func (h *endpointHandler) endpointTopology(ep *serviceEndpoint) (topology, error) {
+	var epTopology topology
+
+	if ep.NodeName != nil && ep.cluster == "" {
+		nodeTopology, err := h.nodeTopology(*ep.NodeName)
+		if err != nil {
+			return topology{}, err
+		}
+
+		epTopology = nodeTopology
+	}
+
+	if epTopology.zone == "" && ep.Zone != nil {
+		epTopology.zone = *ep.Zone
+	}
+
+	return epTopology, nil
+}
+
+func hasHints(epSlices []*kdiscoveryv1.EndpointSlice) bool {
+	for _, epSlice := range epSlices {
 		for _, ep := range epSlice.Endpoints {
-			if !derefBool(ep.Conditions.Ready) {
-				continue
+			if derefBool(ep.Conditions.Ready) && ep.Hints != nil && len(ep.Hints.ForZones) > 0 {
+				return true
 			}
+		}
+	}
+
+	return false
+}
 
-			xdsEndpoints = append(
-				xdsEndpoints,
-				makeLbEndpoints(ep, port)...,
-			)
+// makeFlatLocalityLbEndpoints returns all the endpoints of a service in a single locality, alongside the attributes of their pods as versions.
+func (h *endpointHandler) makeFlatLocalityLbEndpoints(serviceRef gtcv1alpha1.ServiceRef, epSlices []*kdiscoveryv1.EndpointSlice, weight, priority uint32, backendSpec gtcv1alpha1.Backend) (*endpointv3.LocalityLbEndpoints, []string, error) {
+	var (
+		xdsEndpoints []*endpointv3.LbEndpoint
+		versions     []string
+	)
+
+	serviceEndpoints, err := mergeServiceEndpoints(serviceRef, epSlices)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, ep := range serviceEndpoints {
+		health, ok := endpointHealthStatus(ep.Conditions, backendSpec.PublishNotReady)
+		if !ok {
+			continue
 		}
 
+		pod, err := h.endpointPod(ep)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		attrs := makePodAttributes(pod, backendSpec)
+		if !attrs.isZero() {
+			versions = append(versions, attrs.version(pod.Name))
+		}
+
+		xdsEndpoints = append(
+			xdsEndpoints,
+			makeLbEndpoint(ep.addresses, health, attrs),
+		)
 	}
 
 	return &endpointv3.LocalityLbEndpoints{
-		Locality:            &core.Locality{SubZone: serviceRef.Name},
+		Locality:            &core.Locality{SubZone: serviceSubZone(serviceRef)},
 		LoadBalancingWeight: wrapperspb.UInt32(weight),
 		Priority:            priority,
 		LbEndpoints:         xdsEndpoints,
-	}, nil
+	}, versions, nil
 }
 
-func makeLbEndpoints(ep kdiscoveryv1.Endpoint, port uint32) []*endpointv3.LbEndpoint {
-	var eps []*endpointv3.LbEndpoint
+// serviceSubZone returns the sub zone of the localities of a service, qualified by its cluster if remote
+// as localities of the same backend must be unique.
+func serviceSubZone(serviceRef gtcv1alpha1.ServiceRef) string {
+	if serviceRef.Cluster == "" {
+		return serviceRef.Name
+	}
 
-	for _, addr := range ep.Addresses {
-		eps = append(
-			eps,
-			makeLbEndpoint(ep, addr, port),
-		)
+	return serviceRef.Cluster + "/" + serviceRef.Name
+}
+
+// endpointPod returns the pod targeted by an endpoint, nil if it doesn't target a known pod.
+// Pods of remote clusters are unknown.
+func (h *endpointHandler) endpointPod(ep *serviceEndpoint) (*corev1.Pod, error) {
+	if ep.TargetRef == nil || ep.TargetRef.Kind != "Pod" || ep.cluster != "" {
+		return nil, nil
 	}
 
-	return eps
+	pod, err := h.pods.Pods(ep.namespace).Get(ep.TargetRef.Name)
+	switch {
+	case kerrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return pod, nil
+}
+
+// podAttributes are the attributes of the endpoints of a pod.
+type podAttributes struct {
+	weight  uint32
+	hashKey string
+}
+
+// makePodAttributes reads the attributes of the endpoints of a pod, a nil pod has none.
+func makePodAttributes(pod *corev1.Pod, backendSpec gtcv1alpha1.Backend) podAttributes {
+	if pod == nil {
+		return podAttributes{}
+	}
+
+	return podAttributes{
+		weight:  podWeight(pod),
+		hashKey: podHashKey(pod, backendSpec.RingHashConfig),
+	}
+}
+
+func (a podAttributes) isZero() bool {
+	return a == podAttributes{}
 }
 
-func makeLbEndpoint(ep kdiscoveryv1.Endpoint, addr string, port uint32) *endpointv3.LbEndpoint {
+// version identifies the attributes of the endpoints of a pod. Pods are updated too often to account for their versions,
+// only their attributes matter.
+func (a podAttributes) version(podName string) string {
+	return podName + "=" + strconv.FormatUint(uint64(a.weight), 10) + "/" + a.hashKey
+}
+
+// podWeight returns the weight set by the weight annotation of a pod, 0 if it has none or if it is invalid.
+func podWeight(pod *corev1.Pod) uint32 {
+	rawWeight, ok := pod.Annotations[gtcv1alpha1.AnnotationWeight]
+	if !ok {
+		return 0
+	}
+
+	weight, err := strconv.ParseUint(rawWeight, 10, 32)
+	if err != nil {
+		return 0
+	}
+
+	return uint32(weight)
+}
+
+// podHashKey returns the ring hash key of a pod, empty if the backend doesn't use endpoint hash keys or if the pod has no key.
+func podHashKey(pod *corev1.Pod, ringHashConfig *gtcv1alpha1.RingHashConfig) string {
+	if ringHashConfig == nil || ringHashConfig.EndpointHashKey == nil {
+		return ""
+	}
+
+	hashKey := ringHashConfig.EndpointHashKey
+
+	switch {
+	case hashKey.Label != "":
+		return pod.Labels[hashKey.Label]
+	case hashKey.Annotation != "":
+		return pod.Annotations[hashKey.Annotation]
+	default:
+		return pod.Name
+	}
+}
+
+// endpointHealthStatus returns the health status of an endpoint, and false if it should not be served at all.
+// Terminating endpoints still serving are draining, endpoints that are not ready are unhealthy if publishNotReady is set.
+func endpointHealthStatus(conditions kdiscoveryv1.EndpointConditions, publishNotReady bool) (core.HealthStatus, bool) {
+	switch {
+	case derefBool(conditions.Ready):
+		return core.HealthStatus_UNKNOWN, true
+	case derefBool(conditions.Serving) && derefBool(conditions.Terminating):
+		return core.HealthStatus_DRAINING, true
+	case publishNotReady:
+		return core.HealthStatus_UNHEALTHY, true
+	default:
+		return core.HealthStatus_UNKNOWN, false
+	}
+}
+
+// serviceEndpoint is an endpoint of a service, merged accross the EndpointSlices of all its address families.
+type serviceEndpoint struct {
+	kdiscoveryv1.Endpoint
+
+	namespace string
+	cluster   string
+	addresses []*core.SocketAddress
+}
+
+func (ep *serviceEndpoint) addAddress(addr string, port uint32) {
+	for _, known := range ep.addresses {
+		if known.GetAddress() == addr && known.GetPortValue() == port {
+			return
+		}
+	}
+
+	ep.addresses = append(ep.addresses, makeSocketAddress(addr, port))
+}
+
+// endpointTarget identifies the pod targeted by an endpoint. Pods of different source clusters of a ServiceImport
+// can share the same namespace and name, yet are different pods.
+type endpointTarget struct {
+	sourceCluster string
+	ref           corev1.ObjectReference
+}
+
+// mergeServiceEndpoints merges the endpoints targeting the same pod accross the EndpointSlices of all the address families of a service,
+// as dual-stack services have one EndpointSlice per address family. Addresses of the preferred address family come first.
+// Endpoints without target are never merged.
+func mergeServiceEndpoints(serviceRef gtcv1alpha1.ServiceRef, epSlices []*kdiscoveryv1.EndpointSlice) ([]*serviceEndpoint, error) {
+	var (
+		preferred = preferredAddressType(serviceRef.AddressFamily)
+		sorted    = slices.Clone(epSlices)
+		targets   = make(map[endpointTarget]*serviceEndpoint)
+		result    []*serviceEndpoint
+	)
+
+	// Stable sort, slices of the same address family keep their order.
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].AddressType == preferred && sorted[j].AddressType != preferred
+	})
+
+	for _, epSlice := range sorted {
+		port, ok := lookupK8sPort(serviceRef.Port, epSlice.Ports)
+		if !ok {
+			return nil, errors.New("no desired port found on the k8s endpoint slice")
+		}
+
+		for _, ep := range epSlice.Endpoints {
+			if len(ep.Addresses) == 0 {
+				continue
+			}
+
+			target := endpointTarget{sourceCluster: epSlice.Labels[labelMCSSourceCluster]}
+
+			if ep.TargetRef != nil {
+				target.ref = corev1.ObjectReference{Kind: ep.TargetRef.Kind, Namespace: ep.TargetRef.Namespace, Name: ep.TargetRef.Name}
+			}
+
+			merged, ok := targets[target]
+			if !ok {
+				merged = &serviceEndpoint{Endpoint: ep, namespace: epSlice.Namespace, cluster: serviceRef.Cluster}
+				result = append(result, merged)
+
+				if ep.TargetRef != nil {
+					targets[target] = merged
+				}
+			}
+
+			for _, addr := range ep.Addresses {
+				merged.addAddress(addr, port)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func preferredAddressType(addressFamily string) kdiscoveryv1.AddressType {
+	if addressFamily == gtcv1alpha1.AddressFamilyIPv6 {
+		return kdiscoveryv1.AddressTypeIPv6
+	}
+
+	return kdiscoveryv1.AddressTypeIPv4
+}
+
+// makeLbEndpoint returns an endpoint for the given addresses, the first one being its address and the other ones its additional addresses.
+// Endpoints without weight get the default weight, and endpoints without hash key are placed on the ring using their address.
+func makeLbEndpoint(addrs []*core.SocketAddress, health core.HealthStatus, attrs podAttributes) *endpointv3.LbEndpoint {
+	var (
+		lbWeight *wrapperspb.UInt32Value
+		metadata *core.Metadata
+	)
+
+	if attrs.weight > 0 {
+		lbWeight = wrapperspb.UInt32(attrs.weight)
+	}
+
+	if attrs.hashKey != "" {
+		metadata = &core.Metadata{
+			FilterMetadata: map[string]*structpb.Struct{
+				"envoy.lb": {
+					Fields: map[string]*structpb.Value{
+						"hash_key": structpb.NewStringValue(attrs.hashKey),
+					},
+				},
+			},
+		}
+	}
+
+	var additionalAddrs []*endpointv3.Endpoint_AdditionalAddress
+
+	for _, addr := range addrs[1:] {
+		additionalAddrs = append(
+			additionalAddrs,
+			&endpointv3.Endpoint_AdditionalAddress{
+				Address: &core.Address{
+					Address: &core.Address_SocketAddress{SocketAddress: addr},
+				},
+			},
+		)
+	}
+
 	return &endpointv3.LbEndpoint{
+		HealthStatus:        health,
+		LoadBalancingWeight: lbWeight,
+		Metadata:            metadata,
 		HostIdentifier: &endpointv3.LbEndpoint_Endpoint{
 			Endpoint: &endpointv3.Endpoint{
 				Address: &core.Address{
-					Address: &core.Address_SocketAddress{
-						SocketAddress: &core.SocketAddress{
-							Protocol: core.SocketAddress_TCP,
-							Address:  addr,
-							PortSpecifier: &core.SocketAddress_PortValue{
-								PortValue: port,
-							},
-						},
-					},
+					Address: &core.Address_SocketAddress{SocketAddress: addrs[0]},
 				},
+				AdditionalAddresses: additionalAddrs,
 			},
 		},
 	}
 }
 
+func makeSocketAddress(addr string, port uint32) *core.SocketAddress {
+	return &core.SocketAddress{
+		Protocol: core.SocketAddress_TCP,
+		Address:  addr,
+		PortSpecifier: &core.SocketAddress_PortValue{
+			PortValue: port,
+		},
+	}
+}
+
 func lookupK8sPort(k8sSvc gtcv1alpha1.PortRef, epPorts []kdiscoveryv1.EndpointPort) (uint32, bool) {
 	if k8sSvc.Name != "" {
 		for _, p := range epPorts {
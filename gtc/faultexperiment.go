@@ -0,0 +1,210 @@
+package gtc
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	gtcv1alpha1 "github.com/jlevesy/grpc-traffic-controller/api/gtc/v1alpha1"
+	gtclisters "github.com/jlevesy/grpc-traffic-controller/client/listers/gtc/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// faultExperiments applies the faults of the running FaultExperiments of a listener.
+type faultExperiments struct {
+	lister gtclisters.FaultExperimentLister
+}
+
+// apply returns a copy of the listener with the faults of its running experiments applied by name order,
+// or the listener itself if none is running, alongside the versions of the experiments applied.
+func (e faultExperiments) apply(listener *gtcv1alpha1.GRPCListener) (*gtcv1alpha1.GRPCListener, []string, error) {
+	experiments, err := e.lister.FaultExperiments(listener.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sort.Slice(experiments, func(i, j int) bool { return experiments[i].Name < experiments[j].Name })
+
+	var (
+		now      = time.Now()
+		patched  *gtcv1alpha1.GRPCListener
+		versions []string
+	)
+
+	for _, experiment := range experiments {
+		if experiment.Spec.Target.Listener != listener.Name {
+			continue
+		}
+
+		if phase, _ := faultExperimentPhase(experiment, now); phase != gtcv1alpha1.FaultExperimentPhaseRunning {
+			continue
+		}
+
+		// Invalid experiments are reported in their status, they must not prevent the listener from being served.
+		if validateFaultExperiment(listener, experiment) != nil {
+			continue
+		}
+
+		if patched == nil {
+			patched = listener.DeepCopy()
+
+			// Route and backend faults override the fault filter of the listener, add a disabled one if it has none.
+			if findFault(patched.Spec.Interceptors) == nil {
+				patched.Spec.Interceptors = append(
+					patched.Spec.Interceptors,
+					gtcv1alpha1.Interceptor{Fault: &gtcv1alpha1.FaultInterceptor{}},
+				)
+			}
+		}
+
+		applyFaultExperiment(patched, experiment)
+		versions = append(versions, experiment.ResourceVersion)
+	}
+
+	if patched == nil {
+		return listener, nil, nil
+	}
+
+	return patched, versions, nil
+}
+
+// validateFaultExperiment returns an error if the fault of an experiment can't be applied to the listener it targets.
+func validateFaultExperiment(listener *gtcv1alpha1.GRPCListener, experiment *gtcv1alpha1.FaultExperiment) error {
+	var (
+		target = experiment.Spec.Target
+		fault  = experiment.Spec.Fault
+	)
+
+	if _, err := makeFaultFilter(&fault); err != nil {
+		return err
+	}
+
+	if target.Route == nil {
+		if target.Backend != nil {
+			return errors.New("a backend target requires a route")
+		}
+
+		return validateHeaderFault(listener, experiment)
+	}
+
+	routeSpec := listenerRoute(listener, *target.Route)
+	if routeSpec == nil {
+		return fmt.Errorf("listener %s has no route %d", listener.Name, *target.Route)
+	}
+
+	if target.Backend == nil {
+		return validateHeaderFault(listener, experiment)
+	}
+
+	if *target.Backend < 0 || *target.Backend >= len(routeSpec.Backends) {
+		return fmt.Errorf("route %d of listener %s has no backend %d", *target.Route, listener.Name, *target.Backend)
+	}
+
+	if len(fault.Headers) > 0 {
+		return errors.New("backend faults can't match headers")
+	}
+
+	return nil
+}
+
+// validateHeaderFault returns an error if the fault of an experiment matching headers can't be injected
+// in the routes it applies to.
+func validateHeaderFault(listener *gtcv1alpha1.GRPCListener, experiment *gtcv1alpha1.FaultExperiment) error {
+	if len(experiment.Spec.Fault.Headers) == 0 {
+		return nil
+	}
+
+	patched := listener.DeepCopy()
+	applyFaultExperiment(patched, experiment)
+
+	for _, routeSpec := range patched.Spec.AllRoutes() {
+		if _, _, err := makeHeaderFault(patched, routeSpec, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyFaultExperiment replaces the fault interceptor of the experiment target by the experiment fault.
+// The experiment must be valid.
+func applyFaultExperiment(listener *gtcv1alpha1.GRPCListener, experiment *gtcv1alpha1.FaultExperiment) {
+	var (
+		target = experiment.Spec.Target
+		fault  = experiment.Spec.Fault
+	)
+
+	if target.Route == nil {
+		listener.Spec.Interceptors = withFault(listener.Spec.Interceptors, &fault)
+		return
+	}
+
+	routeSpec := listenerRoute(listener, *target.Route)
+
+	if target.Backend == nil {
+		routeSpec.Interceptors = withFault(routeSpec.Interceptors, &fault)
+		return
+	}
+
+	backend := &routeSpec.Backends[*target.Backend]
+	backend.Interceptors = withFault(backend.Interceptors, &fault)
+}
+
+// listenerRoute returns the route of the listener at the given index of its routes, followed by the routes of its virtual hosts.
+func listenerRoute(listener *gtcv1alpha1.GRPCListener, index int) *gtcv1alpha1.Route {
+	if index < 0 {
+		return nil
+	}
+
+	if index < len(listener.Spec.Routes) {
+		return &listener.Spec.Routes[index]
+	}
+
+	index -= len(listener.Spec.Routes)
+
+	for i := range listener.Spec.VirtualHosts {
+		routes := listener.Spec.VirtualHosts[i].Routes
+
+		if index < len(routes) {
+			return &routes[index]
+		}
+
+		index -= len(routes)
+	}
+
+	return nil
+}
+
+// withFault replaces the fault interceptor of the given interceptors, or appends it if there's none.
+// The node selector of the replaced interceptor is dropped, experiments apply to all the clients.
+func withFault(interceptors []gtcv1alpha1.Interceptor, fault *gtcv1alpha1.FaultInterceptor) []gtcv1alpha1.Interceptor {
+	for i := range interceptors {
+		if interceptors[i].Fault != nil {
+			interceptors[i] = gtcv1alpha1.Interceptor{Fault: fault}
+			return interceptors
+		}
+	}
+
+	return append(interceptors, gtcv1alpha1.Interceptor{Fault: fault})
+}
+
+// faultExperimentPhase returns the phase of an experiment at the given time, and the time its next phase starts at,
+// zero once completed.
+func faultExperimentPhase(experiment *gtcv1alpha1.FaultExperiment, now time.Time) (string, time.Time) {
+	start := experiment.CreationTimestamp.Time
+	if experiment.Spec.StartTime != nil {
+		start = experiment.Spec.StartTime.Time
+	}
+
+	end := start.Add(experiment.Spec.Duration.Duration)
+
+	switch {
+	case now.Before(start):
+		return gtcv1alpha1.FaultExperimentPhasePending, start
+	case now.Before(end):
+		return gtcv1alpha1.FaultExperimentPhaseRunning, end
+	default:
+		return gtcv1alpha1.FaultExperimentPhaseCompleted, time.Time{}
+	}
+}
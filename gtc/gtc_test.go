@@ -2,19 +2,33 @@ package gtc_test
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	xdstypev3 "github.com/cncf/xds/go/xds/type/v3"
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	resourcesv3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 	"google.golang.org/grpc/codes"
 	_ "google.golang.org/grpc/xds"
+	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	gtcv1alpha1 "github.com/jlevesy/grpc-traffic-controller/api/gtc/v1alpha1"
+	"github.com/jlevesy/grpc-traffic-controller/bootstrap"
+	_ "github.com/jlevesy/grpc-traffic-controller/bootstrap/xdscreds"
 	"github.com/jlevesy/grpc-traffic-controller/gtc"
 	tr "github.com/jlevesy/grpc-traffic-controller/pkg/testruntime"
 )
@@ -36,12 +50,17 @@ func TestServer(t *testing.T) {
 		desc                string
 		backendCount        int
 		buildEndpointSlices func(backends []tr.Backend) []discoveryv1.EndpointSlice
-		buildGRPCListeners  func(backends []tr.Backend) []gtcv1alpha1.GRPCListener
-		buildCallContext    func(t *testing.T) *tr.CallContext
-		setBackendsBehavior func(t *testing.T, bs tr.Backends)
-		doAssertPreUpdate   func(t *testing.T, callCtx *tr.CallContext)
-		updateResources     func(t *testing.T, k8s tr.FakeK8s, backends []tr.Backend)
-		doAssertPostUpdate  func(t *testing.T, callCtx *tr.CallContext)
+		// buildRemoteEndpointSlices returns the endpoint slices of the remote clusters, by cluster name.
+		buildRemoteEndpointSlices func(backends []tr.Backend) map[string][]discoveryv1.EndpointSlice
+		buildGRPCListeners        func(backends []tr.Backend) []gtcv1alpha1.GRPCListener
+		referenceGrants           []gtcv1alpha1.ReferenceGrant
+		buildPods                 func(backends []tr.Backend) []corev1.Pod
+		nodes                     []corev1.Node
+		buildCallContext          func(t *testing.T) *tr.CallContext
+		setBackendsBehavior       func(t *testing.T, bs tr.Backends)
+		doAssertPreUpdate         func(t *testing.T, callCtx *tr.CallContext)
+		updateResources           func(t *testing.T, k8s tr.FakeK8s, backends []tr.Backend)
+		doAssertPostUpdate        func(t *testing.T, callCtx *tr.CallContext)
 	}{
 		{
 			desc:         "single call port by name",
@@ -138,12 +157,12 @@ func TestServer(t *testing.T) {
 			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "cross namespace",
+			desc:         "listener name percent encoded authority",
 			backendCount: 1,
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
 				return tr.BuildEndpointSlices(
 					serviceNameV1,
-					"some-app",
+					defaultNamespace,
 					backends[0:1],
 				)
 			},
@@ -158,9 +177,8 @@ func TestServer(t *testing.T) {
 									tr.BuildBackend(
 										tr.WithServiceRef(
 											gtcv1alpha1.ServiceRef{
-												Name:      serviceNameV1,
-												Namespace: "some-app",
-												Port:      grpcPort,
+												Name: serviceNameV1,
+												Port: grpcPort,
 											},
 										),
 									),
@@ -170,7 +188,7 @@ func TestServer(t *testing.T) {
 					),
 				}
 			},
-			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			buildCallContext:    tr.AuthorityCallContext("xds:///default/test-xds", "default%2Ftest-xds"),
 			setBackendsBehavior: answer,
 			doAssertPreUpdate: tr.CallOnce(
 				tr.BuildCaller(
@@ -185,12 +203,13 @@ func TestServer(t *testing.T) {
 			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "locality based wrr",
-			backendCount: 4,
+			desc:         "listener name raw authority",
+			backendCount: 1,
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
-				return tr.AppendEndpointSlices(
-					tr.BuildEndpointSlices("test-service", "default", backends[0:2]),
-					tr.BuildEndpointSlices("test-service-v2", "default", backends[2:4]),
+				return tr.BuildEndpointSlices(
+					serviceNameV1,
+					defaultNamespace,
+					backends[0:1],
 				)
 			},
 			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
@@ -202,25 +221,11 @@ func TestServer(t *testing.T) {
 							tr.BuildRoute(
 								tr.WithBackends(
 									tr.BuildBackend(
-										tr.WithLocalities(
-											tr.BuildLocality(
-												tr.WithLocalityWeight(80),
-												tr.WithLocalityServiceRef(
-													gtcv1alpha1.ServiceRef{
-														Name: "test-service",
-														Port: grpcPort,
-													},
-												),
-											),
-											tr.BuildLocality(
-												tr.WithLocalityWeight(20),
-												tr.WithLocalityServiceRef(
-													gtcv1alpha1.ServiceRef{
-														Name: "test-service-v2",
-														Port: grpcPort,
-													},
-												),
-											),
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
 										),
 									),
 								),
@@ -229,34 +234,28 @@ func TestServer(t *testing.T) {
 					),
 				}
 			},
+			buildCallContext:    tr.AuthorityCallContext("xds:///default/test-xds", "default/test-xds"),
 			setBackendsBehavior: answer,
-			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
-			doAssertPreUpdate: tr.CallN(
+			doAssertPreUpdate: tr.CallOnce(
 				tr.BuildCaller(
 					tr.MethodEcho,
 				),
-				10000,
 				tr.NoCallErrors,
 				tr.CountByBackendID(
-					// 80% of calls
-					tr.AssertCountWithinDelta("backend-0", 4000, 500.0),
-					tr.AssertCountWithinDelta("backend-1", 4000, 500.0),
-					// 20% of calls
-					tr.AssertCountWithinDelta("backend-2", 1000, 500.0),
-					tr.AssertCountWithinDelta("backend-3", 1000, 500.0),
+					tr.AssertCount("backend-0", 1),
 				),
 			),
 			updateResources:    noChange,
 			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "priority fallback",
-			backendCount: 2,
+			desc:         "cross namespace",
+			backendCount: 1,
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
-				return tr.AppendEndpointSlices(
-					// No backend for this service.
-					tr.BuildEndpointSlices("test-service", "default", backends[0:0]),
-					tr.BuildEndpointSlices("test-service-v2", "default", backends[1:2]),
+				return tr.BuildEndpointSlices(
+					serviceNameV1,
+					"some-app",
+					backends[0:1],
 				)
 			},
 			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
@@ -268,25 +267,12 @@ func TestServer(t *testing.T) {
 							tr.BuildRoute(
 								tr.WithBackends(
 									tr.BuildBackend(
-										tr.WithLocalities(
-											tr.BuildLocality(
-												tr.WithLocalityPriority(0),
-												tr.WithLocalityServiceRef(
-													gtcv1alpha1.ServiceRef{
-														Name: "test-service",
-														Port: grpcPort,
-													},
-												),
-											),
-											tr.BuildLocality(
-												tr.WithLocalityPriority(1),
-												tr.WithLocalityServiceRef(
-													gtcv1alpha1.ServiceRef{
-														Name: "test-service-v2",
-														Port: grpcPort,
-													},
-												),
-											),
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name:      serviceNameV1,
+												Namespace: "some-app",
+												Port:      grpcPort,
+											},
 										),
 									),
 								),
@@ -295,31 +281,31 @@ func TestServer(t *testing.T) {
 					),
 				}
 			},
-			setBackendsBehavior: answer,
+			referenceGrants: []gtcv1alpha1.ReferenceGrant{
+				tr.BuildReferenceGrant("allow-default", "some-app", []string{"default"}, serviceNameV1),
+			},
 			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
 			doAssertPreUpdate: tr.CallOnce(
 				tr.BuildCaller(
 					tr.MethodEcho,
 				),
 				tr.NoCallErrors,
 				tr.CountByBackendID(
-					// No calls for the first set of backends
-					tr.AssertCount("backend-0", 0),
-					// One call for the second backend.
-					tr.AssertCount("backend-1", 1),
+					tr.AssertCount("backend-0", 1),
 				),
 			),
 			updateResources:    noChange,
 			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "route matcher method matching",
-			backendCount: 2,
-
+			desc:         "cross namespace without reference grant",
+			backendCount: 1,
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
-				return tr.AppendEndpointSlices(
-					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
-					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2]),
+				return tr.BuildEndpointSlices(
+					serviceNameV1,
+					"some-app",
+					backends[0:1],
 				)
 			},
 			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
@@ -328,34 +314,14 @@ func TestServer(t *testing.T) {
 						"test-xds",
 						"default",
 						tr.WithRoutes(
-							tr.BuildRoute(
-								tr.WithRouteMatcher(
-									tr.BuildRouteMatcher(
-										tr.WithMethodMatcher(
-											"echo",
-											"Echo",
-											"EchoPremium",
-										),
-									),
-								),
-								tr.WithBackends(
-									tr.BuildBackend(
-										tr.WithServiceRef(
-											gtcv1alpha1.ServiceRef{
-												Name: serviceNameV2,
-												Port: grpcPort,
-											},
-										),
-									),
-								),
-							),
 							tr.BuildRoute(
 								tr.WithBackends(
 									tr.BuildBackend(
 										tr.WithServiceRef(
 											gtcv1alpha1.ServiceRef{
-												Name: serviceNameV1,
-												Port: grpcPort,
+												Name:      serviceNameV1,
+												Namespace: "some-app",
+												Port:      grpcPort,
 											},
 										),
 									),
@@ -365,44 +331,29 @@ func TestServer(t *testing.T) {
 					),
 				}
 			},
+			referenceGrants: []gtcv1alpha1.ReferenceGrant{
+				tr.BuildReferenceGrant("allow-other", "some-app", []string{"other"}),
+			},
 			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
 			setBackendsBehavior: answer,
-			doAssertPreUpdate: tr.MultiAssert(
-				tr.CallOnce(
-					tr.BuildCaller(
-						tr.MethodEchoPremium,
-					),
-					tr.NoCallErrors,
-					tr.CountByBackendID(
-						// One call for the second backend, because we're calling premium.
-						tr.AssertCount("backend-1", 1),
-						tr.AssertCount("backend-0", 0),
-					),
-				),
-				tr.CallOnce(
-					tr.BuildCaller(
-						tr.MethodEcho,
-					),
-					tr.NoCallErrors,
-					tr.CountByBackendID(
-						// No calls for the first set of backends
-						// First backend should get a call.
-						tr.AssertCount("backend-0", 1),
-						tr.AssertCount("backend-1", 0),
-					),
+			doAssertPreUpdate: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+					tr.WithTimeout(time.Second),
 				),
+				tr.MustFail,
 			),
 			updateResources:    noChange,
 			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "route matcher service matcher",
-			backendCount: 2,
-
+			desc:         "cross namespace with reference grant for another service",
+			backendCount: 1,
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
-				return tr.AppendEndpointSlices(
-					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
-					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2]),
+				return tr.BuildEndpointSlices(
+					serviceNameV1,
+					"some-app",
+					backends[0:1],
 				)
 			},
 			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
@@ -411,33 +362,14 @@ func TestServer(t *testing.T) {
 						"test-xds",
 						"default",
 						tr.WithRoutes(
-							tr.BuildRoute(
-								tr.WithRouteMatcher(
-									tr.BuildRouteMatcher(
-										tr.WithServiceMatcher(
-											"echo",
-											"Echo",
-										),
-									),
-								),
-								tr.WithBackends(
-									tr.BuildBackend(
-										tr.WithServiceRef(
-											gtcv1alpha1.ServiceRef{
-												Name: serviceNameV2,
-												Port: grpcPort,
-											},
-										),
-									),
-								),
-							),
 							tr.BuildRoute(
 								tr.WithBackends(
 									tr.BuildBackend(
 										tr.WithServiceRef(
 											gtcv1alpha1.ServiceRef{
-												Name: serviceNameV1,
-												Port: grpcPort,
+												Name:      serviceNameV1,
+												Namespace: "some-app",
+												Port:      grpcPort,
 											},
 										),
 									),
@@ -447,30 +379,29 @@ func TestServer(t *testing.T) {
 					),
 				}
 			},
+			referenceGrants: []gtcv1alpha1.ReferenceGrant{
+				tr.BuildReferenceGrant("allow-default", "some-app", []string{"default"}, serviceNameV2),
+			},
 			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
 			setBackendsBehavior: answer,
 			doAssertPreUpdate: tr.CallOnce(
 				tr.BuildCaller(
 					tr.MethodEcho,
+					tr.WithTimeout(time.Second),
 				),
-				tr.NoCallErrors,
-				tr.CountByBackendID(
-					// One call for the second backend, because we're calling premium.
-					tr.AssertCount("backend-1", 1),
-					tr.AssertCount("backend-0", 0),
-				),
+				tr.MustFail,
 			),
 			updateResources:    noChange,
 			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "route matcher namespace matcher",
-			backendCount: 2,
-
+			desc:         "cross namespace with reference grant revoked",
+			backendCount: 1,
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
-				return tr.AppendEndpointSlices(
-					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
-					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2]),
+				return tr.BuildEndpointSlices(
+					serviceNameV1,
+					"some-app",
+					backends[0:1],
 				)
 			},
 			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
@@ -479,30 +410,14 @@ func TestServer(t *testing.T) {
 						"test-xds",
 						"default",
 						tr.WithRoutes(
-							tr.BuildRoute(
-								tr.WithRouteMatcher(
-									tr.BuildRouteMatcher(
-										tr.WithNamespaceMatcher("echo"),
-									),
-								),
-								tr.WithBackends(
-									tr.BuildBackend(
-										tr.WithServiceRef(
-											gtcv1alpha1.ServiceRef{
-												Name: serviceNameV2,
-												Port: grpcPort,
-											},
-										),
-									),
-								),
-							),
 							tr.BuildRoute(
 								tr.WithBackends(
 									tr.BuildBackend(
 										tr.WithServiceRef(
 											gtcv1alpha1.ServiceRef{
-												Name: serviceNameV1,
-												Port: grpcPort,
+												Name:      serviceNameV1,
+												Namespace: "some-app",
+												Port:      grpcPort,
 											},
 										),
 									),
@@ -512,6 +427,9 @@ func TestServer(t *testing.T) {
 					),
 				}
 			},
+			referenceGrants: []gtcv1alpha1.ReferenceGrant{
+				tr.BuildReferenceGrant("allow-default", "some-app", []string{"default"}, serviceNameV1),
+			},
 			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
 			setBackendsBehavior: answer,
 			doAssertPreUpdate: tr.CallOnce(
@@ -520,21 +438,35 @@ func TestServer(t *testing.T) {
 				),
 				tr.NoCallErrors,
 				tr.CountByBackendID(
-					// One call for the second backend, because we're calling premium.
-					tr.AssertCount("backend-1", 1),
-					tr.AssertCount("backend-0", 0),
+					tr.AssertCount("backend-0", 1),
 				),
 			),
-			updateResources:    noChange,
-			doAssertPostUpdate: noAssert,
-		},
-		{
-			desc:         "route matcher metadata invert matching",
-			backendCount: 2,
-			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
-				return tr.AppendEndpointSlices(
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, _ []tr.Backend) {
+				err := k8s.GTCApi.ApiV1alpha1().ReferenceGrants("some-app").Delete(
+					context.Background(),
+					"allow-default",
+					metav1.DeleteOptions{},
+				)
+				require.NoError(t, err)
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithTimeout(time.Second),
+					),
+					tr.MustFail,
+				),
+			),
+		},
+		{
+			desc:         "cross namespace without reference grant serves other routes",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.AppendEndpointSlices(
 					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
-					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2]),
+					tr.BuildEndpointSlices(serviceNameV2, "some-app", backends[1:2]),
 				)
 			},
 			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
@@ -546,13 +478,10 @@ func TestServer(t *testing.T) {
 							tr.BuildRoute(
 								tr.WithRouteMatcher(
 									tr.BuildRouteMatcher(
-										tr.WithMetadataMatchers(
-											tr.MetadataInvertMatch(
-												tr.MetadataExactMatch(
-													"x-variant",
-													"Awesome",
-												),
-											),
+										tr.WithMethodMatcher(
+											"echo",
+											"Echo",
+											"EchoPremium",
 										),
 									),
 								),
@@ -560,8 +489,9 @@ func TestServer(t *testing.T) {
 									tr.BuildBackend(
 										tr.WithServiceRef(
 											gtcv1alpha1.ServiceRef{
-												Name: serviceNameV2,
-												Port: grpcPort,
+												Name:      serviceNameV2,
+												Namespace: "some-app",
+												Port:      grpcPort,
 											},
 										),
 									),
@@ -586,43 +516,159 @@ func TestServer(t *testing.T) {
 			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
 			setBackendsBehavior: answer,
 			doAssertPreUpdate: tr.MultiAssert(
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEchoPremium,
+						tr.WithTimeout(time.Second),
+					),
+					tr.MustFail,
+				),
 				tr.CallOnce(
 					tr.BuildCaller(
 						tr.MethodEcho,
-						tr.WithMetadata(
-							map[string]string{
-								"x-variant": "Awesome",
-							},
-						),
 					),
 					tr.NoCallErrors,
 					tr.CountByBackendID(
-						tr.AssertCount("backend-1", 0),
 						tr.AssertCount("backend-0", 1),
+						tr.AssertCount("backend-1", 0),
 					),
 				),
-				tr.CallOnce(
-					tr.BuildCaller(
-						tr.MethodEcho,
-						tr.WithMetadata(
-							map[string]string{
-								"x-variant": "NotAwesome",
-							},
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "locality based wrr",
+			backendCount: 4,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.AppendEndpointSlices(
+					tr.BuildEndpointSlices("test-service", "default", backends[0:2]),
+					tr.BuildEndpointSlices("test-service-v2", "default", backends[2:4]),
+				)
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithLocalities(
+											tr.BuildLocality(
+												tr.WithLocalityWeight(80),
+												tr.WithLocalityServiceRef(
+													gtcv1alpha1.ServiceRef{
+														Name: "test-service",
+														Port: grpcPort,
+													},
+												),
+											),
+											tr.BuildLocality(
+												tr.WithLocalityWeight(20),
+												tr.WithLocalityServiceRef(
+													gtcv1alpha1.ServiceRef{
+														Name: "test-service-v2",
+														Port: grpcPort,
+													},
+												),
+											),
+										),
+									),
+								),
+							),
 						),
 					),
-					tr.NoCallErrors,
-					tr.CountByBackendID(
-						tr.AssertCount("backend-0", 0),
-						tr.AssertCount("backend-1", 1),
+				}
+			},
+			setBackendsBehavior: answer,
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			doAssertPreUpdate: tr.CallN(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				10000,
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					// 80% of calls
+					tr.AssertCountWithinDelta("backend-0", 4000, 500.0),
+					tr.AssertCountWithinDelta("backend-1", 4000, 500.0),
+					// 20% of calls
+					tr.AssertCountWithinDelta("backend-2", 1000, 500.0),
+					tr.AssertCountWithinDelta("backend-3", 1000, 500.0),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "priority fallback",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.AppendEndpointSlices(
+					// No backend for this service.
+					tr.BuildEndpointSlices("test-service", "default", backends[0:0]),
+					tr.BuildEndpointSlices("test-service-v2", "default", backends[1:2]),
+				)
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithLocalities(
+											tr.BuildLocality(
+												tr.WithLocalityPriority(0),
+												tr.WithLocalityServiceRef(
+													gtcv1alpha1.ServiceRef{
+														Name: "test-service",
+														Port: grpcPort,
+													},
+												),
+											),
+											tr.BuildLocality(
+												tr.WithLocalityPriority(1),
+												tr.WithLocalityServiceRef(
+													gtcv1alpha1.ServiceRef{
+														Name: "test-service-v2",
+														Port: grpcPort,
+													},
+												),
+											),
+										),
+									),
+								),
+							),
+						),
 					),
+				}
+			},
+			setBackendsBehavior: answer,
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			doAssertPreUpdate: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					// No calls for the first set of backends
+					tr.AssertCount("backend-0", 0),
+					// One call for the second backend.
+					tr.AssertCount("backend-1", 1),
 				),
 			),
 			updateResources:    noChange,
 			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "route matcher metadata exact matching",
+			desc:         "route matcher method matching",
 			backendCount: 2,
+
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
 				return tr.AppendEndpointSlices(
 					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
@@ -638,11 +684,10 @@ func TestServer(t *testing.T) {
 							tr.BuildRoute(
 								tr.WithRouteMatcher(
 									tr.BuildRouteMatcher(
-										tr.WithMetadataMatchers(
-											tr.MetadataExactMatch(
-												"x-variant",
-												"Awesome",
-											),
+										tr.WithMethodMatcher(
+											"echo",
+											"Echo",
+											"EchoPremium",
 										),
 									),
 								),
@@ -678,12 +723,7 @@ func TestServer(t *testing.T) {
 			doAssertPreUpdate: tr.MultiAssert(
 				tr.CallOnce(
 					tr.BuildCaller(
-						tr.MethodEcho,
-						tr.WithMetadata(
-							map[string]string{
-								"x-variant": "Awesome",
-							},
-						),
+						tr.MethodEchoPremium,
 					),
 					tr.NoCallErrors,
 					tr.CountByBackendID(
@@ -709,8 +749,9 @@ func TestServer(t *testing.T) {
 			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "route matcher metadata safe regex match",
+			desc:         "route matcher methods matching",
 			backendCount: 2,
+
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
 				return tr.AppendEndpointSlices(
 					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
@@ -726,14 +767,9 @@ func TestServer(t *testing.T) {
 							tr.BuildRoute(
 								tr.WithRouteMatcher(
 									tr.BuildRouteMatcher(
-										tr.WithMetadataMatchers(
-											gtcv1alpha1.MetadataMatcher{
-												Name: "x-variant",
-												Regex: &gtcv1alpha1.RegexMatcher{
-													Regex:  "Awe.*",
-													Engine: "re2",
-												},
-											},
+										tr.WithMethodsMatcher(
+											gtcv1alpha1.MethodMatcher{Namespace: "other", Service: "Other", Method: "Echo"},
+											gtcv1alpha1.MethodMatcher{Namespace: "echo", Service: "Echo", Method: "EchoPremium"},
 										),
 									),
 								),
@@ -769,12 +805,7 @@ func TestServer(t *testing.T) {
 			doAssertPreUpdate: tr.MultiAssert(
 				tr.CallOnce(
 					tr.BuildCaller(
-						tr.MethodEcho,
-						tr.WithMetadata(
-							map[string]string{
-								"x-variant": "Awesome",
-							},
-						),
+						tr.MethodEchoPremium,
 					),
 					tr.NoCallErrors,
 					tr.CountByBackendID(
@@ -800,8 +831,9 @@ func TestServer(t *testing.T) {
 			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "route matcher metadata range match",
+			desc:         "route matcher services matching",
 			backendCount: 2,
+
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
 				return tr.AppendEndpointSlices(
 					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
@@ -817,14 +849,9 @@ func TestServer(t *testing.T) {
 							tr.BuildRoute(
 								tr.WithRouteMatcher(
 									tr.BuildRouteMatcher(
-										tr.WithMetadataMatchers(
-											gtcv1alpha1.MetadataMatcher{
-												Name: "x-variant",
-												Range: &gtcv1alpha1.RangeMatcher{
-													Start: 10,
-													End:   20,
-												},
-											},
+										tr.WithServicesMatcher(
+											gtcv1alpha1.ServiceMatcher{Namespace: "other", Service: "Other"},
+											gtcv1alpha1.ServiceMatcher{Namespace: "echo", Service: "Echo"},
 										),
 									),
 								),
@@ -860,13 +887,7 @@ func TestServer(t *testing.T) {
 			doAssertPreUpdate: tr.MultiAssert(
 				tr.CallOnce(
 					tr.BuildCaller(
-						tr.MethodEcho,
-						tr.WithMetadata(
-							map[string]string{
-								// In range, call backend 1.
-								"x-variant": "12",
-							},
-						),
+						tr.MethodEchoPremium,
 					),
 					tr.NoCallErrors,
 					tr.CountByBackendID(
@@ -877,17 +898,11 @@ func TestServer(t *testing.T) {
 				tr.CallOnce(
 					tr.BuildCaller(
 						tr.MethodEcho,
-						tr.WithMetadata(
-							map[string]string{
-								// Out of bound, call backend-0.
-								"x-variant": "9",
-							},
-						),
 					),
 					tr.NoCallErrors,
 					tr.CountByBackendID(
-						tr.AssertCount("backend-0", 1),
-						tr.AssertCount("backend-1", 0),
+						tr.AssertCount("backend-1", 1),
+						tr.AssertCount("backend-0", 0),
 					),
 				),
 			),
@@ -895,8 +910,9 @@ func TestServer(t *testing.T) {
 			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "route matcher metadata present match",
+			desc:         "route matcher service and namespace boundaries",
 			backendCount: 2,
+
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
 				return tr.AppendEndpointSlices(
 					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
@@ -912,12 +928,8 @@ func TestServer(t *testing.T) {
 							tr.BuildRoute(
 								tr.WithRouteMatcher(
 									tr.BuildRouteMatcher(
-										tr.WithMetadataMatchers(
-											tr.MetadataPresentMatch(
-												"x-variant",
-												true,
-											),
-										),
+										// Not a match for the echo.Echo service.
+										tr.WithServiceMatcher("echo", "Ech"),
 									),
 								),
 								tr.WithBackends(
@@ -932,7 +944,25 @@ func TestServer(t *testing.T) {
 								),
 							),
 							tr.BuildRoute(
-								tr.WithBackends(
+								tr.WithRouteMatcher(
+									tr.BuildRouteMatcher(
+										// Not a match for the echo namespace.
+										tr.WithNamespaceMatcher("ech"),
+									),
+								),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV2,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+							tr.BuildRoute(
+								tr.WithBackends(
 									tr.BuildBackend(
 										tr.WithServiceRef(
 											gtcv1alpha1.ServiceRef{
@@ -953,21 +983,7 @@ func TestServer(t *testing.T) {
 				tr.CallOnce(
 					tr.BuildCaller(
 						tr.MethodEcho,
-						tr.WithMetadata(
-							map[string]string{
-								// Header is present, send to v2.
-								"x-variant": "wooop",
-							},
-						),
-					),
-					tr.NoCallErrors,
-					tr.CountByBackendID(
-						tr.AssertCount("backend-1", 1),
-						tr.AssertCount("backend-0", 0),
 					),
-				),
-				tr.CallOnce(
-					tr.BuildCaller(tr.MethodEcho),
 					tr.NoCallErrors,
 					tr.CountByBackendID(
 						tr.AssertCount("backend-0", 1),
@@ -979,8 +995,9 @@ func TestServer(t *testing.T) {
 			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "route matcher metadata prefix match",
+			desc:         "listener aliases",
 			backendCount: 2,
+
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
 				return tr.AppendEndpointSlices(
 					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
@@ -992,29 +1009,8 @@ func TestServer(t *testing.T) {
 					tr.BuildGRPCListener(
 						"test-xds",
 						"default",
+						tr.WithAliases("payments", "payments.prod"),
 						tr.WithRoutes(
-							tr.BuildRoute(
-								tr.WithRouteMatcher(
-									tr.BuildRouteMatcher(
-										tr.WithMetadataMatchers(
-											tr.MetadataPrefixMatch(
-												"x-variant",
-												"wo",
-											),
-										),
-									),
-								),
-								tr.WithBackends(
-									tr.BuildBackend(
-										tr.WithServiceRef(
-											gtcv1alpha1.ServiceRef{
-												Name: serviceNameV2,
-												Port: grpcPort,
-											},
-										),
-									),
-								),
-							),
 							tr.BuildRoute(
 								tr.WithBackends(
 									tr.BuildBackend(
@@ -1031,82 +1027,73 @@ func TestServer(t *testing.T) {
 					),
 				}
 			},
-			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			buildCallContext:    tr.DefaultCallContext("xds:///default/payments.prod"),
 			setBackendsBehavior: answer,
-			doAssertPreUpdate: tr.MultiAssert(
-				tr.CallOnce(
-					tr.BuildCaller(
-						tr.MethodEcho,
-						tr.WithMetadata(
-							map[string]string{
-								// Header has the prefix wo, send to v2.
-								"x-variant": "wooop",
-							},
+			doAssertPreUpdate: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-0", 1),
+				),
+			),
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, _ []tr.Backend) {
+				// Clients dialing an alias get the updates of the listener.
+				_, err := k8s.GTCApi.ApiV1alpha1().GRPCListeners("default").Update(
+					context.Background(),
+					tr.Ptr(
+						tr.BuildGRPCListener(
+							"test-xds",
+							"default",
+							tr.WithAliases("payments", "payments.prod"),
+							tr.WithRoutes(
+								tr.BuildRoute(
+									tr.WithBackends(
+										tr.BuildBackend(
+											tr.WithServiceRef(
+												gtcv1alpha1.ServiceRef{
+													Name: serviceNameV2,
+													Port: grpcPort,
+												},
+											),
+										),
+									),
+								),
+							),
 						),
 					),
-					tr.NoCallErrors,
-					tr.CountByBackendID(
-						tr.AssertCount("backend-1", 1),
-						tr.AssertCount("backend-0", 0),
-					),
-				),
+					metav1.UpdateOptions{},
+				)
+				require.NoError(t, err)
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
 				tr.CallOnce(
 					tr.BuildCaller(
 						tr.MethodEcho,
-						tr.WithMetadata(
-							map[string]string{
-								// Header has not the prefix wo, send to v1.
-								"x-variant": "not",
-							},
-						),
 					),
 					tr.NoCallErrors,
 					tr.CountByBackendID(
-						tr.AssertCount("backend-0", 1),
-						tr.AssertCount("backend-1", 0),
+						tr.AssertCount("backend-1", 1),
 					),
 				),
 			),
-			updateResources:    noChange,
-			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "route matcher metadata suffix match",
-			backendCount: 2,
+			desc:         "listener alias removed",
+			backendCount: 1,
+
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
-				return tr.AppendEndpointSlices(
-					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
-					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2]),
-				)
+				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
 			},
 			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
 				return []gtcv1alpha1.GRPCListener{
 					tr.BuildGRPCListener(
 						"test-xds",
 						"default",
+						tr.WithAliases("payments"),
 						tr.WithRoutes(
-							tr.BuildRoute(
-								tr.WithRouteMatcher(
-									tr.BuildRouteMatcher(
-										tr.WithMetadataMatchers(
-											tr.MetadataSuffixMatch(
-												"x-variant",
-												"oop",
-											),
-										),
-									),
-								),
-								tr.WithBackends(
-									tr.BuildBackend(
-										tr.WithServiceRef(
-											gtcv1alpha1.ServiceRef{
-												Name: serviceNameV2,
-												Port: grpcPort,
-											},
-										),
-									),
-								),
-							),
 							tr.BuildRoute(
 								tr.WithBackends(
 									tr.BuildBackend(
@@ -1123,48 +1110,60 @@ func TestServer(t *testing.T) {
 					),
 				}
 			},
-			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			buildCallContext:    tr.DefaultCallContext("xds:///default/payments"),
 			setBackendsBehavior: answer,
-			doAssertPreUpdate: tr.MultiAssert(
-				tr.CallOnce(
-					tr.BuildCaller(
-						tr.MethodEcho,
-						tr.WithMetadata(
-							map[string]string{
-								// Header has the sufix oop, send to v2.
-								"x-variant": "wooop",
-							},
+			doAssertPreUpdate: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-0", 1),
+				),
+			),
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, _ []tr.Backend) {
+				// Clients dialing the removed alias are told it doesn't exist anymore.
+				_, err := k8s.GTCApi.ApiV1alpha1().GRPCListeners("default").Update(
+					context.Background(),
+					tr.Ptr(
+						tr.BuildGRPCListener(
+							"test-xds",
+							"default",
+							tr.WithRoutes(
+								tr.BuildRoute(
+									tr.WithBackends(
+										tr.BuildBackend(
+											tr.WithServiceRef(
+												gtcv1alpha1.ServiceRef{
+													Name: serviceNameV1,
+													Port: grpcPort,
+												},
+											),
+										),
+									),
+								),
+							),
 						),
 					),
-					tr.NoCallErrors,
-					tr.CountByBackendID(
-						tr.AssertCount("backend-1", 1),
-						tr.AssertCount("backend-0", 0),
-					),
-				),
+					metav1.UpdateOptions{},
+				)
+				require.NoError(t, err)
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
 				tr.CallOnce(
 					tr.BuildCaller(
 						tr.MethodEcho,
-						tr.WithMetadata(
-							map[string]string{
-								// Header has not the suffix oop, send to v1.
-								"x-variant": "not",
-							},
-						),
-					),
-					tr.NoCallErrors,
-					tr.CountByBackendID(
-						tr.AssertCount("backend-0", 1),
-						tr.AssertCount("backend-1", 0),
+						tr.WithTimeout(time.Second),
 					),
+					tr.MustFail,
 				),
 			),
-			updateResources:    noChange,
-			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "route matcher runtime fraction traffic splitting",
+			desc:         "listener virtual hosts",
 			backendCount: 2,
+
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
 				return tr.AppendEndpointSlices(
 					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
@@ -1176,29 +1175,25 @@ func TestServer(t *testing.T) {
 					tr.BuildGRPCListener(
 						"test-xds",
 						"default",
-						tr.WithRoutes(
-							tr.BuildRoute(
-								tr.WithRouteMatcher(
-									tr.BuildRouteMatcher(
-										tr.WithFractionMatcher(
-											gtcv1alpha1.Fraction{
-												Numerator:   20,
-												Denominator: "hundred",
-											},
-										),
-									),
-								),
-								tr.WithBackends(
-									tr.BuildBackend(
-										tr.WithServiceRef(
-											gtcv1alpha1.ServiceRef{
-												Name: serviceNameV2,
-												Port: grpcPort,
-											},
+						tr.WithAliases("test-xds-premium"),
+						tr.WithVirtualHosts(
+							tr.BuildVirtualHost(
+								[]string{"test-xds-premium"},
+								tr.BuildRoute(
+									tr.WithBackends(
+										tr.BuildBackend(
+											tr.WithServiceRef(
+												gtcv1alpha1.ServiceRef{
+													Name: serviceNameV2,
+													Port: grpcPort,
+												},
+											),
 										),
 									),
 								),
 							),
+						),
+						tr.WithRoutes(
 							tr.BuildRoute(
 								tr.WithBackends(
 									tr.BuildBackend(
@@ -1215,70 +1210,98 @@ func TestServer(t *testing.T) {
 					),
 				}
 			},
-			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds-premium"),
 			setBackendsBehavior: answer,
-			doAssertPreUpdate: tr.CallN(
-				tr.BuildCaller(
-					tr.MethodEcho,
+			doAssertPreUpdate: tr.MultiAssert(
+				tr.AssertRouteConfig(
+					"localhost:16000",
+					&corev3.Node{Id: "test-id"},
+					"default/test-xds-premium",
+					func(t *testing.T, routeConfig *routev3.RouteConfiguration) {
+						vhosts := routeConfig.GetVirtualHosts()
+						require.Len(t, vhosts, 2)
+						assert.Equal(t, []string{"default/test-xds-premium", "default%2Ftest-xds-premium", "test-xds-premium"}, vhosts[0].GetDomains())
+						assert.Equal(t, []string{"default/test-xds", "default%2Ftest-xds", "test-xds"}, vhosts[1].GetDomains())
+					},
 				),
-				10000,
-				tr.NoCallErrors,
-				tr.CountByBackendID(
-					tr.AssertCountWithinDelta("backend-1", 2000, 500.0),
-					tr.AssertCountWithinDelta("backend-0", 8000, 500.0),
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-1", 1),
+					),
 				),
 			),
-			updateResources:    noChange,
-			doAssertPostUpdate: noAssert,
-		},
-		{
-			desc:         "listener max stream duration",
-			backendCount: 1,
-			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
-				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
-			},
-			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
-				return []gtcv1alpha1.GRPCListener{
-					tr.BuildGRPCListener(
-						"test-xds",
-						"default",
-						tr.WithMaxStreamDuration(50*time.Millisecond),
-						tr.WithRoutes(
-							tr.BuildRoute(
-								tr.WithBackends(
-									tr.BuildBackend(
-										tr.WithServiceRef(
-											gtcv1alpha1.ServiceRef{
-												Name: serviceNameV1,
-												Port: grpcPort,
-											},
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, _ []tr.Backend) {
+				// The virtual host now serves the name of the listener, the alias falls back to the listener routes.
+				_, err := k8s.GTCApi.ApiV1alpha1().GRPCListeners("default").Update(
+					context.Background(),
+					tr.Ptr(
+						tr.BuildGRPCListener(
+							"test-xds",
+							"default",
+							tr.WithAliases("test-xds-premium"),
+							tr.WithVirtualHosts(
+								tr.BuildVirtualHost(
+									[]string{"test-xds"},
+									tr.BuildRoute(
+										tr.WithBackends(
+											tr.BuildBackend(
+												tr.WithServiceRef(
+													gtcv1alpha1.ServiceRef{
+														Name: serviceNameV2,
+														Port: grpcPort,
+													},
+												),
+											),
+										),
+									),
+								),
+							),
+							tr.WithRoutes(
+								tr.BuildRoute(
+									tr.WithBackends(
+										tr.BuildBackend(
+											tr.WithServiceRef(
+												gtcv1alpha1.ServiceRef{
+													Name: serviceNameV1,
+													Port: grpcPort,
+												},
+											),
 										),
 									),
 								),
 							),
 						),
 					),
-				}
+					metav1.UpdateOptions{},
+				)
+				require.NoError(t, err)
 			},
-			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
-			setBackendsBehavior: hang(10 * time.Second),
-			doAssertPreUpdate: tr.WithinDelay(
-				time.Second,
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
 				tr.CallOnce(
 					tr.BuildCaller(
 						tr.MethodEcho,
 					),
-					tr.MustFail,
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-0", 1),
+					),
 				),
 			),
-			updateResources:    noChange,
-			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "route max stream duration",
-			backendCount: 1,
+			desc:         "short listener name in the client namespace",
+			backendCount: 2,
+
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
-				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
+				return tr.AppendEndpointSlices(
+					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
+					tr.BuildEndpointSlices(serviceNameV1, "other", backends[1:2]),
+				)
 			},
 			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
 				return []gtcv1alpha1.GRPCListener{
@@ -1287,7 +1310,6 @@ func TestServer(t *testing.T) {
 						"default",
 						tr.WithRoutes(
 							tr.BuildRoute(
-								tr.WithRouteMaxStreamDuration(50*time.Millisecond),
 								tr.WithBackends(
 									tr.BuildBackend(
 										tr.WithServiceRef(
@@ -1301,38 +1323,13 @@ func TestServer(t *testing.T) {
 							),
 						),
 					),
-				}
-			},
-			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
-			setBackendsBehavior: hang(10 * time.Second),
-			doAssertPreUpdate: tr.WithinDelay(
-				time.Second,
-				tr.CallOnce(
-					tr.BuildCaller(
-						tr.MethodEcho,
-					),
-					tr.MustFail,
-				),
-			),
-			updateResources:    noChange,
-			doAssertPostUpdate: noAssert,
-		},
-		{
-			desc:         "max requests on backend",
-			backendCount: 1,
-			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
-				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
-			},
-			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
-				return []gtcv1alpha1.GRPCListener{
 					tr.BuildGRPCListener(
 						"test-xds",
-						"default",
+						"other",
 						tr.WithRoutes(
 							tr.BuildRoute(
 								tr.WithBackends(
 									tr.BuildBackend(
-										tr.WithMaxRequests(1),
 										tr.WithServiceRef(
 											gtcv1alpha1.ServiceRef{
 												Name: serviceNameV1,
@@ -1346,35 +1343,40 @@ func TestServer(t *testing.T) {
 					),
 				}
 			},
-			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
-			setBackendsBehavior: hang(1 * time.Second),
-			doAssertPreUpdate: tr.CallNParallel(
+			buildCallContext:    nodeCallContext("xds:///test-xds", "other/test-client"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallOnce(
 				tr.BuildCaller(
 					tr.MethodEcho,
 				),
-				10,
-				tr.AggregateByError(
-					tr.AssertCount("ok", 1),
-					tr.AssertAggregatedValuePartial(
-						"rpc error: code = Unavailable desc = max requests 1 exceeded",
-						9,
-					),
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-1", 1),
 				),
 			),
 			updateResources:    noChange,
 			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "listener interceptors fixed delay injection",
-			backendCount: 1,
+			desc:         "listener hostname",
+			backendCount: 2,
+
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
-				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
+				return tr.AppendEndpointSlices(
+					tr.BuildEndpointSlices(serviceNameV1, "other", backends[0:1]),
+					tr.BuildEndpointSlices(serviceNameV2, "other", backends[1:2]),
+				)
 			},
 			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
 				return []gtcv1alpha1.GRPCListener{
 					tr.BuildGRPCListener(
 						"test-xds",
-						"default",
+						"other",
+						tr.WithAnnotations(
+							map[string]string{
+								gtcv1alpha1.AnnotationHostname: "echo-server",
+							},
+						),
 						tr.WithRoutes(
 							tr.BuildRoute(
 								tr.WithBackends(
@@ -1389,102 +1391,75 @@ func TestServer(t *testing.T) {
 								),
 							),
 						),
-						tr.WithInterceptors(
-							gtcv1alpha1.Interceptor{
-								Fault: &gtcv1alpha1.FaultInterceptor{
-									Delay: &gtcv1alpha1.FaultDelay{
-										Fixed: tr.DurationPtr(500 * time.Millisecond),
-										Percentage: &gtcv1alpha1.Fraction{
-											Numerator:   100,
-											Denominator: "hundred",
-										},
-									},
-								},
-							},
-						),
 					),
 				}
 			},
-			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			// The client namespace is unknown, the hostname is looked up in all namespaces.
+			buildCallContext:    tr.DefaultCallContext("xds:///echo-server"),
 			setBackendsBehavior: answer,
-			doAssertPreUpdate: tr.ExceedDelay(
-				200*time.Millisecond,
-				tr.CallOnce(
-					tr.BuildCaller(
-						tr.MethodEcho,
-					),
-					tr.NoCallErrors,
+			doAssertPreUpdate: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-0", 1),
 				),
 			),
-			updateResources:    noChange,
-			doAssertPostUpdate: noAssert,
-		},
-		{
-			desc:         "listener interceptors delay injection",
-			backendCount: 1,
-			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
-				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
-			},
-			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
-				return []gtcv1alpha1.GRPCListener{
-					tr.BuildGRPCListener(
-						"test-xds",
-						"default",
-						tr.WithRoutes(
-							tr.BuildRoute(
-								tr.WithBackends(
-									tr.BuildBackend(
-										tr.WithServiceRef(
-											gtcv1alpha1.ServiceRef{
-												Name: serviceNameV1,
-												Port: grpcPort,
-											},
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, _ []tr.Backend) {
+				_, err := k8s.GTCApi.ApiV1alpha1().GRPCListeners("other").Update(
+					context.Background(),
+					tr.Ptr(
+						tr.BuildGRPCListener(
+							"test-xds",
+							"other",
+							tr.WithAnnotations(
+								map[string]string{
+									gtcv1alpha1.AnnotationHostname: "echo-server",
+								},
+							),
+							tr.WithRoutes(
+								tr.BuildRoute(
+									tr.WithBackends(
+										tr.BuildBackend(
+											tr.WithServiceRef(
+												gtcv1alpha1.ServiceRef{
+													Name: serviceNameV2,
+													Port: grpcPort,
+												},
+											),
 										),
 									),
 								),
 							),
 						),
-						tr.WithInterceptors(
-							gtcv1alpha1.Interceptor{
-								Fault: &gtcv1alpha1.FaultInterceptor{
-									Delay: &gtcv1alpha1.FaultDelay{
-										Metadata: &gtcv1alpha1.MetadataFault{},
-										Percentage: &gtcv1alpha1.Fraction{
-											Numerator:   100,
-											Denominator: "hundred",
-										},
-									},
-								},
-							},
-						),
 					),
-				}
+					metav1.UpdateOptions{},
+				)
+				require.NoError(t, err)
 			},
-			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
-			setBackendsBehavior: answer,
-			doAssertPreUpdate: tr.ExceedDelay(
-				200*time.Millisecond,
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
 				tr.CallOnce(
 					tr.BuildCaller(
 						tr.MethodEcho,
-						tr.WithMetadata(
-							map[string]string{
-								// Delay by 500ms.
-								"x-envoy-fault-delay-request": "500",
-							},
-						),
 					),
 					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-1", 1),
+					),
 				),
 			),
-			updateResources:    noChange,
-			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "listener interceptors abort injection grpc",
-			backendCount: 1,
+			desc:         "routes share clusters of identical backends",
+			backendCount: 2,
+
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
-				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
+				return tr.AppendEndpointSlices(
+					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
+					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2]),
+				)
 			},
 			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
 				return []gtcv1alpha1.GRPCListener{
@@ -1493,6 +1468,15 @@ func TestServer(t *testing.T) {
 						"default",
 						tr.WithRoutes(
 							tr.BuildRoute(
+								tr.WithRouteMatcher(
+									tr.BuildRouteMatcher(
+										tr.WithMethodMatcher(
+											"echo",
+											"Echo",
+											"EchoPremium",
+										),
+									),
+								),
 								tr.WithBackends(
 									tr.BuildBackend(
 										tr.WithServiceRef(
@@ -1502,41 +1486,100 @@ func TestServer(t *testing.T) {
 											},
 										),
 									),
-								),
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithBackendWeight(3),
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+									tr.BuildBackend(
+										tr.WithBackendWeight(0),
+										tr.WithBackendLBPolicy("ring_hash"),
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
 							),
-						),
-						tr.WithInterceptors(
-							gtcv1alpha1.Interceptor{
-								Fault: &gtcv1alpha1.FaultInterceptor{
-									Abort: &gtcv1alpha1.FaultAbort{
-										Code: tr.Ptr(uint32(4)),
-										Percentage: &gtcv1alpha1.Fraction{
-											Numerator:   100,
-											Denominator: "hundred",
-										},
-									},
-								},
-							},
 						),
 					),
 				}
 			},
 			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
 			setBackendsBehavior: answer,
-			doAssertPreUpdate: tr.CallOnce(
-				tr.BuildCaller(
-					tr.MethodEcho,
+			doAssertPreUpdate: tr.MultiAssert(
+				tr.AssertRouteConfig(
+					"localhost:16000",
+					&corev3.Node{Id: "test-id"},
+					"default/test-xds",
+					func(t *testing.T, routeConfig *routev3.RouteConfiguration) {
+						routes := routeConfig.GetVirtualHosts()[0].GetRoutes()
+						require.Len(t, routes, 2)
+
+						// Identical backends of a route are merged, their weights are summed.
+						premiumClusters := routes[0].GetRoute().GetWeightedClusters()
+						require.Len(t, premiumClusters.GetClusters(), 1)
+						assert.Equal(t, uint32(2), premiumClusters.GetClusters()[0].GetWeight().GetValue())
+						assert.Equal(t, uint32(2), premiumClusters.GetTotalWeight().GetValue())
+
+						// The weight does not change the cluster, but the LB policy does.
+						defaultClusters := routes[1].GetRoute().GetWeightedClusters()
+						require.Len(t, defaultClusters.GetClusters(), 2)
+						assert.Equal(t, premiumClusters.GetClusters()[0].GetName(), defaultClusters.GetClusters()[0].GetName())
+						assert.NotEqual(t, defaultClusters.GetClusters()[0].GetName(), defaultClusters.GetClusters()[1].GetName())
+					},
+				),
+				tr.CallN(
+					tr.BuildCaller(
+						tr.MethodEchoPremium,
+					),
+					10,
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-0", 10),
+					),
+				),
+				tr.CallN(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					10,
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-0", 10),
+					),
 				),
-				tr.MustFail,
 			),
 			updateResources:    noChange,
 			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "listerner interceptors abort metadata grpc",
-			backendCount: 1,
+			desc:         "route matchers",
+			backendCount: 2,
+
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
-				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
+				return tr.AppendEndpointSlices(
+					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
+					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2]),
+				)
 			},
 			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
 				return []gtcv1alpha1.GRPCListener{
@@ -1544,6 +1587,32 @@ func TestServer(t *testing.T) {
 						"test-xds",
 						"default",
 						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithRouteMatchers(
+									tr.BuildRouteMatcher(
+										tr.WithMethodMatcher(
+											"echo",
+											"Echo",
+											"EchoPremium",
+										),
+									),
+									tr.BuildRouteMatcher(
+										tr.WithMetadataMatchers(
+											tr.MetadataExactMatch("x-tenant", "premium"),
+										),
+									),
+								),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV2,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
 							tr.BuildRoute(
 								tr.WithBackends(
 									tr.BuildBackend(
@@ -1557,81 +1626,77 @@ func TestServer(t *testing.T) {
 								),
 							),
 						),
-						tr.WithInterceptors(
-							gtcv1alpha1.Interceptor{
-								Fault: &gtcv1alpha1.FaultInterceptor{
-									Abort: &gtcv1alpha1.FaultAbort{
-										Metadata: &gtcv1alpha1.MetadataFault{},
-										Percentage: &gtcv1alpha1.Fraction{
-											Numerator:   100,
-											Denominator: "hundred",
-										},
-									},
-								},
-							},
-						),
 					),
 				}
 			},
 			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
 			setBackendsBehavior: answer,
-			doAssertPreUpdate: tr.CallOnce(
-				tr.BuildCaller(
-					tr.MethodEcho,
-					tr.WithMetadata(
-						map[string]string{
-							"x-envoy-fault-abort-grpc-request": "3",
-						},
+			doAssertPreUpdate: tr.MultiAssert(
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEchoPremium,
+					),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						// Matched by the first matcher.
+						tr.AssertCount("backend-1", 1),
+						tr.AssertCount("backend-0", 0),
+					),
+				),
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithMetadata(map[string]string{"x-tenant": "premium"}),
+					),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						// Matched by the second matcher.
+						tr.AssertCount("backend-1", 1),
+						tr.AssertCount("backend-0", 0),
+					),
+				),
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-0", 1),
+						tr.AssertCount("backend-1", 0),
 					),
 				),
-				tr.MustFail,
 			),
 			updateResources:    noChange,
 			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "route interceptors overrides abort",
-			backendCount: 1,
+			desc:         "route matcher path regex matching",
+			backendCount: 2,
+
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
-				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
+				return tr.AppendEndpointSlices(
+					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
+					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2]),
+				)
 			},
 			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
 				return []gtcv1alpha1.GRPCListener{
 					tr.BuildGRPCListener(
 						"test-xds",
 						"default",
-						tr.WithInterceptors(
-							gtcv1alpha1.Interceptor{
-								Fault: &gtcv1alpha1.FaultInterceptor{
-									Abort: &gtcv1alpha1.FaultAbort{
-										Code: tr.Ptr(uint32(10)),
-										Percentage: &gtcv1alpha1.Fraction{
-											Numerator:   100,
-											Denominator: "hundred",
-										},
-									},
-								},
-							},
-						),
 						tr.WithRoutes(
 							tr.BuildRoute(
 								tr.WithRouteMatcher(
 									tr.BuildRouteMatcher(
-										tr.WithMethodMatcher("echo", "Echo", "EchoPremium"),
-									),
-								),
-								tr.WithRouteInterceptorOverrides(
-									gtcv1alpha1.Interceptor{
-										Fault: &gtcv1alpha1.FaultInterceptor{
-											Abort: &gtcv1alpha1.FaultAbort{
-												Code: tr.Ptr(uint32(15)),
-												Percentage: &gtcv1alpha1.Fraction{
-													Numerator:   100,
-													Denominator: "hundred",
+										tr.WithPathMatcher(
+											gtcv1alpha1.PathMatcher{
+												Regex: &gtcv1alpha1.RegexMatcher{
+													Regex:  `/echo\.Echo/Echo.+`,
+													Engine: "re2",
 												},
 											},
-										},
-									},
+										),
+									),
 								),
 								tr.WithBackends(
 									tr.BuildBackend(
@@ -1664,63 +1729,61 @@ func TestServer(t *testing.T) {
 			setBackendsBehavior: answer,
 			doAssertPreUpdate: tr.MultiAssert(
 				tr.CallOnce(
-					tr.BuildCaller(tr.MethodEchoPremium),
-					tr.MustFailWithCode(codes.DataLoss),
+					tr.BuildCaller(
+						tr.MethodEchoPremium,
+					),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						// One call for the second backend, because we're calling premium.
+						tr.AssertCount("backend-1", 1),
+						tr.AssertCount("backend-0", 0),
+					),
 				),
 				tr.CallOnce(
-					tr.BuildCaller(tr.MethodEcho),
-					tr.MustFailWithCode(codes.Aborted),
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						// No calls for the first set of backends
+						// First backend should get a call.
+						tr.AssertCount("backend-0", 1),
+						tr.AssertCount("backend-1", 0),
+					),
 				),
 			),
 			updateResources:    noChange,
 			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "backend interceptors overrides abort",
-			backendCount: 1,
+			desc:         "route matcher case insensitive path prefix matching",
+			backendCount: 2,
+
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
-				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
+				return tr.AppendEndpointSlices(
+					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
+					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2]),
+				)
 			},
 			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
 				return []gtcv1alpha1.GRPCListener{
 					tr.BuildGRPCListener(
 						"test-xds",
 						"default",
-						tr.WithInterceptors(
-							gtcv1alpha1.Interceptor{
-								Fault: &gtcv1alpha1.FaultInterceptor{
-									Abort: &gtcv1alpha1.FaultAbort{
-										Code: tr.Ptr(uint32(10)),
-										Percentage: &gtcv1alpha1.Fraction{
-											Numerator:   100,
-											Denominator: "hundred",
-										},
-									},
-								},
-							},
-						),
 						tr.WithRoutes(
 							tr.BuildRoute(
 								tr.WithRouteMatcher(
 									tr.BuildRouteMatcher(
-										tr.WithMethodMatcher("echo", "Echo", "EchoPremium"),
+										tr.WithPathMatcher(
+											gtcv1alpha1.PathMatcher{
+												Prefix:        "/ECHO.ECHO/ECHOPREMIUM",
+												CaseSensitive: tr.Ptr(false),
+											},
+										),
 									),
 								),
 								tr.WithBackends(
 									tr.BuildBackend(
-										tr.WithBackendInterceptorOverrides(
-											gtcv1alpha1.Interceptor{
-												Fault: &gtcv1alpha1.FaultInterceptor{
-													Abort: &gtcv1alpha1.FaultAbort{
-														Code: tr.Ptr(uint32(15)),
-														Percentage: &gtcv1alpha1.Fraction{
-															Numerator:   100,
-															Denominator: "hundred",
-														},
-													},
-												},
-											},
-										),
 										tr.WithServiceRef(
 											gtcv1alpha1.ServiceRef{
 												Name: serviceNameV2,
@@ -1750,40 +1813,68 @@ func TestServer(t *testing.T) {
 			setBackendsBehavior: answer,
 			doAssertPreUpdate: tr.MultiAssert(
 				tr.CallOnce(
-					tr.BuildCaller(tr.MethodEchoPremium),
-					tr.MustFailWithCode(codes.DataLoss),
-				),
-				tr.CallOnce(
-					tr.BuildCaller(tr.MethodEcho),
-					tr.MustFailWithCode(codes.Aborted),
+					tr.BuildCaller(
+						tr.MethodEchoPremium,
+					),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						// One call for the second backend, because we're calling premium.
+						tr.AssertCount("backend-1", 1),
+						tr.AssertCount("backend-0", 0),
+					),
+				),
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						// No calls for the first set of backends
+						// First backend should get a call.
+						tr.AssertCount("backend-0", 1),
+						tr.AssertCount("backend-1", 0),
+					),
 				),
 			),
 			updateResources:    noChange,
 			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "update single call service",
+			desc:         "route matcher service matcher",
 			backendCount: 2,
+
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
 				return tr.AppendEndpointSlices(
-					tr.BuildEndpointSlices(
-						serviceNameV1,
-						defaultNamespace,
-						backends[0:1],
-					),
-					tr.BuildEndpointSlices(
-						serviceNameV2,
-						defaultNamespace,
-						backends[1:2],
-					),
+					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
+					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2]),
 				)
 			},
-			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
 				return []gtcv1alpha1.GRPCListener{
 					tr.BuildGRPCListener(
 						"test-xds",
 						"default",
 						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithRouteMatcher(
+									tr.BuildRouteMatcher(
+										tr.WithServiceMatcher(
+											"echo",
+											"Echo",
+										),
+									),
+								),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV2,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
 							tr.BuildRoute(
 								tr.WithBackends(
 									tr.BuildBackend(
@@ -1808,68 +1899,47 @@ func TestServer(t *testing.T) {
 				),
 				tr.NoCallErrors,
 				tr.CountByBackendID(
-					tr.AssertCount("backend-0", 1),
-				),
-			),
-			updateResources: func(t *testing.T, k8s tr.FakeK8s, _ []tr.Backend) {
-				// We switch the backend to v2, which means that backend pod should point to a new instance.
-				_, err := k8s.GTCApi.ApiV1alpha1().GRPCListeners("default").Update(
-					context.Background(),
-					tr.Ptr(
-						tr.BuildGRPCListener(
-							"test-xds",
-							"default",
-							tr.WithRoutes(
-								tr.BuildRoute(
-									tr.WithBackends(
-										tr.BuildBackend(
-											tr.WithServiceRef(
-												gtcv1alpha1.ServiceRef{
-													Name: serviceNameV2,
-													Port: grpcPort,
-												},
-											),
-										),
-									),
-								),
-							),
-						),
-					),
-					metav1.UpdateOptions{},
-				)
-				require.NoError(t, err)
-			},
-			doAssertPostUpdate: tr.MultiAssert(
-				tr.Wait(500*time.Millisecond),
-				tr.CallOnce(
-					tr.BuildCaller(
-						tr.MethodEcho,
-					),
-					tr.NoCallErrors,
-					tr.CountByBackendID(
-						tr.AssertCount("backend-1", 1),
-					),
+					// One call for the second backend, because we're calling premium.
+					tr.AssertCount("backend-1", 1),
+					tr.AssertCount("backend-0", 0),
 				),
 			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "update single call endpointslice",
+			desc:         "route matcher namespace matcher",
 			backendCount: 2,
+
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
 				return tr.AppendEndpointSlices(
-					tr.BuildEndpointSlices(
-						serviceNameV1,
-						defaultNamespace,
-						backends[0:1],
-					),
+					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
+					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2]),
 				)
 			},
-			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
 				return []gtcv1alpha1.GRPCListener{
 					tr.BuildGRPCListener(
 						"test-xds",
 						"default",
 						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithRouteMatcher(
+									tr.BuildRouteMatcher(
+										tr.WithNamespaceMatcher("echo"),
+									),
+								),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV2,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
 							tr.BuildRoute(
 								tr.WithBackends(
 									tr.BuildBackend(
@@ -1894,68 +1964,64 @@ func TestServer(t *testing.T) {
 				),
 				tr.NoCallErrors,
 				tr.CountByBackendID(
-					tr.AssertCount("backend-0", 1),
-				),
-			),
-			updateResources: func(t *testing.T, k8s tr.FakeK8s, backends []tr.Backend) {
-				// Write the same endpoints, but pointing to the backend at index 1.
-				newEps := tr.BuildEndpointSlices(serviceNameV1, defaultNamespace, backends[1:2])
-
-				for _, ep := range newEps {
-					_, err := k8s.K8s.DiscoveryV1().EndpointSlices(defaultNamespace).Update(
-						context.Background(),
-						ep.DeepCopy(),
-						metav1.UpdateOptions{},
-					)
-					require.NoError(t, err)
-				}
-			},
-			doAssertPostUpdate: tr.MultiAssert(
-				tr.Wait(500*time.Millisecond),
-				tr.CallOnce(
-					tr.BuildCaller(
-						tr.MethodEcho,
-					),
-					tr.NoCallErrors,
-					tr.CountByBackendID(
-						tr.AssertCount("backend-1", 1),
-					),
+					// One call for the second backend, because we're calling premium.
+					tr.AssertCount("backend-1", 1),
+					tr.AssertCount("backend-0", 0),
 				),
 			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "update endpointslice with localities",
+			desc:         "route matcher metadata invert matching",
 			backendCount: 2,
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
 				return tr.AppendEndpointSlices(
-					tr.BuildEndpointSlices(
-						serviceNameV1,
-						defaultNamespace,
-						backends[0:1],
-					),
+					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
+					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2]),
 				)
 			},
-			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
 				return []gtcv1alpha1.GRPCListener{
 					tr.BuildGRPCListener(
 						"test-xds",
 						"default",
 						tr.WithRoutes(
 							tr.BuildRoute(
-								tr.WithBackends(
-									tr.BuildBackend(
-										tr.WithLocalities(
-											tr.BuildLocality(
-												tr.WithLocalityServiceRef(
-													gtcv1alpha1.ServiceRef{
-														Name: serviceNameV1,
-														Port: grpcPort,
-													},
+								tr.WithRouteMatcher(
+									tr.BuildRouteMatcher(
+										tr.WithMetadataMatchers(
+											tr.MetadataInvertMatch(
+												tr.MetadataExactMatch(
+													"x-variant",
+													"Awesome",
 												),
 											),
 										),
 									),
 								),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV2,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
 							),
 						),
 					),
@@ -1963,196 +2029,79 @@ func TestServer(t *testing.T) {
 			},
 			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
 			setBackendsBehavior: answer,
-			doAssertPreUpdate: tr.CallOnce(
-				tr.BuildCaller(
-					tr.MethodEcho,
-				),
-				tr.NoCallErrors,
-				tr.CountByBackendID(
-					tr.AssertCount("backend-0", 1),
-				),
-			),
-			updateResources: func(t *testing.T, k8s tr.FakeK8s, backends []tr.Backend) {
-				// Write the same endpoints, but pointing to the first backend.
-				newEps := tr.BuildEndpointSlices(serviceNameV1, defaultNamespace, backends[1:2])
-
-				for _, ep := range newEps {
-					_, err := k8s.K8s.DiscoveryV1().EndpointSlices(defaultNamespace).Update(
-						context.Background(),
-						ep.DeepCopy(),
-						metav1.UpdateOptions{},
-					)
-					require.NoError(t, err)
-				}
-			},
-			doAssertPostUpdate: tr.MultiAssert(
-				tr.Wait(500*time.Millisecond),
+			doAssertPreUpdate: tr.MultiAssert(
 				tr.CallOnce(
 					tr.BuildCaller(
 						tr.MethodEcho,
+						tr.WithMetadata(
+							map[string]string{
+								"x-variant": "Awesome",
+							},
+						),
 					),
 					tr.NoCallErrors,
 					tr.CountByBackendID(
-						tr.AssertCount("backend-1", 1),
+						tr.AssertCount("backend-1", 0),
+						tr.AssertCount("backend-0", 1),
 					),
 				),
-			),
-		},
-		{
-			desc:         "update single call non existing backend",
-			backendCount: 2,
-			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
-				return tr.AppendEndpointSlices(
-					tr.BuildEndpointSlices(
-						serviceNameV1,
-						defaultNamespace,
-						backends[0:1],
-					),
-				)
-			},
-			buildGRPCListeners:  func([]tr.Backend) []gtcv1alpha1.GRPCListener { return nil },
-			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
-			setBackendsBehavior: answer,
-			doAssertPreUpdate: tr.CallOnce(
-				tr.BuildCaller(
-					tr.MethodEcho,
-					tr.WithTimeout(time.Second),
-				),
-				tr.MustFail,
-			),
-			updateResources: func(t *testing.T, k8s tr.FakeK8s, backends []tr.Backend) {
-				lis := tr.BuildGRPCListener(
-					"test-xds",
-					"default",
-					tr.WithRoutes(
-						tr.BuildRoute(
-							tr.WithBackends(
-								tr.BuildBackend(
-									tr.WithServiceRef(
-										gtcv1alpha1.ServiceRef{
-											Name: serviceNameV1,
-											Port: grpcPort,
-										},
-									),
-								),
-							),
-						),
-					),
-				)
-
-				_, err := k8s.GTCApi.ApiV1alpha1().GRPCListeners("default").Create(
-					context.Background(),
-					&lis,
-					metav1.CreateOptions{},
-				)
-				require.NoError(t, err)
-			},
-			doAssertPostUpdate: tr.MultiAssert(
-				tr.Wait(500*time.Millisecond),
 				tr.CallOnce(
 					tr.BuildCaller(
 						tr.MethodEcho,
+						tr.WithMetadata(
+							map[string]string{
+								"x-variant": "NotAwesome",
+							},
+						),
 					),
 					tr.NoCallErrors,
 					tr.CountByBackendID(
-						tr.AssertCount("backend-0", 1),
+						tr.AssertCount("backend-0", 0),
+						tr.AssertCount("backend-1", 1),
 					),
 				),
 			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "listener retry",
-			backendCount: 1,
+			desc:         "route matcher metadata exact matching",
+			backendCount: 2,
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
-				return tr.BuildEndpointSlices(
-					serviceNameV1,
-					defaultNamespace,
-					backends[0:1],
+				return tr.AppendEndpointSlices(
+					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
+					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2]),
 				)
 			},
-			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
 				return []gtcv1alpha1.GRPCListener{
 					tr.BuildGRPCListener(
 						"test-xds",
 						"default",
-						tr.WithListenerRetry(
-							gtcv1alpha1.RetryPolicy{
-								RetryOn: []string{
-									"unavailable",
-									"cancelled",
-								},
-								NumRetries: tr.Ptr(uint32(2)),
-								Backoff: &gtcv1alpha1.RetryBackoff{
-									BaseInterval: metav1.Duration{
-										Duration: 500 * time.Millisecond,
-									},
-									MaxInterval: tr.DurationPtr(time.Second),
-								},
-							},
-						),
 						tr.WithRoutes(
 							tr.BuildRoute(
+								tr.WithRouteMatcher(
+									tr.BuildRouteMatcher(
+										tr.WithMetadataMatchers(
+											tr.MetadataExactMatch(
+												"x-variant",
+												"Awesome",
+											),
+										),
+									),
+								),
 								tr.WithBackends(
 									tr.BuildBackend(
 										tr.WithServiceRef(
 											gtcv1alpha1.ServiceRef{
-												Name: serviceNameV1,
+												Name: serviceNameV2,
 												Port: grpcPort,
 											},
 										),
 									),
 								),
 							),
-						),
-					),
-				}
-			},
-			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
-			setBackendsBehavior: sequence(codes.Unavailable, codes.Canceled),
-			doAssertPreUpdate: tr.CallOnce(
-				tr.BuildCaller(
-					tr.MethodEcho,
-				),
-				tr.NoCallErrors,
-				tr.CountByBackendID(
-					tr.AssertCount("backend-0", 1),
-				),
-			),
-			updateResources:    noChange,
-			doAssertPostUpdate: noAssert,
-		},
-		{
-			desc:         "route retry",
-			backendCount: 1,
-			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
-				return tr.BuildEndpointSlices(
-					serviceNameV1,
-					defaultNamespace,
-					backends[0:1],
-				)
-			},
-			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
-				return []gtcv1alpha1.GRPCListener{
-					tr.BuildGRPCListener(
-						"test-xds",
-						"default",
-						tr.WithRoutes(
 							tr.BuildRoute(
-								tr.WithRouteRetry(
-									gtcv1alpha1.RetryPolicy{
-										RetryOn: []string{
-											"unavailable",
-											"cancelled",
-										},
-										NumRetries: tr.Ptr(uint32(2)),
-										Backoff: &gtcv1alpha1.RetryBackoff{
-											BaseInterval: metav1.Duration{
-												Duration: 500 * time.Millisecond,
-											},
-											MaxInterval: tr.DurationPtr(time.Second),
-										},
-									},
-								),
 								tr.WithBackends(
 									tr.BuildBackend(
 										tr.WithServiceRef(
@@ -2169,48 +2118,83 @@ func TestServer(t *testing.T) {
 				}
 			},
 			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
-			setBackendsBehavior: sequence(codes.Canceled, codes.Unavailable),
-			doAssertPreUpdate: tr.CallOnce(
-				tr.BuildCaller(
-					tr.MethodEcho,
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.MultiAssert(
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithMetadata(
+							map[string]string{
+								"x-variant": "Awesome",
+							},
+						),
+					),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						// One call for the second backend, because we're calling premium.
+						tr.AssertCount("backend-1", 1),
+						tr.AssertCount("backend-0", 0),
+					),
 				),
-				tr.NoCallErrors,
-				tr.CountByBackendID(
-					tr.AssertCount("backend-0", 1),
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						// No calls for the first set of backends
+						// First backend should get a call.
+						tr.AssertCount("backend-0", 1),
+						tr.AssertCount("backend-1", 0),
+					),
 				),
 			),
 			updateResources:    noChange,
 			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "ring hash backend lb policy",
+			desc:         "route matcher metadata safe regex match",
 			backendCount: 2,
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
-				return tr.BuildEndpointSlices(
-					serviceNameV1,
-					defaultNamespace,
-					backends,
+				return tr.AppendEndpointSlices(
+					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
+					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2]),
 				)
 			},
-			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
 				return []gtcv1alpha1.GRPCListener{
 					tr.BuildGRPCListener(
 						"test-xds",
 						"default",
 						tr.WithRoutes(
 							tr.BuildRoute(
-								tr.WithRouteHashPolicy(
-									gtcv1alpha1.HashPolicy{Metadata: "country"},
+								tr.WithRouteMatcher(
+									tr.BuildRouteMatcher(
+										tr.WithMetadataMatchers(
+											gtcv1alpha1.MetadataMatcher{
+												Name: "x-variant",
+												Regex: &gtcv1alpha1.RegexMatcher{
+													Regex:  "Awe.*",
+													Engine: "re2",
+												},
+											},
+										),
+									),
 								),
 								tr.WithBackends(
 									tr.BuildBackend(
-										tr.WithBackendLBPolicy("ring_hash"),
-										tr.WithBackendRingHashConfig(
-											gtcv1alpha1.RingHashConfig{
-												MinRingSize: 1024,
-												MaxRingSize: 838860,
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV2,
+												Port: grpcPort,
 											},
 										),
+									),
+								),
+							),
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
 										tr.WithServiceRef(
 											gtcv1alpha1.ServiceRef{
 												Name: serviceNameV1,
@@ -2227,26 +2211,32 @@ func TestServer(t *testing.T) {
 			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
 			setBackendsBehavior: answer,
 			doAssertPreUpdate: tr.MultiAssert(
-				tr.CallN(
+				tr.CallOnce(
 					tr.BuildCaller(
 						tr.MethodEcho,
-						tr.WithMetadata(map[string]string{"country": "france"}),
+						tr.WithMetadata(
+							map[string]string{
+								"x-variant": "Awesome",
+							},
+						),
 					),
-					10,
 					tr.NoCallErrors,
 					tr.CountByBackendID(
-						tr.AssertOneBackendGotAllCalls(10),
+						// One call for the second backend, because we're calling premium.
+						tr.AssertCount("backend-1", 1),
+						tr.AssertCount("backend-0", 0),
 					),
 				),
-				tr.CallN(
+				tr.CallOnce(
 					tr.BuildCaller(
 						tr.MethodEcho,
-						tr.WithMetadata(map[string]string{"country": "sweden"}),
 					),
-					10,
 					tr.NoCallErrors,
 					tr.CountByBackendID(
-						tr.AssertOneBackendGotAllCalls(10),
+						// No calls for the first set of backends
+						// First backend should get a call.
+						tr.AssertCount("backend-0", 1),
+						tr.AssertCount("backend-1", 0),
 					),
 				),
 			),
@@ -2254,47 +2244,137 @@ func TestServer(t *testing.T) {
 			doAssertPostUpdate: noAssert,
 		},
 		{
-			desc:         "topology aware routing",
+			desc:         "route matcher metadata range match",
 			backendCount: 2,
 			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
-				slice0 := tr.BuildEndpointSlice(
-					0,
-					serviceNameV1,
-					defaultNamespace,
-					backends[0],
-					tr.WithEndpointZone("zone-a"),
-					tr.WithEndpointHints(
-						discoveryv1.EndpointHints{
-							ForZones: []discoveryv1.ForZone{
-								{Name: "zone-a"},
-							},
-						},
-					),
-				)
-
-				slice1 := tr.BuildEndpointSlice(
-					1,
-					serviceNameV1,
-					defaultNamespace,
-					backends[1],
-					tr.WithEndpointZone("zone-b"),
-					tr.WithEndpointHints(
-						discoveryv1.EndpointHints{
-							ForZones: []discoveryv1.ForZone{
-								{Name: "zone-b"},
+				return tr.AppendEndpointSlices(
+					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
+					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2]),
+				)
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithRouteMatcher(
+									tr.BuildRouteMatcher(
+										tr.WithMetadataMatchers(
+											gtcv1alpha1.MetadataMatcher{
+												Name: "x-variant",
+												Range: &gtcv1alpha1.RangeMatcher{
+													Start: 10,
+													End:   20,
+												},
+											},
+										),
+									),
+								),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV2,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.MultiAssert(
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithMetadata(
+							map[string]string{
+								// In range, call backend 1.
+								"x-variant": "12",
 							},
-						},
+						),
+					),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-1", 1),
+						tr.AssertCount("backend-0", 0),
+					),
+				),
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithMetadata(
+							map[string]string{
+								// Out of bound, call backend-0.
+								"x-variant": "9",
+							},
+						),
+					),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-0", 1),
+						tr.AssertCount("backend-1", 0),
 					),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "route matcher metadata present match",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.AppendEndpointSlices(
+					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
+					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2]),
 				)
-
-				return []discoveryv1.EndpointSlice{slice0, slice1}
 			},
-			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
 				return []gtcv1alpha1.GRPCListener{
 					tr.BuildGRPCListener(
 						"test-xds",
 						"default",
 						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithRouteMatcher(
+									tr.BuildRouteMatcher(
+										tr.WithMetadataMatchers(
+											tr.MetadataPresentMatch(
+												"x-variant",
+												true,
+											),
+										),
+									),
+								),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV2,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
 							tr.BuildRoute(
 								tr.WithBackends(
 									tr.BuildBackend(
@@ -2313,88 +2393,5406 @@ func TestServer(t *testing.T) {
 			},
 			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
 			setBackendsBehavior: answer,
-			doAssertPreUpdate: tr.CallN(
-				tr.BuildCaller(
-					tr.MethodEcho,
+			doAssertPreUpdate: tr.MultiAssert(
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithMetadata(
+							map[string]string{
+								// Header is present, send to v2.
+								"x-variant": "wooop",
+							},
+						),
+					),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-1", 1),
+						tr.AssertCount("backend-0", 0),
+					),
 				),
-				10,
-				tr.NoCallErrors,
-				tr.CountByBackendID(
-					tr.AssertCount("backend-0", 10),
+				tr.CallOnce(
+					tr.BuildCaller(tr.MethodEcho),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-0", 1),
+						tr.AssertCount("backend-1", 0),
+					),
 				),
 			),
 			updateResources:    noChange,
 			doAssertPostUpdate: noAssert,
 		},
-	} {
-		t.Run(testCase.desc, func(t *testing.T) {
-			backends, err := tr.StartBackends(
-				tr.Config{
-					BackendCount: testCase.backendCount,
-				},
-			)
-			require.NoError(t, err)
-
-			defer func() {
-				err := backends.Stop()
-				require.NoError(t, err)
-			}()
-
-			var (
-				ctx, cancel = context.WithCancel(context.Background())
-				k8s         = tr.NewFakeK8s(
-					t,
-					testCase.buildGRPCListeners(backends),
-					testCase.buildEndpointSlices(backends),
+		{
+			desc:         "route matcher metadata prefix match",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.AppendEndpointSlices(
+					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
+					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2]),
 				)
-				serverExited = make(chan struct{})
-			)
-
-			defer cancel()
-
-			server, err := gtc.NewXDSServer(
-				ctx,
-				gtc.XDSServerConfig{
-					K8sInformers: k8s.K8sInformers,
-					GTCInformers: k8s.GTCInformers,
-					// TODO(jly): find a way to make this parralelizable.
-					// The thing is that having multiple xds servers in parrallel means sadly
-					// having multiple values for the XDS_BOOTSTRAP_CONFIG env variables.
-					// which is impossible as far as I know.
-					BindAddr: ":16000",
-				},
-				newLogger(t),
-			)
-			require.NoError(t, err)
-
-			k8s.Start(ctx, t)
-
-			go func() {
-				err := server.Run(ctx)
-				require.NoError(t, err)
-				close(serverExited)
-			}()
-
-			// Always explicitely stop the server and wait for it to be finished.
-			t.Cleanup(func() {
-				cancel()
-
-				<-serverExited
-			})
-
-			testCase.setBackendsBehavior(t, backends)
-
-			callCtx := testCase.buildCallContext(t)
-			testCase.doAssertPreUpdate(t, callCtx)
-
-			testCase.updateResources(t, k8s, backends)
-
-			testCase.doAssertPostUpdate(t, callCtx)
-
-			err = callCtx.Close()
-			require.NoError(t, err)
-		})
-	}
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithRouteMatcher(
+									tr.BuildRouteMatcher(
+										tr.WithMetadataMatchers(
+											tr.MetadataPrefixMatch(
+												"x-variant",
+												"wo",
+											),
+										),
+									),
+								),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV2,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.MultiAssert(
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithMetadata(
+							map[string]string{
+								// Header has the prefix wo, send to v2.
+								"x-variant": "wooop",
+							},
+						),
+					),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-1", 1),
+						tr.AssertCount("backend-0", 0),
+					),
+				),
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithMetadata(
+							map[string]string{
+								// Header has not the prefix wo, send to v1.
+								"x-variant": "not",
+							},
+						),
+					),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-0", 1),
+						tr.AssertCount("backend-1", 0),
+					),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "route matcher metadata suffix match",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.AppendEndpointSlices(
+					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
+					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2]),
+				)
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithRouteMatcher(
+									tr.BuildRouteMatcher(
+										tr.WithMetadataMatchers(
+											tr.MetadataSuffixMatch(
+												"x-variant",
+												"oop",
+											),
+										),
+									),
+								),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV2,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.MultiAssert(
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithMetadata(
+							map[string]string{
+								// Header has the sufix oop, send to v2.
+								"x-variant": "wooop",
+							},
+						),
+					),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-1", 1),
+						tr.AssertCount("backend-0", 0),
+					),
+				),
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithMetadata(
+							map[string]string{
+								// Header has not the suffix oop, send to v1.
+								"x-variant": "not",
+							},
+						),
+					),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-0", 1),
+						tr.AssertCount("backend-1", 0),
+					),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "route matcher runtime fraction traffic splitting",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.AppendEndpointSlices(
+					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
+					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2]),
+				)
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithRouteMatcher(
+									tr.BuildRouteMatcher(
+										tr.WithFractionMatcher(
+											gtcv1alpha1.Fraction{
+												Numerator:   20,
+												Denominator: "hundred",
+											},
+										),
+									),
+								),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV2,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallN(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				10000,
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCountWithinDelta("backend-1", 2000, 500.0),
+					tr.AssertCountWithinDelta("backend-0", 8000, 500.0),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "listener max stream duration",
+			backendCount: 1,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithMaxStreamDuration(50*time.Millisecond),
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: hang(10 * time.Second),
+			doAssertPreUpdate: tr.WithinDelay(
+				time.Second,
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					tr.MustFail,
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "route max stream duration",
+			backendCount: 1,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithRouteMaxStreamDuration(50*time.Millisecond),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: hang(10 * time.Second),
+			doAssertPreUpdate: tr.WithinDelay(
+				time.Second,
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					tr.MustFail,
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "max requests on backend",
+			backendCount: 1,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithMaxRequests(1),
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: hang(1 * time.Second),
+			doAssertPreUpdate: tr.CallNParallel(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				10,
+				tr.AggregateByError(
+					tr.AssertCount("ok", 1),
+					tr.AssertAggregatedValuePartial(
+						"rpc error: code = Unavailable desc = max requests 1 exceeded",
+						9,
+					),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "listener interceptors fixed delay injection",
+			backendCount: 1,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+						tr.WithInterceptors(
+							gtcv1alpha1.Interceptor{
+								Fault: &gtcv1alpha1.FaultInterceptor{
+									Delay: &gtcv1alpha1.FaultDelay{
+										Fixed: tr.DurationPtr(500 * time.Millisecond),
+										Percentage: &gtcv1alpha1.Fraction{
+											Numerator:   100,
+											Denominator: "hundred",
+										},
+									},
+								},
+							},
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.ExceedDelay(
+				200*time.Millisecond,
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					tr.NoCallErrors,
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "listener interceptors delay injection",
+			backendCount: 1,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+						tr.WithInterceptors(
+							gtcv1alpha1.Interceptor{
+								Fault: &gtcv1alpha1.FaultInterceptor{
+									Delay: &gtcv1alpha1.FaultDelay{
+										Metadata: &gtcv1alpha1.MetadataFault{},
+										Percentage: &gtcv1alpha1.Fraction{
+											Numerator:   100,
+											Denominator: "hundred",
+										},
+									},
+								},
+							},
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.ExceedDelay(
+				200*time.Millisecond,
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithMetadata(
+							map[string]string{
+								// Delay by 500ms.
+								"x-envoy-fault-delay-request": "500",
+							},
+						),
+					),
+					tr.NoCallErrors,
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "listener interceptors abort injection grpc",
+			backendCount: 1,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+						tr.WithInterceptors(
+							gtcv1alpha1.Interceptor{
+								Fault: &gtcv1alpha1.FaultInterceptor{
+									Abort: &gtcv1alpha1.FaultAbort{
+										Code: tr.Ptr(uint32(4)),
+										Percentage: &gtcv1alpha1.Fraction{
+											Numerator:   100,
+											Denominator: "hundred",
+										},
+									},
+								},
+							},
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				tr.MustFail,
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "listerner interceptors abort metadata grpc",
+			backendCount: 1,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+						tr.WithInterceptors(
+							gtcv1alpha1.Interceptor{
+								Fault: &gtcv1alpha1.FaultInterceptor{
+									Abort: &gtcv1alpha1.FaultAbort{
+										Metadata: &gtcv1alpha1.MetadataFault{},
+										Percentage: &gtcv1alpha1.Fraction{
+											Numerator:   100,
+											Denominator: "hundred",
+										},
+									},
+								},
+							},
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+					tr.WithMetadata(
+						map[string]string{
+							"x-envoy-fault-abort-grpc-request": "3",
+						},
+					),
+				),
+				tr.MustFail,
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "listener interceptors abort matching metadata",
+			backendCount: 1,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+						tr.WithInterceptors(
+							gtcv1alpha1.Interceptor{
+								Fault: &gtcv1alpha1.FaultInterceptor{
+									Abort: &gtcv1alpha1.FaultAbort{
+										Code: tr.Ptr(uint32(4)),
+										Percentage: &gtcv1alpha1.Fraction{
+											Numerator:   100,
+											Denominator: "hundred",
+										},
+									},
+									Headers: []gtcv1alpha1.HeaderMatcher{
+										gtcv1alpha1.HeaderMatcher(tr.MetadataExactMatch("x-chaos", "true")),
+									},
+								},
+							},
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.MultiAssert(
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithMetadata(
+							map[string]string{
+								"x-chaos": "true",
+							},
+						),
+					),
+					tr.MustFailWithCode(codes.DeadlineExceeded),
+				),
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithMetadata(
+							map[string]string{
+								"x-chaos": "false",
+							},
+						),
+					),
+					tr.NoCallErrors,
+				),
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					tr.NoCallErrors,
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "route matcher runtime fraction with a fault matching metadata",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.AppendEndpointSlices(
+					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
+					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2]),
+				)
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithRouteMatcher(
+									tr.BuildRouteMatcher(
+										tr.WithFractionMatcher(
+											gtcv1alpha1.Fraction{
+												Numerator:   50,
+												Denominator: "hundred",
+											},
+										),
+									),
+								),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV2,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+						tr.WithInterceptors(
+							gtcv1alpha1.Interceptor{
+								Fault: &gtcv1alpha1.FaultInterceptor{
+									Abort: &gtcv1alpha1.FaultAbort{
+										Code: tr.Ptr(uint32(4)),
+										Percentage: &gtcv1alpha1.Fraction{
+											Numerator:   100,
+											Denominator: "hundred",
+										},
+									},
+									Headers: []gtcv1alpha1.HeaderMatcher{
+										gtcv1alpha1.HeaderMatcher(tr.MetadataExactMatch("x-chaos", "true")),
+									},
+								},
+							},
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			// Calls carrying the metadata would be rolled against the fraction twice, the listener is rejected.
+			doAssertPreUpdate: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+					tr.WithTimeout(time.Second),
+				),
+				tr.MustFail,
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "route interceptors overrides abort matching metadata",
+			backendCount: 1,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithInterceptors(
+							gtcv1alpha1.Interceptor{
+								Fault: &gtcv1alpha1.FaultInterceptor{
+									Abort: &gtcv1alpha1.FaultAbort{
+										Code: tr.Ptr(uint32(10)),
+										Percentage: &gtcv1alpha1.Fraction{
+											Numerator:   100,
+											Denominator: "hundred",
+										},
+									},
+								},
+							},
+						),
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithRouteMatcher(
+									tr.BuildRouteMatcher(
+										tr.WithMethodMatcher("echo", "Echo", "EchoPremium"),
+									),
+								),
+								tr.WithRouteInterceptorOverrides(
+									gtcv1alpha1.Interceptor{
+										Fault: &gtcv1alpha1.FaultInterceptor{
+											Abort: &gtcv1alpha1.FaultAbort{
+												Code: tr.Ptr(uint32(15)),
+												Percentage: &gtcv1alpha1.Fraction{
+													Numerator:   100,
+													Denominator: "hundred",
+												},
+											},
+											Headers: []gtcv1alpha1.HeaderMatcher{
+												gtcv1alpha1.HeaderMatcher(tr.MetadataPresentMatch("x-chaos", true)),
+											},
+										},
+									},
+								),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.MultiAssert(
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEchoPremium,
+						tr.WithMetadata(
+							map[string]string{
+								"x-chaos": "true",
+							},
+						),
+					),
+					tr.MustFailWithCode(codes.DataLoss),
+				),
+				// The route fault replaces the listener fault, even for calls it doesn't match.
+				tr.CallOnce(
+					tr.BuildCaller(tr.MethodEchoPremium),
+					tr.NoCallErrors,
+				),
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithMetadata(
+							map[string]string{
+								"x-chaos": "true",
+							},
+						),
+					),
+					tr.MustFailWithCode(codes.Aborted),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "listener interceptors abort metadata percentage",
+			backendCount: 1,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+						tr.WithInterceptors(
+							gtcv1alpha1.Interceptor{
+								Fault: &gtcv1alpha1.FaultInterceptor{
+									// No percentage, metadata controls it.
+									Abort: &gtcv1alpha1.FaultAbort{
+										Metadata: &gtcv1alpha1.MetadataFault{},
+									},
+								},
+							},
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.MultiAssert(
+				tr.CallN(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithMetadata(
+							map[string]string{
+								"x-envoy-fault-abort-grpc-request":       "3",
+								"x-envoy-fault-abort-request-percentage": "0",
+							},
+						),
+					),
+					10,
+					tr.NoCallErrors,
+				),
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithMetadata(
+							map[string]string{
+								"x-envoy-fault-abort-grpc-request":       "3",
+								"x-envoy-fault-abort-request-percentage": "100",
+							},
+						),
+					),
+					tr.MustFailWithCode(codes.InvalidArgument),
+				),
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithMetadata(
+							map[string]string{
+								"x-envoy-fault-abort-grpc-request": "3",
+							},
+						),
+					),
+					tr.MustFailWithCode(codes.InvalidArgument),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "listener interceptors delay metadata percentage",
+			backendCount: 1,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+						tr.WithInterceptors(
+							gtcv1alpha1.Interceptor{
+								Fault: &gtcv1alpha1.FaultInterceptor{
+									Delay: &gtcv1alpha1.FaultDelay{
+										Metadata: &gtcv1alpha1.MetadataFault{},
+									},
+								},
+							},
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.MultiAssert(
+				tr.WithinDelay(
+					200*time.Millisecond,
+					tr.CallOnce(
+						tr.BuildCaller(
+							tr.MethodEcho,
+							tr.WithMetadata(
+								map[string]string{
+									"x-envoy-fault-delay-request":            "500",
+									"x-envoy-fault-delay-request-percentage": "0",
+								},
+							),
+						),
+						tr.NoCallErrors,
+					),
+				),
+				tr.ExceedDelay(
+					200*time.Millisecond,
+					tr.CallOnce(
+						tr.BuildCaller(
+							tr.MethodEcho,
+							tr.WithMetadata(
+								map[string]string{
+									"x-envoy-fault-delay-request":            "500",
+									"x-envoy-fault-delay-request-percentage": "100",
+								},
+							),
+						),
+						tr.NoCallErrors,
+					),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "route node selector",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return append(
+					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
+					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2])...,
+				)
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithRouteNodeSelector(gtcv1alpha1.NodeSelector{ID: "default/checkout-*"}),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV2,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    nodeCallContext("xds:///default/test-xds", "default/checkout-7d9f"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-1", 1),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "route node selector not matching",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return append(
+					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
+					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2])...,
+				)
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithRouteNodeSelector(gtcv1alpha1.NodeSelector{ID: "default/checkout-*"}),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV2,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    nodeCallContext("xds:///default/test-xds", "default/cart-5c2b"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-0", 1),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "route node selector on the topology of the client pod",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return append(
+					tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1]),
+					tr.BuildEndpointSlices(serviceNameV2, "default", backends[1:2])...,
+				)
+			},
+			nodes: []corev1.Node{
+				tr.BuildNode("node-b", "region-1", "zone-b"),
+			},
+			buildPods: func([]tr.Backend) []corev1.Pod {
+				return []corev1.Pod{
+					tr.BuildPod("client", "clients", nil, tr.WithPodNode("node-b")),
+				}
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithRouteNodeSelector(
+									gtcv1alpha1.NodeSelector{
+										Locality: &gtcv1alpha1.LocalitySelector{Region: "region-1", Zone: "zone-b"},
+									},
+								),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV2,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			// The bootstrap locality of the client is zone-a, yet its pod runs in zone-b.
+			buildCallContext:    podCallContext("clients", "client"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-1", 1),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "listener interceptors node selector",
+			backendCount: 1,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+						tr.WithInterceptors(
+							gtcv1alpha1.Interceptor{
+								Fault: &gtcv1alpha1.FaultInterceptor{
+									Abort: &gtcv1alpha1.FaultAbort{
+										Code: tr.Ptr(uint32(10)),
+										Percentage: &gtcv1alpha1.Fraction{
+											Numerator:   100,
+											Denominator: "hundred",
+										},
+									},
+								},
+								NodeSelector: &gtcv1alpha1.NodeSelector{
+									Metadata: map[string]string{"app": "checkout"},
+								},
+							},
+						),
+					),
+				}
+			},
+			buildCallContext:    metadataCallContext(map[string]string{"app": "checkout"}),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				tr.MustFailWithCode(codes.Aborted),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "route interceptors node selector overrides disabled listener interceptor",
+			backendCount: 1,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithRouteInterceptorOverrides(
+									gtcv1alpha1.Interceptor{
+										Fault: &gtcv1alpha1.FaultInterceptor{
+											Abort: &gtcv1alpha1.FaultAbort{
+												Code: tr.Ptr(uint32(8)),
+												Percentage: &gtcv1alpha1.Fraction{
+													Numerator:   100,
+													Denominator: "hundred",
+												},
+											},
+										},
+										NodeSelector: &gtcv1alpha1.NodeSelector{
+											Locality: &gtcv1alpha1.LocalitySelector{Zone: "zone-a"},
+										},
+									},
+								),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+						tr.WithInterceptors(
+							gtcv1alpha1.Interceptor{
+								Fault: &gtcv1alpha1.FaultInterceptor{
+									Abort: &gtcv1alpha1.FaultAbort{
+										Code: tr.Ptr(uint32(10)),
+										Percentage: &gtcv1alpha1.Fraction{
+											Numerator:   100,
+											Denominator: "hundred",
+										},
+									},
+								},
+								NodeSelector: &gtcv1alpha1.NodeSelector{
+									Metadata: map[string]string{"app": "cart"},
+								},
+							},
+						),
+					),
+				}
+			},
+			buildCallContext:    metadataCallContext(map[string]string{"app": "checkout"}),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				tr.MustFailWithCode(codes.ResourceExhausted),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "route interceptors overrides abort",
+			backendCount: 1,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithInterceptors(
+							gtcv1alpha1.Interceptor{
+								Fault: &gtcv1alpha1.FaultInterceptor{
+									Abort: &gtcv1alpha1.FaultAbort{
+										Code: tr.Ptr(uint32(10)),
+										Percentage: &gtcv1alpha1.Fraction{
+											Numerator:   100,
+											Denominator: "hundred",
+										},
+									},
+								},
+							},
+						),
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithRouteMatcher(
+									tr.BuildRouteMatcher(
+										tr.WithMethodMatcher("echo", "Echo", "EchoPremium"),
+									),
+								),
+								tr.WithRouteInterceptorOverrides(
+									gtcv1alpha1.Interceptor{
+										Fault: &gtcv1alpha1.FaultInterceptor{
+											Abort: &gtcv1alpha1.FaultAbort{
+												Code: tr.Ptr(uint32(15)),
+												Percentage: &gtcv1alpha1.Fraction{
+													Numerator:   100,
+													Denominator: "hundred",
+												},
+											},
+										},
+									},
+								),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV2,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.MultiAssert(
+				tr.CallOnce(
+					tr.BuildCaller(tr.MethodEchoPremium),
+					tr.MustFailWithCode(codes.DataLoss),
+				),
+				tr.CallOnce(
+					tr.BuildCaller(tr.MethodEcho),
+					tr.MustFailWithCode(codes.Aborted),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "backend interceptors overrides abort",
+			backendCount: 1,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithInterceptors(
+							gtcv1alpha1.Interceptor{
+								Fault: &gtcv1alpha1.FaultInterceptor{
+									Abort: &gtcv1alpha1.FaultAbort{
+										Code: tr.Ptr(uint32(10)),
+										Percentage: &gtcv1alpha1.Fraction{
+											Numerator:   100,
+											Denominator: "hundred",
+										},
+									},
+								},
+							},
+						),
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithRouteMatcher(
+									tr.BuildRouteMatcher(
+										tr.WithMethodMatcher("echo", "Echo", "EchoPremium"),
+									),
+								),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithBackendInterceptorOverrides(
+											gtcv1alpha1.Interceptor{
+												Fault: &gtcv1alpha1.FaultInterceptor{
+													Abort: &gtcv1alpha1.FaultAbort{
+														Code: tr.Ptr(uint32(15)),
+														Percentage: &gtcv1alpha1.Fraction{
+															Numerator:   100,
+															Denominator: "hundred",
+														},
+													},
+												},
+											},
+										),
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV2,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.MultiAssert(
+				tr.CallOnce(
+					tr.BuildCaller(tr.MethodEchoPremium),
+					tr.MustFailWithCode(codes.DataLoss),
+				),
+				tr.CallOnce(
+					tr.BuildCaller(tr.MethodEcho),
+					tr.MustFailWithCode(codes.Aborted),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "copies of a backend with different interceptors",
+			backendCount: 1,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(serviceNameV1, "default", backends[0:1])
+			},
+			buildGRPCListeners: func(backends []tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						// Backend interceptors override the filter of the listener.
+						tr.WithInterceptors(gtcv1alpha1.Interceptor{Fault: &gtcv1alpha1.FaultInterceptor{}}),
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithBackendInterceptorOverrides(
+											gtcv1alpha1.Interceptor{
+												Fault: &gtcv1alpha1.FaultInterceptor{
+													Abort: &gtcv1alpha1.FaultAbort{
+														Code: tr.Ptr(uint32(15)),
+														Percentage: &gtcv1alpha1.Fraction{
+															Numerator:   100,
+															Denominator: "hundred",
+														},
+													},
+												},
+											},
+										),
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.MultiAssert(
+				tr.AssertRouteConfig(
+					"localhost:16000",
+					&corev3.Node{Id: "test-id"},
+					"default/test-xds",
+					func(t *testing.T, routeConfig *routev3.RouteConfiguration) {
+						// Each copy keeps its own cluster, to carry its own interceptors.
+						clusters := routeConfig.GetVirtualHosts()[0].GetRoutes()[0].GetRoute().GetWeightedClusters().GetClusters()
+						require.Len(t, clusters, 2)
+						assert.NotEqual(t, clusters[0].GetName(), clusters[1].GetName())
+						assert.NotEmpty(t, clusters[0].GetTypedPerFilterConfig())
+						assert.Empty(t, clusters[1].GetTypedPerFilterConfig())
+					},
+				),
+				tr.CallN(
+					tr.BuildCaller(tr.MethodEcho),
+					100,
+					tr.CountByBackendID(
+						tr.AssertCountWithinDelta("backend-0", 50, 20.0),
+					),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "weighted subsets of a single service",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return []discoveryv1.EndpointSlice{
+					tr.BuildEndpointSlice(0, serviceNameV1, defaultNamespace, backends[0], tr.WithEndpointTargetRef("pod-0")),
+					tr.BuildEndpointSlice(1, serviceNameV1, defaultNamespace, backends[1], tr.WithEndpointTargetRef("pod-1")),
+				}
+			},
+			buildPods: func([]tr.Backend) []corev1.Pod {
+				return []corev1.Pod{
+					tr.BuildPod("pod-0", defaultNamespace, map[string]string{"version": "v1"}),
+					tr.BuildPod("pod-1", defaultNamespace, map[string]string{"version": "v2"}),
+				}
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+										tr.WithBackendSubset(map[string]string{"version": "v1"}),
+										tr.WithBackendWeight(80),
+									),
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+										tr.WithBackendSubset(map[string]string{"version": "v2"}),
+										tr.WithBackendWeight(20),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallN(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				10000,
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCountWithinDelta("backend-0", 8000, 500.0),
+					tr.AssertCountWithinDelta("backend-1", 2000, 500.0),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "update subset pods labels",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return []discoveryv1.EndpointSlice{
+					tr.BuildEndpointSlice(0, serviceNameV1, defaultNamespace, backends[0], tr.WithEndpointTargetRef("pod-0")),
+					tr.BuildEndpointSlice(1, serviceNameV1, defaultNamespace, backends[1], tr.WithEndpointTargetRef("pod-1")),
+				}
+			},
+			buildPods: func([]tr.Backend) []corev1.Pod {
+				return []corev1.Pod{
+					tr.BuildPod("pod-0", defaultNamespace, map[string]string{"version": "v1"}),
+					tr.BuildPod("pod-1", defaultNamespace, map[string]string{"version": "v2"}),
+				}
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+										tr.WithBackendSubset(map[string]string{"version": "v2"}),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallN(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				10,
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-1", 10),
+				),
+			),
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, _ []tr.Backend) {
+				// Swap the versions of both pods, the subset should now only select pod-0.
+				for podName, version := range map[string]string{"pod-0": "v2", "pod-1": "v1"} {
+					_, err := k8s.K8s.CoreV1().Pods(defaultNamespace).Update(
+						context.Background(),
+						tr.Ptr(tr.BuildPod(podName, defaultNamespace, map[string]string{"version": version})),
+						metav1.UpdateOptions{},
+					)
+					require.NoError(t, err)
+				}
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
+				tr.CallN(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					10,
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-0", 10),
+					),
+				),
+			),
+		},
+		{
+			desc:         "pods backend",
+			backendCount: 3,
+			buildEndpointSlices: func([]tr.Backend) []discoveryv1.EndpointSlice {
+				return nil
+			},
+			buildPods: func(backends []tr.Backend) []corev1.Pod {
+				return []corev1.Pod{
+					tr.BuildPod("worker-0", defaultNamespace, map[string]string{"app": "worker"}, tr.WithPodBackend(backends[0]), tr.WithPodNode("node-a")),
+					tr.BuildPod("worker-1", defaultNamespace, map[string]string{"app": "worker"}, tr.WithPodBackend(backends[1]), tr.WithPodNode("node-b")),
+					// Not selected.
+					tr.BuildPod("other-0", defaultNamespace, map[string]string{"app": "other"}, tr.WithPodBackend(backends[2]), tr.WithPodNode("node-a")),
+					// Not ready.
+					tr.BuildPod("worker-2", defaultNamespace, map[string]string{"app": "worker"}),
+				}
+			},
+			nodes: []corev1.Node{
+				tr.BuildNode("node-a", "region-1", "zone-a"),
+				tr.BuildNode("node-b", "region-1", "zone-a"),
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithPodsRef(
+											gtcv1alpha1.PodsRef{
+												Selector: metav1.LabelSelector{
+													MatchLabels: map[string]string{"app": "worker"},
+												},
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallN(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				1000,
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCountWithinDelta("backend-0", 500, 100.0),
+					tr.AssertCountWithinDelta("backend-1", 500, 100.0),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "pods backend pod becoming unready",
+			backendCount: 2,
+			buildEndpointSlices: func([]tr.Backend) []discoveryv1.EndpointSlice {
+				return nil
+			},
+			buildPods: func(backends []tr.Backend) []corev1.Pod {
+				return []corev1.Pod{
+					tr.BuildPod("worker-0", defaultNamespace, map[string]string{"app": "worker"}, tr.WithPodBackend(backends[0])),
+					tr.BuildPod("worker-1", defaultNamespace, map[string]string{"app": "worker"}, tr.WithPodBackend(backends[1])),
+				}
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithPodsRef(
+											gtcv1alpha1.PodsRef{
+												Selector: metav1.LabelSelector{
+													MatchLabels: map[string]string{"app": "worker"},
+												},
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallN(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				100,
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCountWithinDelta("backend-0", 50, 20.0),
+					tr.AssertCountWithinDelta("backend-1", 50, 20.0),
+				),
+			),
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, backends []tr.Backend) {
+				// Only the readiness of the pod changes, among other status conditions.
+				pod := tr.BuildPod("worker-1", defaultNamespace, map[string]string{"app": "worker"}, tr.WithPodBackend(backends[1]))
+				pod.Status.Conditions = []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: corev1.ConditionFalse},
+					{Type: corev1.ContainersReady, Status: corev1.ConditionFalse},
+				}
+
+				_, err := k8s.K8s.CoreV1().Pods(defaultNamespace).Update(
+					context.Background(),
+					&pod,
+					metav1.UpdateOptions{},
+				)
+				require.NoError(t, err)
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
+				tr.CallN(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					10,
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-0", 10),
+					),
+				),
+			),
+		},
+		{
+			desc:         "update pods backend",
+			backendCount: 2,
+			buildEndpointSlices: func([]tr.Backend) []discoveryv1.EndpointSlice {
+				return nil
+			},
+			buildPods: func(backends []tr.Backend) []corev1.Pod {
+				return []corev1.Pod{
+					tr.BuildPod("worker-0", defaultNamespace, map[string]string{"app": "worker"}, tr.WithPodBackend(backends[0])),
+					tr.BuildPod("worker-1", defaultNamespace, map[string]string{"app": "other"}, tr.WithPodBackend(backends[1])),
+				}
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithPodsRef(
+											gtcv1alpha1.PodsRef{
+												Selector: metav1.LabelSelector{
+													MatchLabels: map[string]string{"app": "worker"},
+												},
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallN(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				10,
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-0", 10),
+				),
+			),
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, backends []tr.Backend) {
+				// Swap the labels of both pods, worker-1 should now be the only one selected.
+				for i, app := range []string{"other", "worker"} {
+					_, err := k8s.K8s.CoreV1().Pods(defaultNamespace).Update(
+						context.Background(),
+						tr.Ptr(
+							tr.BuildPod(
+								fmt.Sprintf("worker-%d", i),
+								defaultNamespace,
+								map[string]string{"app": app},
+								tr.WithPodBackend(backends[i]),
+							),
+						),
+						metav1.UpdateOptions{},
+					)
+					require.NoError(t, err)
+				}
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
+				tr.CallN(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					10,
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-1", 10),
+					),
+				),
+			),
+		},
+		{
+			desc:         "cross namespace pods backend",
+			backendCount: 1,
+			buildEndpointSlices: func([]tr.Backend) []discoveryv1.EndpointSlice {
+				return nil
+			},
+			buildPods: func(backends []tr.Backend) []corev1.Pod {
+				return []corev1.Pod{
+					tr.BuildPod("worker-0", "some-app", map[string]string{"app": "worker"}, tr.WithPodBackend(backends[0])),
+				}
+			},
+			referenceGrants: []gtcv1alpha1.ReferenceGrant{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "allow-default", Namespace: "some-app"},
+					Spec: gtcv1alpha1.ReferenceGrantSpec{
+						From: []gtcv1alpha1.ReferenceGrantFrom{{Namespace: "default"}},
+						To:   []gtcv1alpha1.ReferenceGrantTo{{Kind: gtcv1alpha1.ReferenceKindPod}},
+					},
+				},
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithPodsRef(
+											gtcv1alpha1.PodsRef{
+												Namespace: "some-app",
+												Selector: metav1.LabelSelector{
+													MatchLabels: map[string]string{"app": "worker"},
+												},
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-0", 1),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "update single call service",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.AppendEndpointSlices(
+					tr.BuildEndpointSlices(
+						serviceNameV1,
+						defaultNamespace,
+						backends[0:1],
+					),
+					tr.BuildEndpointSlices(
+						serviceNameV2,
+						defaultNamespace,
+						backends[1:2],
+					),
+				)
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-0", 1),
+				),
+			),
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, _ []tr.Backend) {
+				// We switch the backend to v2, which means that backend pod should point to a new instance.
+				_, err := k8s.GTCApi.ApiV1alpha1().GRPCListeners("default").Update(
+					context.Background(),
+					tr.Ptr(
+						tr.BuildGRPCListener(
+							"test-xds",
+							"default",
+							tr.WithRoutes(
+								tr.BuildRoute(
+									tr.WithBackends(
+										tr.BuildBackend(
+											tr.WithServiceRef(
+												gtcv1alpha1.ServiceRef{
+													Name: serviceNameV2,
+													Port: grpcPort,
+												},
+											),
+										),
+									),
+								),
+							),
+						),
+					),
+					metav1.UpdateOptions{},
+				)
+				require.NoError(t, err)
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-1", 1),
+					),
+				),
+			),
+		},
+		{
+			desc:         "update single call endpointslice",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.AppendEndpointSlices(
+					tr.BuildEndpointSlices(
+						serviceNameV1,
+						defaultNamespace,
+						backends[0:1],
+					),
+				)
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-0", 1),
+				),
+			),
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, backends []tr.Backend) {
+				// Write the same endpoints, but pointing to the backend at index 1.
+				newEps := tr.BuildEndpointSlices(serviceNameV1, defaultNamespace, backends[1:2])
+
+				for _, ep := range newEps {
+					_, err := k8s.K8s.DiscoveryV1().EndpointSlices(defaultNamespace).Update(
+						context.Background(),
+						ep.DeepCopy(),
+						metav1.UpdateOptions{},
+					)
+					require.NoError(t, err)
+				}
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-1", 1),
+					),
+				),
+			),
+		},
+		{
+			desc:         "update endpointslice with localities",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.AppendEndpointSlices(
+					tr.BuildEndpointSlices(
+						serviceNameV1,
+						defaultNamespace,
+						backends[0:1],
+					),
+				)
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithLocalities(
+											tr.BuildLocality(
+												tr.WithLocalityServiceRef(
+													gtcv1alpha1.ServiceRef{
+														Name: serviceNameV1,
+														Port: grpcPort,
+													},
+												),
+											),
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-0", 1),
+				),
+			),
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, backends []tr.Backend) {
+				// Write the same endpoints, but pointing to the first backend.
+				newEps := tr.BuildEndpointSlices(serviceNameV1, defaultNamespace, backends[1:2])
+
+				for _, ep := range newEps {
+					_, err := k8s.K8s.DiscoveryV1().EndpointSlices(defaultNamespace).Update(
+						context.Background(),
+						ep.DeepCopy(),
+						metav1.UpdateOptions{},
+					)
+					require.NoError(t, err)
+				}
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-1", 1),
+					),
+				),
+			),
+		},
+		{
+			desc:         "update single call non existing backend",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.AppendEndpointSlices(
+					tr.BuildEndpointSlices(
+						serviceNameV1,
+						defaultNamespace,
+						backends[0:1],
+					),
+				)
+			},
+			buildGRPCListeners:  func([]tr.Backend) []gtcv1alpha1.GRPCListener { return nil },
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+					tr.WithTimeout(time.Second),
+				),
+				tr.MustFail,
+			),
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, backends []tr.Backend) {
+				lis := tr.BuildGRPCListener(
+					"test-xds",
+					"default",
+					tr.WithRoutes(
+						tr.BuildRoute(
+							tr.WithBackends(
+								tr.BuildBackend(
+									tr.WithServiceRef(
+										gtcv1alpha1.ServiceRef{
+											Name: serviceNameV1,
+											Port: grpcPort,
+										},
+									),
+								),
+							),
+						),
+					),
+				)
+
+				_, err := k8s.GTCApi.ApiV1alpha1().GRPCListeners("default").Create(
+					context.Background(),
+					&lis,
+					metav1.CreateOptions{},
+				)
+				require.NoError(t, err)
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-0", 1),
+					),
+				),
+			),
+		},
+		{
+			desc:         "listener retry",
+			backendCount: 1,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(
+					serviceNameV1,
+					defaultNamespace,
+					backends[0:1],
+				)
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithListenerRetry(
+							gtcv1alpha1.RetryPolicy{
+								RetryOn: []string{
+									"unavailable",
+									"cancelled",
+								},
+								NumRetries: tr.Ptr(uint32(2)),
+								Backoff: &gtcv1alpha1.RetryBackoff{
+									BaseInterval: metav1.Duration{
+										Duration: 500 * time.Millisecond,
+									},
+									MaxInterval: tr.DurationPtr(time.Second),
+								},
+							},
+						),
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: sequence(codes.Unavailable, codes.Canceled),
+			doAssertPreUpdate: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-0", 1),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "route retry",
+			backendCount: 1,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(
+					serviceNameV1,
+					defaultNamespace,
+					backends[0:1],
+				)
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithRouteRetry(
+									gtcv1alpha1.RetryPolicy{
+										RetryOn: []string{
+											"unavailable",
+											"cancelled",
+										},
+										NumRetries: tr.Ptr(uint32(2)),
+										Backoff: &gtcv1alpha1.RetryBackoff{
+											BaseInterval: metav1.Duration{
+												Duration: 500 * time.Millisecond,
+											},
+											MaxInterval: tr.DurationPtr(time.Second),
+										},
+									},
+								),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: sequence(codes.Canceled, codes.Unavailable),
+			doAssertPreUpdate: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-0", 1),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "ring hash backend lb policy",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(
+					serviceNameV1,
+					defaultNamespace,
+					backends,
+				)
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithRouteHashPolicy(
+									gtcv1alpha1.HashPolicy{Metadata: "country"},
+								),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithBackendLBPolicy("ring_hash"),
+										tr.WithBackendRingHashConfig(
+											gtcv1alpha1.RingHashConfig{
+												MinRingSize: 1024,
+												MaxRingSize: 838860,
+											},
+										),
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.MultiAssert(
+				tr.CallN(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithMetadata(map[string]string{"country": "france"}),
+					),
+					10,
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertOneBackendGotAllCalls(10),
+					),
+				),
+				tr.CallN(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithMetadata(map[string]string{"country": "sweden"}),
+					),
+					10,
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertOneBackendGotAllCalls(10),
+					),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "topology priorities fallback to the same region",
+			backendCount: 3,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return []discoveryv1.EndpointSlice{
+					tr.BuildEndpointSlice(0, serviceNameV1, defaultNamespace, backends[0], tr.WithEndpointNodeName("node-a")),
+					tr.BuildEndpointSlice(1, serviceNameV1, defaultNamespace, backends[1], tr.WithEndpointNodeName("node-b")),
+					tr.BuildEndpointSlice(2, serviceNameV1, defaultNamespace, backends[2], tr.WithEndpointNodeName("node-c")),
+				}
+			},
+			nodes: []corev1.Node{
+				tr.BuildNode("node-a", "region-1", "zone-a"),
+				tr.BuildNode("node-b", "region-1", "zone-b"),
+				tr.BuildNode("node-c", "region-2", "zone-c"),
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    localCallContext(""),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallN(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				10,
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-0", 10),
+				),
+			),
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, _ []tr.Backend) {
+				err := k8s.K8s.DiscoveryV1().EndpointSlices(defaultNamespace).Delete(
+					context.Background(),
+					serviceNameV1+"-0",
+					metav1.DeleteOptions{},
+				)
+				require.NoError(t, err)
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
+				tr.CallN(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					10,
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-1", 10),
+					),
+				),
+			),
+		},
+		{
+			desc:         "topology priorities fallback to any region",
+			backendCount: 3,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return []discoveryv1.EndpointSlice{
+					tr.BuildEndpointSlice(0, serviceNameV1, defaultNamespace, backends[0], tr.WithEndpointNodeName("node-a")),
+					tr.BuildEndpointSlice(1, serviceNameV1, defaultNamespace, backends[1], tr.WithEndpointNodeName("node-b")),
+					tr.BuildEndpointSlice(2, serviceNameV1, defaultNamespace, backends[2], tr.WithEndpointNodeName("node-c")),
+				}
+			},
+			nodes: []corev1.Node{
+				tr.BuildNode("node-a", "region-1", "zone-a"),
+				tr.BuildNode("node-b", "region-1", "zone-b"),
+				tr.BuildNode("node-c", "region-2", "zone-c"),
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    localCallContext(""),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallN(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				10,
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-0", 10),
+				),
+			),
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, _ []tr.Backend) {
+				for _, name := range []string{serviceNameV1 + "-0", serviceNameV1 + "-1"} {
+					err := k8s.K8s.DiscoveryV1().EndpointSlices(defaultNamespace).Delete(
+						context.Background(),
+						name,
+						metav1.DeleteOptions{},
+					)
+					require.NoError(t, err)
+				}
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
+				tr.CallN(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					10,
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-2", 10),
+					),
+				),
+			),
+		},
+		{
+			desc:         "topology priorities follow node changes",
+			backendCount: 3,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return []discoveryv1.EndpointSlice{
+					tr.BuildEndpointSlice(0, serviceNameV1, defaultNamespace, backends[0], tr.WithEndpointNodeName("node-a")),
+					tr.BuildEndpointSlice(1, serviceNameV1, defaultNamespace, backends[1], tr.WithEndpointNodeName("node-b")),
+					tr.BuildEndpointSlice(2, serviceNameV1, defaultNamespace, backends[2], tr.WithEndpointNodeName("node-c")),
+				}
+			},
+			nodes: []corev1.Node{
+				tr.BuildNode("node-a", "region-1", "zone-a"),
+				tr.BuildNode("node-b", "region-1", "zone-b"),
+				tr.BuildNode("node-c", "region-2", "zone-c"),
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    localCallContext(""),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallN(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				10,
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-0", 10),
+				),
+			),
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, _ []tr.Backend) {
+				// Move node-a away from the zone of the client, node-b now has the highest priority.
+				_, err := k8s.K8s.CoreV1().Nodes().Update(
+					context.Background(),
+					tr.Ptr(tr.BuildNode("node-a", "region-2", "zone-d")),
+					metav1.UpdateOptions{},
+				)
+				require.NoError(t, err)
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
+				tr.CallN(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					10,
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-1", 10),
+					),
+				),
+			),
+		},
+		{
+			desc:         "client topology from its pod",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return []discoveryv1.EndpointSlice{
+					tr.BuildEndpointSlice(0, serviceNameV1, defaultNamespace, backends[0], tr.WithEndpointNodeName("node-a")),
+					tr.BuildEndpointSlice(1, serviceNameV1, defaultNamespace, backends[1], tr.WithEndpointNodeName("node-b")),
+				}
+			},
+			nodes: []corev1.Node{
+				tr.BuildNode("node-a", "region-1", "zone-a"),
+				tr.BuildNode("node-b", "region-1", "zone-b"),
+				tr.BuildNode("node-c", "region-1", "zone-b"),
+			},
+			buildPods: func([]tr.Backend) []corev1.Pod {
+				return []corev1.Pod{
+					tr.BuildPod("client", "clients", nil, tr.WithPodNode("node-c")),
+				}
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			// The bootstrap locality of the client is zone-a, yet its pod runs in zone-b.
+			buildCallContext:    podCallContext("clients", "client"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallN(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				10,
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-1", 10),
+				),
+			),
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, _ []tr.Backend) {
+				// Move the node of the client to zone-a, node-a now has the highest priority.
+				_, err := k8s.K8s.CoreV1().Nodes().Update(
+					context.Background(),
+					tr.Ptr(tr.BuildNode("node-c", "region-1", "zone-a")),
+					metav1.UpdateOptions{},
+				)
+				require.NoError(t, err)
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
+				tr.CallN(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					10,
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-0", 10),
+					),
+				),
+			),
+		},
+		{
+			desc:         "same node preference fallback to all endpoints",
+			backendCount: 3,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return []discoveryv1.EndpointSlice{
+					tr.BuildEndpointSlice(0, serviceNameV1, defaultNamespace, backends[0], tr.WithEndpointNodeName("node-a")),
+					tr.BuildEndpointSlice(1, serviceNameV1, defaultNamespace, backends[1], tr.WithEndpointNodeName("node-b")),
+					tr.BuildEndpointSlice(2, serviceNameV1, defaultNamespace, backends[2], tr.WithEndpointNodeName("node-c")),
+				}
+			},
+			nodes: []corev1.Node{
+				tr.BuildNode("node-a", "region-1", "zone-a"),
+				tr.BuildNode("node-b", "region-1", "zone-a"),
+				tr.BuildNode("node-c", "region-2", "zone-c"),
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+										tr.WithBackendSameNode(gtcv1alpha1.SameNodeFallbackAll),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    localCallContext("node-b"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallN(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				10,
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-1", 10),
+				),
+			),
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, _ []tr.Backend) {
+				err := k8s.K8s.DiscoveryV1().EndpointSlices(defaultNamespace).Delete(
+					context.Background(),
+					serviceNameV1+"-1",
+					metav1.DeleteOptions{},
+				)
+				require.NoError(t, err)
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
+				tr.CallN(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					10,
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-0", 10),
+					),
+				),
+			),
+		},
+		{
+			desc:         "same node preference fallback to the same zone",
+			backendCount: 3,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return []discoveryv1.EndpointSlice{
+					tr.BuildEndpointSlice(0, serviceNameV1, defaultNamespace, backends[0], tr.WithEndpointNodeName("node-a")),
+					tr.BuildEndpointSlice(1, serviceNameV1, defaultNamespace, backends[1], tr.WithEndpointNodeName("node-b")),
+					tr.BuildEndpointSlice(2, serviceNameV1, defaultNamespace, backends[2], tr.WithEndpointNodeName("node-c")),
+				}
+			},
+			nodes: []corev1.Node{
+				tr.BuildNode("node-a", "region-1", "zone-a"),
+				tr.BuildNode("node-b", "region-1", "zone-a"),
+				tr.BuildNode("node-c", "region-2", "zone-c"),
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+										tr.WithBackendSameNode(gtcv1alpha1.SameNodeFallbackZone),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    localCallContext("node-a"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallN(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				10,
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-0", 10),
+				),
+			),
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, _ []tr.Backend) {
+				// Only node-c is left, outside of the zone of the client.
+				for _, name := range []string{serviceNameV1 + "-0", serviceNameV1 + "-1"} {
+					err := k8s.K8s.DiscoveryV1().EndpointSlices(defaultNamespace).Delete(
+						context.Background(),
+						name,
+						metav1.DeleteOptions{},
+					)
+					require.NoError(t, err)
+				}
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithTimeout(time.Second),
+					),
+					tr.MustFail,
+				),
+			),
+		},
+		{
+			desc:         "same node preference without fallback",
+			backendCount: 3,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return []discoveryv1.EndpointSlice{
+					tr.BuildEndpointSlice(0, serviceNameV1, defaultNamespace, backends[0], tr.WithEndpointNodeName("node-a")),
+					tr.BuildEndpointSlice(1, serviceNameV1, defaultNamespace, backends[1], tr.WithEndpointNodeName("node-b")),
+					tr.BuildEndpointSlice(2, serviceNameV1, defaultNamespace, backends[2], tr.WithEndpointNodeName("node-c")),
+				}
+			},
+			nodes: []corev1.Node{
+				tr.BuildNode("node-a", "region-1", "zone-a"),
+				tr.BuildNode("node-b", "region-1", "zone-a"),
+				tr.BuildNode("node-c", "region-2", "zone-c"),
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+										tr.WithBackendSameNode(gtcv1alpha1.SameNodeFallbackNone),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    localCallContext("node-c"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallN(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				10,
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-2", 10),
+				),
+			),
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, _ []tr.Backend) {
+				err := k8s.K8s.DiscoveryV1().EndpointSlices(defaultNamespace).Delete(
+					context.Background(),
+					serviceNameV1+"-2",
+					metav1.DeleteOptions{},
+				)
+				require.NoError(t, err)
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithTimeout(time.Second),
+					),
+					tr.MustFail,
+				),
+			),
+		},
+		{
+			desc:         "terminating endpoints are draining",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return []discoveryv1.EndpointSlice{
+					tr.BuildEndpointSlice(0, serviceNameV1, defaultNamespace, backends[0]),
+					tr.BuildEndpointSlice(1, serviceNameV1, defaultNamespace, backends[1], tr.WithEndpointConditions(false, true, true)),
+				}
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.MultiAssert(
+				assertHealthStatuses(
+					corev3.HealthStatus_UNKNOWN,
+					corev3.HealthStatus_DRAINING,
+				),
+				tr.CallN(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					10,
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-0", 10),
+					),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "not ready endpoints are unhealthy",
+			backendCount: 3,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return []discoveryv1.EndpointSlice{
+					tr.BuildEndpointSlice(0, serviceNameV1, defaultNamespace, backends[0]),
+					tr.BuildEndpointSlice(1, serviceNameV1, defaultNamespace, backends[1], tr.WithEndpointConditions(false, false, false)),
+					tr.BuildEndpointSlice(2, serviceNameV1, defaultNamespace, backends[2], tr.WithEndpointConditions(false, true, true)),
+				}
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+										tr.WithBackendPublishNotReady(),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.MultiAssert(
+				assertHealthStatuses(
+					corev3.HealthStatus_UNKNOWN,
+					corev3.HealthStatus_UNHEALTHY,
+					corev3.HealthStatus_DRAINING,
+				),
+				tr.CallN(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					10,
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-0", 10),
+					),
+				),
+			),
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, backends []tr.Backend) {
+				slice := tr.BuildEndpointSlice(0, serviceNameV1, defaultNamespace, backends[0], tr.WithEndpointConditions(false, false, false))
+
+				_, err := k8s.K8s.DiscoveryV1().EndpointSlices(defaultNamespace).Update(
+					context.Background(),
+					&slice,
+					metav1.UpdateOptions{},
+				)
+				require.NoError(t, err)
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
+				assertHealthStatuses(
+					corev3.HealthStatus_UNHEALTHY,
+					corev3.HealthStatus_UNHEALTHY,
+					corev3.HealthStatus_DRAINING,
+				),
+			),
+		},
+		{
+			desc:         "endpoint weights from pod annotations",
+			backendCount: 3,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return []discoveryv1.EndpointSlice{
+					tr.BuildEndpointSlice(0, serviceNameV1, defaultNamespace, backends[0], tr.WithEndpointTargetRef("pod-0")),
+					tr.BuildEndpointSlice(1, serviceNameV1, defaultNamespace, backends[1], tr.WithEndpointTargetRef("pod-1")),
+					tr.BuildEndpointSlice(2, serviceNameV1, defaultNamespace, backends[2], tr.WithEndpointTargetRef("pod-2")),
+				}
+			},
+			buildPods: func([]tr.Backend) []corev1.Pod {
+				return []corev1.Pod{
+					tr.BuildPod("pod-0", defaultNamespace, nil, tr.WithPodAnnotations(map[string]string{gtcv1alpha1.AnnotationWeight: "3"})),
+					tr.BuildPod("pod-1", defaultNamespace, nil, tr.WithPodAnnotations(map[string]string{gtcv1alpha1.AnnotationWeight: "invalid"})),
+					tr.BuildPod("pod-2", defaultNamespace, nil),
+				}
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate:   assertEndpointWeights(5, 3, 0, 0),
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, _ []tr.Backend) {
+				_, err := k8s.K8s.CoreV1().Pods(defaultNamespace).Update(
+					context.Background(),
+					tr.Ptr(tr.BuildPod("pod-2", defaultNamespace, nil, tr.WithPodAnnotations(map[string]string{gtcv1alpha1.AnnotationWeight: "2"}))),
+					metav1.UpdateOptions{},
+				)
+				require.NoError(t, err)
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
+				assertEndpointWeights(6, 3, 0, 2),
+			),
+		},
+		{
+			desc:         "dual-stack service",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return []discoveryv1.EndpointSlice{
+					tr.BuildEndpointSlice(0, serviceNameV1, defaultNamespace, backends[0], tr.WithEndpointTargetRef("pod-0")),
+					tr.BuildEndpointSlice(1, serviceNameV1, defaultNamespace, backends[1], tr.WithEndpointTargetRef("pod-1")),
+					tr.BuildEndpointSlice(2, serviceNameV1, defaultNamespace, backends[0], tr.WithEndpointTargetRef("pod-0"), tr.WithEndpointAddresses(discoveryv1.AddressTypeIPv6, "::1")),
+					tr.BuildEndpointSlice(3, serviceNameV1, defaultNamespace, backends[1], tr.WithEndpointTargetRef("pod-1"), tr.WithEndpointAddresses(discoveryv1.AddressTypeIPv6, "::1")),
+				}
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.MultiAssert(
+				assertEndpointAddresses(
+					[]string{"127.0.0.1", "::1"},
+					[]string{"127.0.0.1", "::1"},
+				),
+				tr.CallN(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					10,
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCountWithinDelta("backend-0", 5, 1.0),
+						tr.AssertCountWithinDelta("backend-1", 5, 1.0),
+					),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "dual-stack service preferring IPv6",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return []discoveryv1.EndpointSlice{
+					tr.BuildEndpointSlice(0, serviceNameV1, defaultNamespace, backends[0], tr.WithEndpointTargetRef("pod-0")),
+					tr.BuildEndpointSlice(1, serviceNameV1, defaultNamespace, backends[1], tr.WithEndpointTargetRef("pod-1")),
+					tr.BuildEndpointSlice(2, serviceNameV1, defaultNamespace, backends[0], tr.WithEndpointTargetRef("pod-0"), tr.WithEndpointAddresses(discoveryv1.AddressTypeIPv6, "::1")),
+					tr.BuildEndpointSlice(3, serviceNameV1, defaultNamespace, backends[1], tr.WithEndpointTargetRef("pod-1"), tr.WithEndpointAddresses(discoveryv1.AddressTypeIPv6, "::1")),
+				}
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name:          serviceNameV1,
+												Port:          grpcPort,
+												AddressFamily: gtcv1alpha1.AddressFamilyIPv6,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.MultiAssert(
+				assertEndpointAddresses(
+					[]string{"::1", "127.0.0.1"},
+					[]string{"::1", "127.0.0.1"},
+				),
+				tr.CallN(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					10,
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCountWithinDelta("backend-0", 5, 1.0),
+						tr.AssertCountWithinDelta("backend-1", 5, 1.0),
+					),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "ring hash endpoint hash keys",
+			backendCount: 3,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return []discoveryv1.EndpointSlice{
+					tr.BuildEndpointSlice(0, serviceNameV1, defaultNamespace, backends[0], tr.WithEndpointTargetRef("pod-0")),
+					tr.BuildEndpointSlice(1, serviceNameV1, defaultNamespace, backends[1], tr.WithEndpointTargetRef("pod-1")),
+					tr.BuildEndpointSlice(2, serviceNameV1, defaultNamespace, backends[2], tr.WithEndpointTargetRef("pod-2")),
+				}
+			},
+			buildPods: func([]tr.Backend) []corev1.Pod {
+				return []corev1.Pod{
+					tr.BuildPod("pod-0", defaultNamespace, map[string]string{"cache-shard": "shard-0"}),
+					tr.BuildPod("pod-1", defaultNamespace, nil),
+					tr.BuildPod("pod-2", defaultNamespace, map[string]string{"cache-shard": "shard-2"}),
+				}
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithRouteHashPolicy(
+									gtcv1alpha1.HashPolicy{Metadata: "country"},
+								),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithBackendLBPolicy("ring_hash"),
+										tr.WithBackendRingHashConfig(
+											gtcv1alpha1.RingHashConfig{
+												MinRingSize: 1024,
+												MaxRingSize: 838860,
+												EndpointHashKey: &gtcv1alpha1.EndpointHashKey{
+													Label: "cache-shard",
+												},
+											},
+										),
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			// The gRPC client of this test doesn't implement gRFC A76, only the hash keys it is sent are checked,
+			// calls stick to a backend through the route hash policy.
+			doAssertPreUpdate: tr.MultiAssert(
+				assertEndpointHashKeys("shard-0", "", "shard-2"),
+				tr.CallN(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithMetadata(map[string]string{"country": "france"}),
+					),
+					10,
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertOneBackendGotAllCalls(10),
+					),
+				),
+			),
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, _ []tr.Backend) {
+				_, err := k8s.K8s.CoreV1().Pods(defaultNamespace).Update(
+					context.Background(),
+					tr.Ptr(tr.BuildPod("pod-1", defaultNamespace, map[string]string{"cache-shard": "shard-1"})),
+					metav1.UpdateOptions{},
+				)
+				require.NoError(t, err)
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
+				assertEndpointHashKeys("shard-0", "shard-1", "shard-2"),
+			),
+		},
+		{
+			desc:         "ring hash endpoint hash keys from pod names",
+			backendCount: 1,
+			buildEndpointSlices: func([]tr.Backend) []discoveryv1.EndpointSlice {
+				return nil
+			},
+			buildPods: func(backends []tr.Backend) []corev1.Pod {
+				return []corev1.Pod{
+					tr.BuildPod("pod-0", defaultNamespace, map[string]string{"app": "cache"}, tr.WithPodBackend(backends[0])),
+				}
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithBackendLBPolicy("ring_hash"),
+										tr.WithBackendRingHashConfig(
+											gtcv1alpha1.RingHashConfig{
+												MinRingSize:     1024,
+												MaxRingSize:     838860,
+												EndpointHashKey: &gtcv1alpha1.EndpointHashKey{},
+											},
+										),
+										tr.WithPodsRef(
+											gtcv1alpha1.PodsRef{
+												Selector: metav1.LabelSelector{
+													MatchLabels: map[string]string{"app": "cache"},
+												},
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate:   assertEndpointHashKeys("pod-0"),
+			updateResources:     noChange,
+			doAssertPostUpdate:  noAssert,
+		},
+		{
+			desc:         "ring hash hash policy regex rewrite",
+			backendCount: 3,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(
+					serviceNameV1,
+					defaultNamespace,
+					backends,
+				)
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithRouteHashPolicy(
+									gtcv1alpha1.HashPolicy{
+										Metadata: "session-key",
+										RegexRewrite: &gtcv1alpha1.RegexRewrite{
+											Pattern: gtcv1alpha1.RegexMatcher{
+												Regex:  "^([^:]+):.*$",
+												Engine: "re2",
+											},
+											Substitution: `\1`,
+										},
+									},
+								),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithBackendLBPolicy("ring_hash"),
+										tr.WithBackendRingHashConfig(
+											gtcv1alpha1.RingHashConfig{
+												MinRingSize: 1024,
+												MaxRingSize: 838860,
+											},
+										),
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.MultiAssert(
+				tr.CallEach(
+					tenantCallers("acme", 20),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertOneBackendGotAllCalls(20),
+					),
+				),
+				tr.CallEach(
+					tenantCallers("globex", 20),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertOneBackendGotAllCalls(20),
+					),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "ring hash request hash header",
+			backendCount: 3,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(
+					serviceNameV1,
+					defaultNamespace,
+					backends,
+				)
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithRouteHashPolicy(
+									gtcv1alpha1.HashPolicy{Metadata: "tenant"},
+								),
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithBackendLBPolicy("ring_hash"),
+										tr.WithBackendRingHashConfig(
+											gtcv1alpha1.RingHashConfig{
+												MinRingSize:       1024,
+												MaxRingSize:       838860,
+												RequestHashHeader: "tenant",
+											},
+										),
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			// The gRPC client of this test doesn't implement gRFC A76, only the policy it is sent is checked,
+			// calls stick to a backend through the route hash policy.
+			doAssertPreUpdate: tr.MultiAssert(
+				assertFirstBackendCluster(
+					func(t *testing.T, cluster *clusterv3.Cluster) {
+						policies := cluster.GetLoadBalancingPolicy().GetPolicies()
+						require.Len(t, policies, 2)
+
+						var grpcPolicy xdstypev3.TypedStruct
+
+						require.NoError(t, policies[0].GetTypedExtensionConfig().GetTypedConfig().UnmarshalTo(&grpcPolicy))
+						assert.Equal(t, "type.googleapis.com/ring_hash_experimental", grpcPolicy.TypeUrl)
+						assert.Equal(t, "tenant", grpcPolicy.GetValue().GetFields()["requestHashHeader"].GetStringValue())
+						assert.Equal(t, float64(1024), grpcPolicy.GetValue().GetFields()["minRingSize"].GetNumberValue())
+					},
+				),
+				tr.CallN(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithMetadata(map[string]string{"tenant": "acme"}),
+					),
+					20,
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertOneBackendGotAllCalls(20),
+					),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "service import backend",
+			backendCount: 3,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return []discoveryv1.EndpointSlice{
+					tr.BuildEndpointSlice(0, serviceNameV1, defaultNamespace, backends[0], tr.WithEndpointSourceCluster("cluster-a")),
+					tr.BuildEndpointSlice(1, serviceNameV1, defaultNamespace, backends[1], tr.WithEndpointSourceCluster("cluster-b")),
+					tr.BuildEndpointSlice(2, serviceNameV1, defaultNamespace, backends[2], tr.WithEndpointSourceCluster("cluster-c")),
+				}
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceImportRef(
+											gtcv1alpha1.ServiceImportRef{
+												ServiceRef: gtcv1alpha1.ServiceRef{
+													Name: serviceNameV1,
+													Port: grpcPort,
+												},
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallN(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				9,
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCountWithinDelta("backend-0", 3, 1.0),
+					tr.AssertCountWithinDelta("backend-1", 3, 1.0),
+					tr.AssertCountWithinDelta("backend-2", 3, 1.0),
+				),
+			),
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, _ []tr.Backend) {
+				err := k8s.K8s.DiscoveryV1().EndpointSlices(defaultNamespace).Delete(
+					context.Background(),
+					serviceNameV1+"-0",
+					metav1.DeleteOptions{},
+				)
+				require.NoError(t, err)
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
+				tr.CallN(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					10,
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-1", 5),
+						tr.AssertCount("backend-2", 5),
+					),
+				),
+			),
+		},
+		{
+			desc:         "service import backend same pod names in different source clusters",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return []discoveryv1.EndpointSlice{
+					tr.BuildEndpointSlice(0, serviceNameV1, defaultNamespace, backends[0], tr.WithEndpointSourceCluster("cluster-a"), tr.WithEndpointTargetRef("worker-0")),
+					tr.BuildEndpointSlice(1, serviceNameV1, defaultNamespace, backends[1], tr.WithEndpointSourceCluster("cluster-b"), tr.WithEndpointTargetRef("worker-0")),
+				}
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceImportRef(
+											gtcv1alpha1.ServiceImportRef{
+												ServiceRef: gtcv1alpha1.ServiceRef{
+													Name: serviceNameV1,
+													Port: grpcPort,
+												},
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallN(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				10,
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCountWithinDelta("backend-0", 5, 1.0),
+					tr.AssertCountWithinDelta("backend-1", 5, 1.0),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "service import source cluster priorities",
+			backendCount: 3,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return []discoveryv1.EndpointSlice{
+					tr.BuildEndpointSlice(0, serviceNameV1, defaultNamespace, backends[0], tr.WithEndpointSourceCluster("cluster-a")),
+					tr.BuildEndpointSlice(1, serviceNameV1, defaultNamespace, backends[1], tr.WithEndpointSourceCluster("cluster-b")),
+					tr.BuildEndpointSlice(2, serviceNameV1, defaultNamespace, backends[2], tr.WithEndpointSourceCluster("cluster-c")),
+				}
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceImportRef(
+											gtcv1alpha1.ServiceImportRef{
+												ServiceRef: gtcv1alpha1.ServiceRef{
+													Name: serviceNameV1,
+													Port: grpcPort,
+												},
+												SourceClusters: []string{"cluster-b", "cluster-a"},
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallN(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				10,
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-1", 10),
+				),
+			),
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, _ []tr.Backend) {
+				for _, sliceName := range []string{serviceNameV1 + "-0", serviceNameV1 + "-1"} {
+					err := k8s.K8s.DiscoveryV1().EndpointSlices(defaultNamespace).Delete(
+						context.Background(),
+						sliceName,
+						metav1.DeleteOptions{},
+					)
+					require.NoError(t, err)
+				}
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
+				tr.CallN(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					10,
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-2", 10),
+					),
+				),
+			),
+		},
+		{
+			desc:         "remote cluster service",
+			backendCount: 3,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(serviceNameV1, defaultNamespace, backends[0:1])
+			},
+			buildRemoteEndpointSlices: func(backends []tr.Backend) map[string][]discoveryv1.EndpointSlice {
+				return map[string][]discoveryv1.EndpointSlice{
+					"east": tr.BuildEndpointSlices(serviceNameV1, defaultNamespace, backends[1:2]),
+				}
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name:    serviceNameV1,
+												Port:    grpcPort,
+												Cluster: "east",
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallN(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				10,
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					// The service of the local cluster is left out.
+					tr.AssertCount("backend-1", 10),
+				),
+			),
+			updateResources: func(t *testing.T, k8s tr.FakeK8s, backends []tr.Backend) {
+				remote := k8s.RemoteClusters["east"]
+
+				for _, ep := range tr.BuildEndpointSlices(serviceNameV1, defaultNamespace, backends[2:3]) {
+					_, err := remote.K8s.DiscoveryV1().EndpointSlices(defaultNamespace).Update(
+						context.Background(),
+						ep.DeepCopy(),
+						metav1.UpdateOptions{},
+					)
+					require.NoError(t, err)
+				}
+			},
+			doAssertPostUpdate: tr.MultiAssert(
+				tr.Wait(500*time.Millisecond),
+				tr.CallN(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					10,
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-2", 10),
+					),
+				),
+			),
+		},
+		{
+			desc:         "localities across clusters",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				return tr.BuildEndpointSlices(serviceNameV1, defaultNamespace, backends[0:1])
+			},
+			buildRemoteEndpointSlices: func(backends []tr.Backend) map[string][]discoveryv1.EndpointSlice {
+				return map[string][]discoveryv1.EndpointSlice{
+					"east": tr.BuildEndpointSlices(serviceNameV1, defaultNamespace, backends[1:2]),
+				}
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithLocalities(
+											tr.BuildLocality(
+												tr.WithLocalityWeight(80),
+												tr.WithLocalityServiceRef(
+													gtcv1alpha1.ServiceRef{
+														Name: serviceNameV1,
+														Port: grpcPort,
+													},
+												),
+											),
+											tr.BuildLocality(
+												tr.WithLocalityWeight(20),
+												tr.WithLocalityServiceRef(
+													gtcv1alpha1.ServiceRef{
+														Name:    serviceNameV1,
+														Port:    grpcPort,
+														Cluster: "east",
+													},
+												),
+											),
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallN(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				10000,
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCountWithinDelta("backend-0", 8000, 500.0),
+					tr.AssertCountWithinDelta("backend-1", 2000, 500.0),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+		{
+			desc:         "topology aware routing",
+			backendCount: 2,
+			buildEndpointSlices: func(backends []tr.Backend) []discoveryv1.EndpointSlice {
+				slice0 := tr.BuildEndpointSlice(
+					0,
+					serviceNameV1,
+					defaultNamespace,
+					backends[0],
+					tr.WithEndpointZone("zone-a"),
+					tr.WithEndpointHints(
+						discoveryv1.EndpointHints{
+							ForZones: []discoveryv1.ForZone{
+								{Name: "zone-a"},
+							},
+						},
+					),
+				)
+
+				slice1 := tr.BuildEndpointSlice(
+					1,
+					serviceNameV1,
+					defaultNamespace,
+					backends[1],
+					tr.WithEndpointZone("zone-b"),
+					tr.WithEndpointHints(
+						discoveryv1.EndpointHints{
+							ForZones: []discoveryv1.ForZone{
+								{Name: "zone-b"},
+							},
+						},
+					),
+				)
+
+				return []discoveryv1.EndpointSlice{slice0, slice1}
+			},
+			buildGRPCListeners: func([]tr.Backend) []gtcv1alpha1.GRPCListener {
+				return []gtcv1alpha1.GRPCListener{
+					tr.BuildGRPCListener(
+						"test-xds",
+						"default",
+						tr.WithRoutes(
+							tr.BuildRoute(
+								tr.WithBackends(
+									tr.BuildBackend(
+										tr.WithServiceRef(
+											gtcv1alpha1.ServiceRef{
+												Name: serviceNameV1,
+												Port: grpcPort,
+											},
+										),
+									),
+								),
+							),
+						),
+					),
+				}
+			},
+			buildCallContext:    tr.DefaultCallContext("xds:///default/test-xds"),
+			setBackendsBehavior: answer,
+			doAssertPreUpdate: tr.CallN(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				10,
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-0", 10),
+				),
+			),
+			updateResources:    noChange,
+			doAssertPostUpdate: noAssert,
+		},
+	} {
+		t.Run(testCase.desc, func(t *testing.T) {
+			backends, err := tr.StartBackends(
+				tr.Config{
+					BackendCount: testCase.backendCount,
+				},
+			)
+			require.NoError(t, err)
+
+			defer func() {
+				err := backends.Stop()
+				require.NoError(t, err)
+			}()
+
+			var (
+				ctx, cancel = context.WithCancel(context.Background())
+				k8s         = tr.NewFakeK8s(
+					t,
+					testCase.buildGRPCListeners(backends),
+					testCase.buildEndpointSlices(backends),
+				)
+				serverExited = make(chan struct{})
+			)
+
+			defer cancel()
+
+			k8s.CreateReferenceGrants(t, testCase.referenceGrants...)
+			if testCase.buildPods != nil {
+				k8s.CreatePods(t, testCase.buildPods(backends)...)
+			}
+			k8s.CreateNodes(t, testCase.nodes...)
+			if testCase.buildRemoteEndpointSlices != nil {
+				for cluster, endpointSlices := range testCase.buildRemoteEndpointSlices(backends) {
+					k8s.AddRemoteCluster(cluster, endpointSlices)
+				}
+			}
+
+			server, err := gtc.NewXDSServer(
+				ctx,
+				gtc.XDSServerConfig{
+					K8sInformers:   k8s.K8sInformers,
+					GTCInformers:   k8s.GTCInformers,
+					RemoteClusters: k8s.RemoteK8sInformers(),
+					// TODO(jly): find a way to make this parralelizable.
+					// The thing is that having multiple xds servers in parrallel means sadly
+					// having multiple values for the XDS_BOOTSTRAP_CONFIG env variables.
+					// which is impossible as far as I know.
+					BindAddr: ":16000",
+				},
+				newLogger(t),
+			)
+			require.NoError(t, err)
+
+			k8s.Start(ctx, t)
+
+			go func() {
+				err := server.Run(ctx)
+				require.NoError(t, err)
+				close(serverExited)
+			}()
+
+			// Always explicitely stop the server and wait for it to be finished.
+			t.Cleanup(func() {
+				cancel()
+
+				<-serverExited
+			})
+
+			testCase.setBackendsBehavior(t, backends)
+
+			callCtx := testCase.buildCallContext(t)
+			testCase.doAssertPreUpdate(t, callCtx)
+
+			testCase.updateResources(t, k8s, backends)
+
+			testCase.doAssertPostUpdate(t, callCtx)
+
+			err = callCtx.Close()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestServerReferenceGrantCondition(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		k8s         = tr.NewFakeK8s(
+			t,
+			[]gtcv1alpha1.GRPCListener{
+				tr.BuildGRPCListener(
+					"test-xds",
+					"default",
+					tr.WithRoutes(
+						tr.BuildRoute(
+							tr.WithBackends(
+								tr.BuildBackend(
+									tr.WithServiceRef(
+										gtcv1alpha1.ServiceRef{
+											Name:      serviceNameV1,
+											Namespace: "some-app",
+											Port:      grpcPort,
+										},
+									),
+								),
+							),
+						),
+					),
+				),
+			},
+			nil,
+		)
+	)
+
+	defer cancel()
+
+	runServer(ctx, t, k8s, gtc.XDSServerConfig{BindAddr: ":16007"})
+
+	condition := assertResolvedRefsCondition(t, k8s, metav1.ConditionFalse)
+	assert.Equal(t, gtcv1alpha1.GRPCListenerReasonRefNotPermitted, condition.Reason)
+	assert.Contains(t, condition.Message, "Service some-app/"+serviceNameV1)
+
+	grant := tr.BuildReferenceGrant("allow-default", "some-app", []string{"default"}, serviceNameV1)
+
+	_, err := k8s.GTCApi.ApiV1alpha1().ReferenceGrants("some-app").Create(ctx, &grant, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	condition = assertResolvedRefsCondition(t, k8s, metav1.ConditionTrue)
+	assert.Equal(t, gtcv1alpha1.GRPCListenerReasonResolvedRefs, condition.Reason)
+}
+
+func assertResolvedRefsCondition(t *testing.T, k8s tr.FakeK8s, wantStatus metav1.ConditionStatus) metav1.Condition {
+	t.Helper()
+
+	var condition metav1.Condition
+
+	require.Eventually(
+		t,
+		func() bool {
+			lis, err := k8s.GTCApi.ApiV1alpha1().GRPCListeners("default").Get(context.Background(), "test-xds", metav1.GetOptions{})
+			require.NoError(t, err)
+
+			current := apimeta.FindStatusCondition(lis.Status.Conditions, gtcv1alpha1.GRPCListenerConditionResolvedRefs)
+			if current == nil || current.Status != wantStatus {
+				return false
+			}
+
+			condition = *current
+
+			return true
+		},
+		time.Second,
+		50*time.Millisecond,
+	)
+
+	return condition
+}
+
+func TestServerAuthentication(t *testing.T) {
+	const (
+		secureXDSAddr  = "localhost:16001"
+		validToken     = "valid-token"
+		clientSPIFFEID = "spiffe://cluster.local/ns/default/sa/test-client"
+	)
+
+	tlsFiles := tr.GenerateTLSFiles(t, clientSPIFFEID)
+
+	for _, testCase := range []struct {
+		desc         string
+		required     bool
+		token        string
+		serverConfig func(tokenFile string) bootstrap.ServerConfig
+		doAssert     func(t *testing.T, callCtx *tr.CallContext)
+	}{
+		{
+			desc:     "client certificate",
+			required: true,
+			serverConfig: func(string) bootstrap.ServerConfig {
+				return bootstrap.ServerConfig{
+					URI:               secureXDSAddr,
+					CACertificateFile: tlsFiles.CAFile,
+					CertificateFile:   tlsFiles.ClientCertFile,
+					PrivateKeyFile:    tlsFiles.ClientKeyFile,
+				}
+			},
+			doAssert: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-0", 1),
+				),
+			),
+		},
+		{
+			desc:     "valid service account token",
+			required: true,
+			token:    validToken,
+			serverConfig: func(tokenFile string) bootstrap.ServerConfig {
+				return bootstrap.ServerConfig{
+					URI:               secureXDSAddr,
+					CACertificateFile: tlsFiles.CAFile,
+					TokenFile:         tokenFile,
+				}
+			},
+			doAssert: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				tr.NoCallErrors,
+				tr.CountByBackendID(
+					tr.AssertCount("backend-0", 1),
+				),
+			),
+		},
+		{
+			desc:  "invalid service account token",
+			token: "invalid-token",
+			serverConfig: func(tokenFile string) bootstrap.ServerConfig {
+				return bootstrap.ServerConfig{
+					URI:               secureXDSAddr,
+					CACertificateFile: tlsFiles.CAFile,
+					TokenFile:         tokenFile,
+				}
+			},
+			doAssert: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+					tr.WithTimeout(time.Second),
+				),
+				tr.MustFail,
+			),
+		},
+		{
+			desc:     "no credentials with required authentication",
+			required: true,
+			serverConfig: func(string) bootstrap.ServerConfig {
+				return bootstrap.ServerConfig{
+					URI:               secureXDSAddr,
+					CACertificateFile: tlsFiles.CAFile,
+				}
+			},
+			doAssert: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+					tr.WithTimeout(time.Second),
+				),
+				tr.MustFail,
+			),
+		},
+		{
+			desc: "no credentials with optional authentication",
+			serverConfig: func(string) bootstrap.ServerConfig {
+				return bootstrap.ServerConfig{
+					URI:               secureXDSAddr,
+					CACertificateFile: tlsFiles.CAFile,
+				}
+			},
+			doAssert: tr.CallOnce(
+				tr.BuildCaller(
+					tr.MethodEcho,
+				),
+				tr.NoCallErrors,
+			),
+		},
+	} {
+		t.Run(testCase.desc, func(t *testing.T) {
+			backends, err := tr.StartBackends(tr.Config{BackendCount: 1})
+			require.NoError(t, err)
+
+			defer func() {
+				err := backends.Stop()
+				require.NoError(t, err)
+			}()
+
+			var (
+				ctx, cancel = context.WithCancel(context.Background())
+				k8s         = tr.NewFakeK8s(
+					t,
+					[]gtcv1alpha1.GRPCListener{
+						tr.BuildGRPCListener(
+							"test-xds",
+							"default",
+							tr.WithRoutes(
+								tr.BuildRoute(
+									tr.WithBackends(
+										tr.BuildBackend(
+											tr.WithServiceRef(
+												gtcv1alpha1.ServiceRef{
+													Name: serviceNameV1,
+													Port: grpcPort,
+												},
+											),
+										),
+									),
+								),
+							),
+						),
+					},
+					tr.BuildEndpointSlices(serviceNameV1, defaultNamespace, backends),
+				)
+				tokenFile = filepath.Join(t.TempDir(), "token")
+			)
+
+			defer cancel()
+
+			k8s.AcceptTokens(map[string]string{
+				validToken: "system:serviceaccount:default:test-client",
+			})
+
+			err = os.WriteFile(tokenFile, []byte(testCase.token), 0o600)
+			require.NoError(t, err)
+
+			runServer(
+				ctx,
+				t,
+				k8s,
+				gtc.XDSServerConfig{
+					BindAddr: ":16001",
+					TLS:      tlsFiles.ServerConfig(t),
+					Authentication: gtc.AuthenticationConfig{
+						Required:     testCase.required,
+						TokenReviews: k8s.K8s.AuthenticationV1().TokenReviews(),
+					},
+				},
+			)
+
+			callCtx := tr.BootstrapCallContext(
+				"xds:///default/test-xds",
+				bootstrap.BootstrapConfig{
+					XDSServers: []bootstrap.XDSServer{testCase.serverConfig(tokenFile).XDSServer()},
+					Node:       bootstrap.Node{ID: "test-client"},
+				},
+			)(t)
+
+			testCase.doAssert(t, callCtx)
+
+			err = callCtx.Close()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestServerAuthorization(t *testing.T) {
+	const (
+		authzXDSAddr = "localhost:16002"
+		defaultToken = "default-namespace-token"
+		otherToken   = "other-namespace-token"
+	)
+
+	tlsFiles := tr.GenerateTLSFiles(t, "spiffe://cluster.local/ns/default/sa/test-client")
+
+	for _, testCase := range []struct {
+		desc        string
+		annotations map[string]string
+		target      string
+		nodeID      string
+		token       string
+		wantDenied  bool
+	}{
+		{
+			desc:  "client from the same namespace",
+			token: defaultToken,
+		},
+		{
+			desc:       "client from another namespace",
+			token:      otherToken,
+			wantDenied: true,
+		},
+		{
+			desc: "client from another namespace allowed by annotation",
+			annotations: map[string]string{
+				gtcv1alpha1.AnnotationAllowedClientNamespaces: "some-namespace, other",
+			},
+			token: otherToken,
+		},
+		{
+			desc: "any client allowed by annotation",
+			annotations: map[string]string{
+				gtcv1alpha1.AnnotationAllowedClientNamespaces: "*",
+			},
+			token: otherToken,
+		},
+		{
+			desc:   "client from the same namespace dialing an alias",
+			target: "xds:///default/test-xds-alias",
+			token:  defaultToken,
+		},
+		{
+			desc:       "client from another namespace dialing an alias",
+			target:     "xds:///default/test-xds-alias",
+			token:      otherToken,
+			wantDenied: true,
+		},
+		{
+			desc: "client from the same namespace dialing a hostname",
+			annotations: map[string]string{
+				gtcv1alpha1.AnnotationHostname: "test-xds-host",
+			},
+			target: "xds:///test-xds-host",
+			token:  defaultToken,
+		},
+		{
+			desc: "client from another namespace dialing a hostname",
+			annotations: map[string]string{
+				gtcv1alpha1.AnnotationHostname: "test-xds-host",
+			},
+			target:     "xds:///test-xds-host",
+			token:      otherToken,
+			wantDenied: true,
+		},
+		{
+			desc:       "unauthenticated client",
+			nodeID:     "test-client",
+			wantDenied: true,
+		},
+		{
+			desc:       "unauthenticated client forging its node ID",
+			nodeID:     "default/test-client",
+			wantDenied: true,
+		},
+		{
+			desc: "unauthenticated client allowed by annotation",
+			annotations: map[string]string{
+				gtcv1alpha1.AnnotationAllowedClientNamespaces: "*",
+			},
+			nodeID:     "default/test-client",
+			wantDenied: true,
+		},
+		{
+			desc:       "authenticated identity takes precedence over the node ID",
+			nodeID:     "default/test-client",
+			token:      otherToken,
+			wantDenied: true,
+		},
+	} {
+		t.Run(testCase.desc, func(t *testing.T) {
+			backends, err := tr.StartBackends(tr.Config{BackendCount: 1})
+			require.NoError(t, err)
+
+			defer func() {
+				err := backends.Stop()
+				require.NoError(t, err)
+			}()
+
+			var (
+				ctx, cancel = context.WithCancel(context.Background())
+				k8s         = tr.NewFakeK8s(
+					t,
+					[]gtcv1alpha1.GRPCListener{
+						tr.BuildGRPCListener(
+							"test-xds",
+							"default",
+							tr.WithAnnotations(testCase.annotations),
+							tr.WithAliases("test-xds-alias"),
+							tr.WithRoutes(
+								tr.BuildRoute(
+									tr.WithBackends(
+										tr.BuildBackend(
+											tr.WithServiceRef(
+												gtcv1alpha1.ServiceRef{
+													Name: serviceNameV1,
+													Port: grpcPort,
+												},
+											),
+										),
+									),
+								),
+							),
+						),
+					},
+					tr.BuildEndpointSlices(serviceNameV1, defaultNamespace, backends),
+				)
+				serverConfig = bootstrap.ServerConfig{URI: authzXDSAddr}
+				deniedBefore = deniedSubscriptions(t)
+			)
+
+			defer cancel()
+
+			k8s.AcceptTokens(map[string]string{
+				defaultToken: "system:serviceaccount:default:test-client",
+				otherToken:   "system:serviceaccount:other:test-client",
+			})
+
+			if testCase.token != "" {
+				serverConfig.CACertificateFile = tlsFiles.CAFile
+				serverConfig.TokenFile = filepath.Join(t.TempDir(), "token")
+
+				err = os.WriteFile(serverConfig.TokenFile, []byte(testCase.token), 0o600)
+				require.NoError(t, err)
+			}
+
+			runServer(
+				ctx,
+				t,
+				k8s,
+				gtc.XDSServerConfig{
+					BindAddr: ":16002",
+					TLS:      tlsFiles.ServerConfig(t),
+					Authentication: gtc.AuthenticationConfig{
+						TokenReviews: k8s.K8s.AuthenticationV1().TokenReviews(),
+					},
+					Authorization: gtc.AuthorizationConfig{Enabled: true},
+				},
+			)
+
+			if testCase.token == "" {
+				// Plaintext clients can't reach a TLS server.
+				serverConfig.CACertificateFile = tlsFiles.CAFile
+			}
+
+			target := testCase.target
+			if target == "" {
+				target = "xds:///default/test-xds"
+			}
+
+			callCtx := tr.BootstrapCallContext(
+				target,
+				bootstrap.BootstrapConfig{
+					XDSServers: []bootstrap.XDSServer{serverConfig.XDSServer()},
+					Node:       bootstrap.Node{ID: testCase.nodeID},
+				},
+			)(t)
+
+			if testCase.wantDenied {
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+						tr.WithTimeout(time.Second),
+					),
+					tr.MustFail,
+				)(t, callCtx)
+
+				// Unauthenticated streams are rejected before subscribing to anything.
+				if testCase.token != "" {
+					require.Greater(t, deniedSubscriptions(t), deniedBefore)
+				}
+			} else {
+				tr.CallOnce(
+					tr.BuildCaller(
+						tr.MethodEcho,
+					),
+					tr.NoCallErrors,
+					tr.CountByBackendID(
+						tr.AssertCount("backend-0", 1),
+					),
+				)(t, callCtx)
+
+				require.Equal(t, deniedBefore, deniedSubscriptions(t))
+			}
+
+			err = callCtx.Close()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestServerAuthorizationDeniedSubscription(t *testing.T) {
+	const authzXDSAddr = "localhost:16006"
+
+	backends, err := tr.StartBackends(tr.Config{BackendCount: 1})
+	require.NoError(t, err)
+
+	defer func() {
+		err := backends.Stop()
+		require.NoError(t, err)
+	}()
+
+	route := tr.BuildRoute(
+		tr.WithBackends(
+			tr.BuildBackend(
+				tr.WithServiceRef(
+					gtcv1alpha1.ServiceRef{
+						Name: serviceNameV1,
+						Port: grpcPort,
+					},
+				),
+			),
+		),
+	)
+
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		tlsFiles    = tr.GenerateTLSFiles(t, "spiffe://cluster.local/ns/default/sa/test-client")
+		k8s         = tr.NewFakeK8s(
+			t,
+			[]gtcv1alpha1.GRPCListener{
+				tr.BuildGRPCListener("test-xds", "default", tr.WithRoutes(route)),
+				tr.BuildGRPCListener("test-xds-2", "default", tr.WithRoutes(route)),
+				tr.BuildGRPCListener("test-xds", "other", tr.WithRoutes(route)),
+			},
+			tr.BuildEndpointSlices(serviceNameV1, defaultNamespace, backends),
+		)
+	)
+
+	defer cancel()
+
+	runServer(
+		ctx,
+		t,
+		k8s,
+		gtc.XDSServerConfig{
+			BindAddr:      ":16006",
+			TLS:           tlsFiles.ServerConfig(t),
+			Authorization: gtc.AuthorizationConfig{Enabled: true},
+		},
+	)
+
+	var (
+		stream       = tr.OpenADSStream(t, authzXDSAddr, tlsFiles.ClientConfig(t), &corev3.Node{Id: "test-client"})
+		deniedBefore = deniedSubscriptions(t)
+	)
+
+	got := stream.Subscribe(t, resourcesv3.ListenerType, "default/test-xds")
+	assert.Equal(t, []string{"default/test-xds"}, got)
+
+	// The denied listener is left out of the response, the allowed one keeps being served on the same stream.
+	got = stream.Subscribe(t, resourcesv3.ListenerType, "default/test-xds", "other/test-xds")
+	assert.Equal(t, []string{"default/test-xds"}, got)
+	assert.Equal(t, deniedBefore+1, deniedSubscriptions(t))
+
+	got = stream.Subscribe(t, resourcesv3.ListenerType, "default/test-xds", "other/test-xds", "default/test-xds-2")
+	assert.ElementsMatch(t, []string{"default/test-xds", "default/test-xds-2"}, got)
+	// The subscription was already denied on this stream.
+	assert.Equal(t, deniedBefore+1, deniedSubscriptions(t))
+}
+
+func TestServerRouteLookup(t *testing.T) {
+	const routeLookupXDSAddr = "localhost:16003"
+
+	backends, err := tr.StartBackends(tr.Config{BackendCount: 2})
+	require.NoError(t, err)
+
+	defer func() {
+		err := backends.Stop()
+		require.NoError(t, err)
+	}()
+
+	routeLookupService, err := tr.StartFakeRouteLookupService(
+		func(keys map[string]string) []string {
+			switch keys["shard"] {
+			case "a":
+				return []string{"default/test-xds/target/shard-a"}
+			case "b":
+				return []string{"default/test-xds/target/shard-b"}
+			default:
+				return nil
+			}
+		},
+	)
+	require.NoError(t, err)
+
+	defer routeLookupService.Stop()
+
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		k8s         = tr.NewFakeK8s(
+			t,
+			[]gtcv1alpha1.GRPCListener{
+				tr.BuildGRPCListener(
+					"test-xds",
+					"default",
+					tr.WithRoutes(
+						tr.BuildRoute(
+							tr.WithRouteLookup(
+								gtcv1alpha1.RouteLookup{
+									LookupService:  routeLookupService.Target(),
+									CacheSizeBytes: 1024,
+									KeyBuilders: []gtcv1alpha1.RouteLookupKeyBuilder{
+										{
+											Methods: []gtcv1alpha1.MethodMatcher{
+												{Namespace: "echo", Service: "Echo"},
+											},
+											Metadata: []gtcv1alpha1.RouteLookupMetadataKey{
+												{Key: "shard", Names: []string{"x-shard"}},
+											},
+										},
+									},
+									Targets: []gtcv1alpha1.RouteLookupTarget{
+										{
+											Name: "shard-a",
+											Backend: tr.BuildBackend(
+												tr.WithServiceRef(
+													gtcv1alpha1.ServiceRef{
+														Name: serviceNameV1,
+														Port: grpcPort,
+													},
+												),
+											),
+										},
+										{
+											Name: "shard-b",
+											Backend: tr.BuildBackend(
+												tr.WithServiceRef(
+													gtcv1alpha1.ServiceRef{
+														Name: serviceNameV2,
+														Port: grpcPort,
+													},
+												),
+											),
+										},
+									},
+									DefaultTarget: "shard-a",
+								},
+							),
+						),
+					),
+				),
+			},
+			tr.AppendEndpointSlices(
+				tr.BuildEndpointSlices(serviceNameV1, defaultNamespace, backends[0:1]),
+				tr.BuildEndpointSlices(serviceNameV2, defaultNamespace, backends[1:2]),
+			),
+		)
+	)
+
+	defer cancel()
+
+	runServer(ctx, t, k8s, gtc.XDSServerConfig{BindAddr: ":16003"})
+
+	callCtx := tr.BootstrapCallContext(
+		"xds:///default/test-xds",
+		bootstrap.BootstrapConfig{
+			XDSServers: []bootstrap.XDSServer{
+				bootstrap.ServerConfig{URI: routeLookupXDSAddr}.XDSServer(),
+			},
+			Node: bootstrap.Node{ID: "test-id"},
+		},
+	)(t)
+
+	defer func() {
+		err := callCtx.Close()
+		require.NoError(t, err)
+	}()
+
+	tr.MultiAssert(
+		tr.CallN(
+			tr.BuildCaller(
+				tr.MethodEcho,
+				tr.WithMetadata(map[string]string{"x-shard": "b"}),
+			),
+			10,
+			tr.NoCallErrors,
+			tr.CountByBackendID(
+				tr.AssertCount("backend-1", 10),
+			),
+		),
+		tr.CallN(
+			tr.BuildCaller(
+				tr.MethodEcho,
+				tr.WithMetadata(map[string]string{"x-shard": "a"}),
+			),
+			10,
+			tr.NoCallErrors,
+			tr.CountByBackendID(
+				tr.AssertCount("backend-0", 10),
+			),
+		),
+		// Failed lookups are routed to the default target.
+		tr.CallN(
+			tr.BuildCaller(
+				tr.MethodEcho,
+				tr.WithMetadata(map[string]string{"x-shard": "unknown"}),
+			),
+			10,
+			tr.NoCallErrors,
+			tr.CountByBackendID(
+				tr.AssertCount("backend-0", 10),
+			),
+		),
+	)(t, callCtx)
+}
+
+func TestServerFaultExperiment(t *testing.T) {
+	const faultExperimentXDSAddr = "localhost:16004"
+
+	backends, err := tr.StartBackends(tr.Config{BackendCount: 2})
+	require.NoError(t, err)
+
+	defer func() {
+		err := backends.Stop()
+		require.NoError(t, err)
+	}()
+
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		k8s         = tr.NewFakeK8s(
+			t,
+			[]gtcv1alpha1.GRPCListener{
+				tr.BuildGRPCListener(
+					"test-xds",
+					"default",
+					tr.WithRoutes(
+						tr.BuildRoute(
+							tr.WithRouteMatcher(
+								tr.BuildRouteMatcher(
+									tr.WithMethodMatcher("echo", "Echo", "EchoPremium"),
+								),
+							),
+							tr.WithBackends(
+								tr.BuildBackend(
+									tr.WithServiceRef(
+										gtcv1alpha1.ServiceRef{
+											Name: serviceNameV2,
+											Port: grpcPort,
+										},
+									),
+								),
+							),
+						),
+						tr.BuildRoute(
+							tr.WithBackends(
+								tr.BuildBackend(
+									tr.WithServiceRef(
+										gtcv1alpha1.ServiceRef{
+											Name: serviceNameV1,
+											Port: grpcPort,
+										},
+									),
+								),
+							),
+						),
+					),
+				),
+			},
+			tr.AppendEndpointSlices(
+				tr.BuildEndpointSlices(serviceNameV1, defaultNamespace, backends[0:1]),
+				tr.BuildEndpointSlices(serviceNameV2, defaultNamespace, backends[1:2]),
+			),
+		)
+		start    = time.Now().Add(time.Second)
+		duration = 2 * time.Second
+	)
+
+	defer cancel()
+
+	k8s.CreateFaultExperiments(
+		t,
+		// Aborts all calls to the first route.
+		tr.BuildFaultExperiment(
+			"abort-premium",
+			"default",
+			gtcv1alpha1.FaultExperimentTarget{
+				Listener: "test-xds",
+				Route:    tr.Ptr(0),
+			},
+			start,
+			duration,
+			gtcv1alpha1.FaultInterceptor{
+				Abort: &gtcv1alpha1.FaultAbort{
+					Code: tr.Ptr(uint32(10)),
+					Percentage: &gtcv1alpha1.Fraction{
+						Numerator:   100,
+						Denominator: "hundred",
+					},
+				},
+			},
+		),
+		// Aborts the calls to the backend of the second route.
+		tr.BuildFaultExperiment(
+			"abort-backend",
+			"default",
+			gtcv1alpha1.FaultExperimentTarget{
+				Listener: "test-xds",
+				Route:    tr.Ptr(1),
+				Backend:  tr.Ptr(0),
+			},
+			start,
+			duration,
+			gtcv1alpha1.FaultInterceptor{
+				Abort: &gtcv1alpha1.FaultAbort{
+					Code: tr.Ptr(uint32(8)),
+					Percentage: &gtcv1alpha1.Fraction{
+						Numerator:   100,
+						Denominator: "hundred",
+					},
+				},
+			},
+		),
+	)
+
+	runServer(ctx, t, k8s, gtc.XDSServerConfig{BindAddr: ":16004"})
+
+	callCtx := tr.BootstrapCallContext(
+		"xds:///default/test-xds",
+		bootstrap.BootstrapConfig{
+			XDSServers: []bootstrap.XDSServer{
+				bootstrap.ServerConfig{URI: faultExperimentXDSAddr}.XDSServer(),
+			},
+			Node: bootstrap.Node{ID: "test-id"},
+		},
+	)(t)
+
+	defer func() {
+		err := callCtx.Close()
+		require.NoError(t, err)
+	}()
+
+	// Pending experiments don't inject any fault.
+	tr.MultiAssert(
+		tr.CallOnce(
+			tr.BuildCaller(tr.MethodEchoPremium),
+			tr.NoCallErrors,
+			tr.CountByBackendID(tr.AssertCount("backend-1", 1)),
+		),
+		tr.CallOnce(
+			tr.BuildCaller(tr.MethodEcho),
+			tr.NoCallErrors,
+			tr.CountByBackendID(tr.AssertCount("backend-0", 1)),
+		),
+	)(t, callCtx)
+
+	assertFaultExperimentPhase(t, k8s, "abort-premium", gtcv1alpha1.FaultExperimentPhasePending)
+
+	time.Sleep(time.Until(start.Add(500 * time.Millisecond)))
+
+	tr.MultiAssert(
+		tr.CallOnce(
+			tr.BuildCaller(tr.MethodEchoPremium),
+			tr.MustFailWithCode(codes.Aborted),
+		),
+		tr.CallOnce(
+			tr.BuildCaller(tr.MethodEcho),
+			tr.MustFailWithCode(codes.ResourceExhausted),
+		),
+	)(t, callCtx)
+
+	assertFaultExperimentPhase(t, k8s, "abort-premium", gtcv1alpha1.FaultExperimentPhaseRunning)
+	assertFaultExperimentPhase(t, k8s, "abort-backend", gtcv1alpha1.FaultExperimentPhaseRunning)
+
+	time.Sleep(time.Until(start.Add(duration + 500*time.Millisecond)))
+
+	// Expired experiments are removed.
+	tr.MultiAssert(
+		tr.CallOnce(
+			tr.BuildCaller(tr.MethodEchoPremium),
+			tr.NoCallErrors,
+			tr.CountByBackendID(tr.AssertCount("backend-1", 1)),
+		),
+		tr.CallOnce(
+			tr.BuildCaller(tr.MethodEcho),
+			tr.NoCallErrors,
+			tr.CountByBackendID(tr.AssertCount("backend-0", 1)),
+		),
+	)(t, callCtx)
+
+	assertFaultExperimentPhase(t, k8s, "abort-premium", gtcv1alpha1.FaultExperimentPhaseCompleted)
+	assertFaultExperimentPhase(t, k8s, "abort-backend", gtcv1alpha1.FaultExperimentPhaseCompleted)
+}
+
+func TestServerInvalidFaultExperiment(t *testing.T) {
+	const faultExperimentXDSAddr = "localhost:16005"
+
+	backends, err := tr.StartBackends(tr.Config{BackendCount: 1})
+	require.NoError(t, err)
+
+	defer func() {
+		err := backends.Stop()
+		require.NoError(t, err)
+	}()
+
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		k8s         = tr.NewFakeK8s(
+			t,
+			[]gtcv1alpha1.GRPCListener{
+				tr.BuildGRPCListener(
+					"test-xds",
+					"default",
+					tr.WithRoutes(
+						tr.BuildRoute(
+							tr.WithRouteMatcher(
+								tr.BuildRouteMatcher(
+									tr.WithFractionMatcher(
+										gtcv1alpha1.Fraction{
+											Numerator:   100,
+											Denominator: "hundred",
+										},
+									),
+								),
+							),
+							tr.WithBackends(
+								tr.BuildBackend(
+									tr.WithServiceRef(
+										gtcv1alpha1.ServiceRef{
+											Name: serviceNameV1,
+											Port: grpcPort,
+										},
+									),
+								),
+							),
+						),
+					),
+				),
+			},
+			tr.BuildEndpointSlices(serviceNameV1, defaultNamespace, backends[0:1]),
+		)
+		abort = &gtcv1alpha1.FaultAbort{
+			Code: tr.Ptr(uint32(10)),
+			Percentage: &gtcv1alpha1.Fraction{
+				Numerator:   100,
+				Denominator: "hundred",
+			},
+		}
+	)
+
+	defer cancel()
+
+	k8s.CreateFaultExperiments(
+		t,
+		// Backend faults can't match headers.
+		tr.BuildFaultExperiment(
+			"abort-backend-with-headers",
+			"default",
+			gtcv1alpha1.FaultExperimentTarget{
+				Listener: "test-xds",
+				Route:    tr.Ptr(0),
+				Backend:  tr.Ptr(0),
+			},
+			time.Now(),
+			time.Minute,
+			gtcv1alpha1.FaultInterceptor{
+				Abort: abort,
+				Headers: []gtcv1alpha1.HeaderMatcher{
+					gtcv1alpha1.HeaderMatcher(tr.MetadataExactMatch("x-chaos", "true")),
+				},
+			},
+		),
+		// The listener has a single route.
+		tr.BuildFaultExperiment(
+			"abort-missing-route",
+			"default",
+			gtcv1alpha1.FaultExperimentTarget{
+				Listener: "test-xds",
+				Route:    tr.Ptr(1),
+			},
+			time.Now(),
+			time.Minute,
+			gtcv1alpha1.FaultInterceptor{Abort: abort},
+		),
+		// The route matches a fraction of the calls, faults matching headers can't be injected in it.
+		tr.BuildFaultExperiment(
+			"abort-fraction-route-with-headers",
+			"default",
+			gtcv1alpha1.FaultExperimentTarget{
+				Listener: "test-xds",
+			},
+			time.Now(),
+			time.Minute,
+			gtcv1alpha1.FaultInterceptor{
+				Abort: abort,
+				Headers: []gtcv1alpha1.HeaderMatcher{
+					gtcv1alpha1.HeaderMatcher(tr.MetadataExactMatch("x-chaos", "true")),
+				},
+			},
+		),
+	)
+
+	runServer(ctx, t, k8s, gtc.XDSServerConfig{BindAddr: ":16005"})
+
+	callCtx := tr.BootstrapCallContext(
+		"xds:///default/test-xds",
+		bootstrap.BootstrapConfig{
+			XDSServers: []bootstrap.XDSServer{
+				bootstrap.ServerConfig{URI: faultExperimentXDSAddr}.XDSServer(),
+			},
+			Node: bootstrap.Node{ID: "test-id"},
+		},
+	)(t)
+
+	defer func() {
+		err := callCtx.Close()
+		require.NoError(t, err)
+	}()
+
+	// Invalid experiments are skipped, the listener is still served.
+	tr.CallOnce(
+		tr.BuildCaller(
+			tr.MethodEcho,
+			tr.WithTimeout(time.Second),
+			tr.WithMetadata(map[string]string{"x-chaos": "true"}),
+		),
+		tr.NoCallErrors,
+		tr.CountByBackendID(tr.AssertCount("backend-0", 1)),
+	)(t, callCtx)
+
+	for _, name := range []string{"abort-backend-with-headers", "abort-missing-route", "abort-fraction-route-with-headers"} {
+		assertFaultExperimentPhase(t, k8s, name, gtcv1alpha1.FaultExperimentPhaseInvalid)
+
+		experiment, err := k8s.GTCApi.ApiV1alpha1().FaultExperiments("default").Get(context.Background(), name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.NotEmpty(t, experiment.Status.Message, name)
+	}
+}
+
+func assertFaultExperimentPhase(t *testing.T, k8s tr.FakeK8s, name, wantPhase string) {
+	t.Helper()
+
+	require.Eventually(
+		t,
+		func() bool {
+			experiment, err := k8s.GTCApi.ApiV1alpha1().FaultExperiments("default").Get(context.Background(), name, metav1.GetOptions{})
+			require.NoError(t, err)
+
+			return experiment.Status.Phase == wantPhase
+		},
+		time.Second,
+		50*time.Millisecond,
+	)
+}
+
+// runServer starts an xDS server configured by cfg, backed by the given fake k8s.
+func runServer(ctx context.Context, t *testing.T, k8s tr.FakeK8s, cfg gtc.XDSServerConfig) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	cfg.K8sInformers = k8s.K8sInformers
+	cfg.GTCInformers = k8s.GTCInformers
+	cfg.GTCClient = k8s.GTCApi
+
+	server, err := gtc.NewXDSServer(ctx, cfg, newLogger(t))
+	require.NoError(t, err)
+
+	k8s.Start(ctx, t)
+
+	serverExited := make(chan struct{})
+
+	go func() {
+		err := server.Run(ctx)
+		require.NoError(t, err)
+		close(serverExited)
+	}()
+
+	// Always explicitely stop the server and wait for it to be finished.
+	t.Cleanup(func() {
+		cancel()
+
+		<-serverExited
+	})
+}
+
+func deniedSubscriptions(t *testing.T) float64 {
+	t.Helper()
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+
+	var total float64
+
+	for _, family := range families {
+		if family.GetName() != "gtc_xds_denied_subscriptions_total" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			total += metric.GetCounter().GetValue()
+		}
+	}
+
+	return total
+}
+
+// localCallContext is a call context for a client running in zone-a of region-1, on the given node if not empty.
+func localCallContext(nodeName string) func(t *testing.T) *tr.CallContext {
+	var metadata map[string]string
+
+	if nodeName != "" {
+		metadata = map[string]string{bootstrap.MetadataNodeName: nodeName}
+	}
+
+	return tr.BootstrapCallContext(
+		"xds:///default/test-xds",
+		bootstrap.BootstrapConfig{
+			XDSServers: []bootstrap.XDSServer{
+				bootstrap.ServerConfig{URI: "localhost:16000"}.XDSServer(),
+			},
+			Node: bootstrap.Node{
+				ID: "test-id",
+				Locality: bootstrap.Locality{
+					Region: "region-1",
+					Zone:   "zone-a",
+				},
+				Metadata: metadata,
+			},
+		},
+	)
+}
+
+// nodeCallContext returns a call context of a client dialing the given target with the given node ID.
+func nodeCallContext(target, nodeID string) func(t *testing.T) *tr.CallContext {
+	return tr.BootstrapCallContext(
+		target,
+		bootstrap.BootstrapConfig{
+			XDSServers: []bootstrap.XDSServer{
+				bootstrap.ServerConfig{URI: "localhost:16000"}.XDSServer(),
+			},
+			Node: bootstrap.Node{ID: nodeID},
+		},
+	)
+}
+
+// metadataCallContext returns a call context of a client running in zone-a of region-1, with the given node metadata.
+func metadataCallContext(metadata map[string]string) func(t *testing.T) *tr.CallContext {
+	return tr.BootstrapCallContext(
+		"xds:///default/test-xds",
+		bootstrap.BootstrapConfig{
+			XDSServers: []bootstrap.XDSServer{
+				bootstrap.ServerConfig{URI: "localhost:16000"}.XDSServer(),
+			},
+			Node: bootstrap.Node{
+				ID: "test-id",
+				Locality: bootstrap.Locality{
+					Region: "region-1",
+					Zone:   "zone-a",
+				},
+				Metadata: metadata,
+			},
+		},
+	)
+}
+
+// podCallContext returns a call context of a client identifying its pod, with a bootstrap locality in zone-a.
+func podCallContext(namespace, name string) func(t *testing.T) *tr.CallContext {
+	return tr.BootstrapCallContext(
+		"xds:///default/test-xds",
+		bootstrap.BootstrapConfig{
+			XDSServers: []bootstrap.XDSServer{
+				bootstrap.ServerConfig{URI: "localhost:16000"}.XDSServer(),
+			},
+			Node: bootstrap.Node{
+				ID: "test-id",
+				Locality: bootstrap.Locality{
+					Region: "region-1",
+					Zone:   "zone-a",
+				},
+				Metadata: map[string]string{
+					bootstrap.MetadataPodNamespace: namespace,
+					bootstrap.MetadataPodName:      name,
+				},
+			},
+		},
+	)
+}
+
+// withFirstBackendClusterName looks up the name of the cluster of the first backend of the test listener,
+// as served in its route configuration, and runs the assertion built for it.
+func withFirstBackendClusterName(buildAssertion func(clusterName string) func(*testing.T, *tr.CallContext)) func(*testing.T, *tr.CallContext) {
+	return func(t *testing.T, callCtx *tr.CallContext) {
+		var clusterName string
+
+		tr.AssertRouteConfig(
+			"localhost:16000",
+			&corev3.Node{Id: "test-id"},
+			"default/test-xds",
+			func(t *testing.T, routeConfig *routev3.RouteConfiguration) {
+				clusters := routeConfig.GetVirtualHosts()[0].GetRoutes()[0].GetRoute().GetWeightedClusters().GetClusters()
+				require.NotEmpty(t, clusters)
+
+				clusterName = clusters[0].GetName()
+			},
+		)(t, callCtx)
+
+		buildAssertion(clusterName)(t, callCtx)
+	}
+}
+
+// assertFirstBackendLoadAssignment runs the given assertion against the load assignment served for the first backend of the test listener.
+func assertFirstBackendLoadAssignment(assertion func(t *testing.T, cla *endpointv3.ClusterLoadAssignment)) func(*testing.T, *tr.CallContext) {
+	return withFirstBackendClusterName(
+		func(clusterName string) func(*testing.T, *tr.CallContext) {
+			return tr.AssertLoadAssignment(
+				"localhost:16000",
+				&corev3.Node{Id: "test-id"},
+				clusterName,
+				assertion,
+			)
+		},
+	)
+}
+
+// assertFirstBackendCluster runs the given assertion against the cluster served for the first backend of the test listener.
+func assertFirstBackendCluster(assertion func(t *testing.T, cluster *clusterv3.Cluster)) func(*testing.T, *tr.CallContext) {
+	return withFirstBackendClusterName(
+		func(clusterName string) func(*testing.T, *tr.CallContext) {
+			return tr.AssertCluster(
+				"localhost:16000",
+				&corev3.Node{Id: "test-id"},
+				clusterName,
+				assertion,
+			)
+		},
+	)
+}
+
+// assertHealthStatuses checks the health statuses of the endpoints served for the first backend of the test listener, in order.
+func assertHealthStatuses(want ...corev3.HealthStatus) func(*testing.T, *tr.CallContext) {
+	return assertFirstBackendLoadAssignment(
+		func(t *testing.T, cla *endpointv3.ClusterLoadAssignment) {
+			var got []corev3.HealthStatus
+
+			for _, locality := range cla.Endpoints {
+				for _, ep := range locality.LbEndpoints {
+					got = append(got, ep.HealthStatus)
+				}
+			}
+
+			assert.Equal(t, want, got)
+		},
+	)
+}
+
+// assertEndpointAddresses checks the addresses of the endpoints served for the first backend of the test listener, in order,
+// the first address of each endpoint being its address and the other ones its additional addresses.
+func assertEndpointAddresses(want ...[]string) func(*testing.T, *tr.CallContext) {
+	return assertFirstBackendLoadAssignment(
+		func(t *testing.T, cla *endpointv3.ClusterLoadAssignment) {
+			var got [][]string
+
+			for _, locality := range cla.Endpoints {
+				for _, ep := range locality.LbEndpoints {
+					addrs := []string{ep.GetEndpoint().GetAddress().GetSocketAddress().GetAddress()}
+
+					for _, additional := range ep.GetEndpoint().GetAdditionalAddresses() {
+						addrs = append(addrs, additional.GetAddress().GetSocketAddress().GetAddress())
+					}
+
+					got = append(got, addrs)
+				}
+			}
+
+			assert.Equal(t, want, got)
+		},
+	)
+}
+
+// assertEndpointHashKeys checks the ring hash keys of the endpoints served for the first backend of the test listener, in order,
+// an empty key meaning no key.
+func assertEndpointHashKeys(want ...string) func(*testing.T, *tr.CallContext) {
+	return assertFirstBackendLoadAssignment(
+		func(t *testing.T, cla *endpointv3.ClusterLoadAssignment) {
+			var got []string
+
+			for _, locality := range cla.Endpoints {
+				for _, ep := range locality.LbEndpoints {
+					got = append(
+						got,
+						ep.GetMetadata().GetFilterMetadata()["envoy.lb"].GetFields()["hash_key"].GetStringValue(),
+					)
+				}
+			}
+
+			assert.Equal(t, want, got)
+		},
+	)
+}
+
+// assertEndpointWeights checks the weight of the single locality served for the first backend of the test listener,
+// followed by the weights of its endpoints in order, 0 meaning no weight.
+func assertEndpointWeights(wantLocalityWeight uint32, wantEndpointWeights ...uint32) func(*testing.T, *tr.CallContext) {
+	return assertFirstBackendLoadAssignment(
+		func(t *testing.T, cla *endpointv3.ClusterLoadAssignment) {
+			require.Len(t, cla.Endpoints, 1)
+			assert.Equal(t, wantLocalityWeight, cla.Endpoints[0].GetLoadBalancingWeight().GetValue())
+
+			var got []uint32
+
+			for _, ep := range cla.Endpoints[0].LbEndpoints {
+				got = append(got, ep.GetLoadBalancingWeight().GetValue())
+			}
+
+			assert.Equal(t, wantEndpointWeights, got)
+		},
+	)
+}
+
+// tenantCallers builds callers sending distinct session keys, all prefixed by the given tenant.
+func tenantCallers(tenant string, count int) []tr.Caller {
+	callers := make([]tr.Caller, count)
+
+	for i := range callers {
+		callers[i] = tr.BuildCaller(
+			tr.MethodEcho,
+			tr.WithMetadata(map[string]string{"session-key": fmt.Sprintf("%s:%d", tenant, i)}),
+		)
+	}
+
+	return callers
 }
 
 func noChange(*testing.T, tr.FakeK8s, []tr.Backend) {}
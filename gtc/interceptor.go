@@ -3,10 +3,12 @@ package gtc
 import (
 	"errors"
 
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	faultv31 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/common/fault/v3"
 	faultv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/fault/v3"
 	router "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/router/v3"
 	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	anyv1 "github.com/golang/protobuf/ptypes/any"
 	gtcv1alpha1 "github.com/jlevesy/grpc-traffic-controller/api/gtc/v1alpha1"
@@ -48,7 +50,7 @@ func makeFilters(interceptors []gtcv1alpha1.Interceptor) ([]*hcm.HttpFilter, err
 func makeFilter(interceptor gtcv1alpha1.Interceptor) (*hcm.HttpFilter, error) {
 	switch {
 	case interceptor.Fault != nil:
-		faultFilter, err := makeFaultFilter(interceptor.Fault)
+		faultConfig, err := makeFaultFilterConfig(interceptor.Fault)
 		if err != nil {
 			return nil, err
 		}
@@ -56,7 +58,7 @@ func makeFilter(interceptor gtcv1alpha1.Interceptor) (*hcm.HttpFilter, error) {
 		return &hcm.HttpFilter{
 			Name: wellknown.Fault,
 			ConfigType: &hcm.HttpFilter_TypedConfig{
-				TypedConfig: mustAny(faultFilter),
+				TypedConfig: faultConfig,
 			},
 		}, nil
 	default:
@@ -82,17 +84,92 @@ func makeFilterOverrides(interceptors []gtcv1alpha1.Interceptor) (map[string]*an
 func makeFilterOverride(interceptor gtcv1alpha1.Interceptor) (string, *anyv1.Any, error) {
 	switch {
 	case interceptor.Fault != nil:
-		faultFilter, err := makeFaultFilter(interceptor.Fault)
+		faultConfig, err := makeFaultFilterConfig(interceptor.Fault)
 		if err != nil {
 			return "", nil, err
 		}
 
-		return wellknown.Fault, mustAny(faultFilter), nil
+		return wellknown.Fault, faultConfig, nil
 	default:
 		return "", nil, errors.New("malformed filter override")
 	}
 }
 
+// makeFaultFilterConfig returns the filter config of a fault interceptor.
+// gRPC clients ignore the headers of the fault filter: a fault matching headers is disabled here,
+// and injected by routes matching these headers instead, see makeHeaderFault.
+func makeFaultFilterConfig(fault *gtcv1alpha1.FaultInterceptor) (*anyv1.Any, error) {
+	faultFilter, err := makeFaultFilter(fault)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(faultFilter.Headers) > 0 {
+		return mustAny(&faultv3.HTTPFault{}), nil
+	}
+
+	return mustAny(faultFilter), nil
+}
+
+// makeHeaderFault returns the headers to match and the filter overrides injecting the fault of a route,
+// if this fault only applies to calls matching headers.
+func makeHeaderFault(listener *gtcv1alpha1.GRPCListener, routeSpec gtcv1alpha1.Route, filterOverrides map[string]*anyv1.Any) ([]*route.HeaderMatcher, map[string]*anyv1.Any, error) {
+	// The fault of a route replaces the fault of the listener.
+	fault := findFault(routeSpec.Interceptors)
+	if fault == nil {
+		fault = findFault(listener.Spec.Interceptors)
+	}
+
+	if fault == nil || len(fault.Headers) == 0 {
+		return nil, nil, nil
+	}
+
+	// Calls carrying the headers would be rolled against the fraction twice: by the route injecting the fault,
+	// then by the route itself if not picked.
+	if matchesFraction(routeSpec) {
+		return nil, nil, errors.New("a route matching a fraction of the calls can't inject a fault matching headers")
+	}
+
+	faultFilter, err := makeFaultFilter(fault)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	faultOverrides := make(map[string]*anyv1.Any, len(filterOverrides)+1)
+	for name, cfg := range filterOverrides {
+		faultOverrides[name] = cfg
+	}
+
+	faultOverrides[wellknown.Fault] = mustAny(faultFilter)
+
+	return faultFilter.Headers, faultOverrides, nil
+}
+
+// matchesFraction returns true if any matcher of the route matches a fraction of the calls.
+func matchesFraction(routeSpec gtcv1alpha1.Route) bool {
+	if routeSpec.Matcher != nil && routeSpec.Matcher.Fraction != nil {
+		return true
+	}
+
+	for _, matcher := range routeSpec.Matchers {
+		if matcher.Fraction != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func findFault(interceptors []gtcv1alpha1.Interceptor) *gtcv1alpha1.FaultInterceptor {
+	for _, interceptor := range interceptors {
+		if interceptor.Fault != nil {
+			return interceptor.Fault
+		}
+	}
+
+	return nil
+}
+
 func makeFaultFilter(fault *gtcv1alpha1.FaultInterceptor) (*faultv3.HTTPFault, error) {
 	var ff faultv3.HTTPFault
 
@@ -118,6 +195,9 @@ func makeFaultFilter(fault *gtcv1alpha1.FaultInterceptor) (*faultv3.HTTPFault, e
 			if err != nil {
 				return nil, err
 			}
+		} else if fault.Delay.Metadata != nil {
+			// Metadata can only lower the percentage of the fault, which gRPC clients default to 0.
+			ff.Delay.Percentage = &typev3.FractionalPercent{Numerator: 100}
 		}
 	}
 
@@ -143,6 +223,9 @@ func makeFaultFilter(fault *gtcv1alpha1.FaultInterceptor) (*faultv3.HTTPFault, e
 			if err != nil {
 				return nil, err
 			}
+		} else if fault.Abort.Metadata != nil {
+			// Metadata can only lower the percentage of the fault, which gRPC clients default to 0.
+			ff.Abort.Percentage = &typev3.FractionalPercent{Numerator: 100}
 		}
 	}
 
@@ -150,5 +233,14 @@ func makeFaultFilter(fault *gtcv1alpha1.FaultInterceptor) (*faultv3.HTTPFault, e
 		ff.MaxActiveFaults = wrapperspb.UInt32(*fault.MaxActiveFaults)
 	}
 
+	for _, header := range fault.Headers {
+		headerMatcher, err := makeMetadataMatcher(gtcv1alpha1.MetadataMatcher(header))
+		if err != nil {
+			return nil, err
+		}
+
+		ff.Headers = append(ff.Headers, headerMatcher)
+	}
+
 	return &ff, nil
 }
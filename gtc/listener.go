@@ -2,60 +2,82 @@ package gtc
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	resourcesv3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	gtcv1alpha1 "github.com/jlevesy/grpc-traffic-controller/api/gtc/v1alpha1"
 	gtclisters "github.com/jlevesy/grpc-traffic-controller/client/listers/gtc/v1alpha1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 type listenerHandler struct {
-	grpcListeners gtclisters.GRPCListenerLister
+	grpcListeners    gtclisters.GRPCListenerLister
+	faultExperiments faultExperiments
+
+	topologies
 }
 
 func (h *listenerHandler) resolveResource(req resolveRequest) (*resolveResponse, error) {
-	response := newResolveResponse(resourcesv3.ListenerType, len(req.resourceNames))
-
-	for i, resourceName := range req.resourceNames {
-		resource, version, err := h.makeListener(resourceName)
-		if err != nil {
+	response := newResolveResponse(resourcesv3.ListenerType, 0)
+
+	for _, resourceName := range req.resourceNames {
+		resource, versions, err := h.makeListener(resourceName, req.nodeInfo)
+		switch {
+		case kerrors.IsNotFound(err):
+			// Leaving the listener out of the response tells the client it doesn't exist, or doesn't anymore.
+			continue
+		case err != nil:
 			return nil, err
 		}
 
-		response.resources[i], err = encodeResource(req.typeUrl, resource)
+		encoded, err := encodeResource(req.typeUrl, resource)
 		if err != nil {
 			return nil, err
 		}
 
-		if err := response.useResourceVersion(version); err != nil {
-			return nil, err
+		response.resources = append(response.resources, encoded)
+
+		for _, v := range versions {
+			if err := response.useResourceVersion(v); err != nil {
+				return nil, err
+			}
 		}
 	}
 
 	return response, nil
 }
 
-func (h *listenerHandler) makeListener(resourceName string) (*listenerv3.Listener, string, error) {
-	namespace, name, err := parseListenerName(resourceName)
+func (h *listenerHandler) makeListener(resourceName string, node *core.Node) (*listenerv3.Listener, []string, error) {
+	listener, err := resolveListener(h.grpcListeners, resourceName, node)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	listener, experimentVersions, err := h.faultExperiments.apply(listener)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
-	listener, err := h.grpcListeners.GRPCListeners(namespace).Get(name)
+	client, _, err := h.clientTopology(node)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
+	listener, nodeVersion := selectNode(listener, node, client)
+
 	filters, err := makeFilters(listener.Spec.Interceptors)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
-	routeConfig, err := makeRouteConfig(resourceName, listener)
+	routeConfig, err := makeRouteConfig(listener)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
 	httpConnManager := &hcm.HttpConnectionManager{
@@ -73,22 +95,118 @@ func (h *listenerHandler) makeListener(resourceName string) (*listenerv3.Listene
 		ApiListener: &listenerv3.ApiListener{
 			ApiListener: mustAny(httpConnManager),
 		},
-	}, listener.ResourceVersion, nil
+	}, append(experimentVersions, listener.ResourceVersion, nodeVersion), nil
 }
 
-func parseListenerName(resourceName string) (string, string, error) {
-	sp := strings.SplitN(resourceName, "/", 2)
-	if len(sp) != 2 {
-		return "", "", malformedListenerResourceNameError(resourceName)
+// resolveListener returns the GRPCListener a listener resource name refers to, on behalf of the given node.
+// A short name, without namespace, is looked up in the namespace of the client first,
+// then among the hostnames of the listeners of all the namespaces.
+func resolveListener(lister gtclisters.GRPCListenerLister, resourceName string, node *core.Node) (*gtcv1alpha1.GRPCListener, error) {
+	if resourceName == "" {
+		return nil, malformedListenerResourceNameError(resourceName)
+	}
+
+	namespace, name, ok := strings.Cut(resourceName, "/")
+	if ok {
+		if namespace == "" || name == "" {
+			return nil, malformedListenerResourceNameError(resourceName)
+		}
+
+		return findListener(lister, namespace, name)
+	}
+
+	if clientNamespace, _, ok := clientPodRef(node); ok {
+		listener, err := findListener(lister, clientNamespace, resourceName)
+		if !kerrors.IsNotFound(err) {
+			return listener, err
+		}
 	}
 
-	return sp[0], sp[1], nil
+	return findListenerByHostname(lister, resourceName)
+}
+
+// findListenerByHostname returns the GRPCListener of any namespace with the given hostname annotation.
+// A hostname claimed by several listeners is rejected.
+func findListenerByHostname(lister gtclisters.GRPCListenerLister, hostname string) (*gtcv1alpha1.GRPCListener, error) {
+	listeners, err := lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var found *gtcv1alpha1.GRPCListener
+
+	for _, listener := range listeners {
+		if listener.Annotations[gtcv1alpha1.AnnotationHostname] != hostname {
+			continue
+		}
+
+		if found != nil {
+			return nil, fmt.Errorf(
+				"hostname %q is claimed by more than one GRPCListener: %s/%s and %s/%s",
+				hostname,
+				found.Namespace,
+				found.Name,
+				listener.Namespace,
+				listener.Name,
+			)
+		}
+
+		found = listener
+	}
+
+	if found == nil {
+		return nil, kerrors.NewNotFound(gtcv1alpha1.Resource("grpclisteners"), hostname)
+	}
+
+	return found, nil
+}
+
+// findListener returns the GRPCListener of the namespace named after name, or else the first one, by name, aliased by name.
+func findListener(lister gtclisters.GRPCListenerLister, namespace, name string) (*gtcv1alpha1.GRPCListener, error) {
+	listener, err := lister.GRPCListeners(namespace).Get(name)
+	if !kerrors.IsNotFound(err) {
+		return listener, err
+	}
+
+	listeners, listErr := lister.GRPCListeners(namespace).List(labels.Everything())
+	if listErr != nil {
+		return nil, listErr
+	}
+
+	slices.SortFunc(listeners, func(a, b *gtcv1alpha1.GRPCListener) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	for _, listener := range listeners {
+		if slices.Contains(listener.Spec.Aliases, name) {
+			return listener, nil
+		}
+	}
+
+	return nil, err
 }
 
 func listenerName(namespace, name string) string {
 	return namespace + "/" + name
 }
 
+// listenerResourceNames returns all the listener resource names clients can dial to reach the listener:
+// its name and aliases, with and without namespace, and its hostname.
+func listenerResourceNames(listener *gtcv1alpha1.GRPCListener) []string {
+	names := append([]string{listener.Name}, listener.Spec.Aliases...)
+	resourceNames := make([]string, 0, 2*len(names)+1)
+
+	for _, name := range names {
+		resourceNames = append(resourceNames, listenerName(listener.Namespace, name), name)
+	}
+
+	if hostname, ok := listener.Annotations[gtcv1alpha1.AnnotationHostname]; ok {
+		resourceNames = append(resourceNames, hostname)
+	}
+
+	return resourceNames
+}
+
 type malformedListenerResourceNameError string
 
 func (m malformedListenerResourceNameError) Error() string {
@@ -0,0 +1,16 @@
+package gtc
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var deniedSubscriptionsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "gtc",
+		Subsystem: "xds",
+		Name:      "denied_subscriptions_total",
+		Help:      "Count of xDS subscriptions denied by authorization, by type URL and GRPCListener namespace.",
+	},
+	[]string{"type_url", "namespace"},
+)
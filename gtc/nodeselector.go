@@ -0,0 +1,190 @@
+package gtc
+
+import (
+	"strings"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	gtcv1alpha1 "github.com/jlevesy/grpc-traffic-controller/api/gtc/v1alpha1"
+)
+
+// selectNode returns a copy of the listener holding only the routes and the interceptors selecting the given node,
+// or the listener itself if it has no node selector. The client topology is the one resolved to prioritize endpoints.
+// The version returned tells which selectors matched the node, as the listener resource now depends on it.
+func selectNode(listener *gtcv1alpha1.GRPCListener, node *core.Node, client topology) (*gtcv1alpha1.GRPCListener, string) {
+	if !hasNodeSelector(listener) {
+		return listener, ""
+	}
+
+	var (
+		selected = listener.DeepCopy()
+		matches  strings.Builder
+	)
+
+	matchNode := func(selector *gtcv1alpha1.NodeSelector) bool {
+		if selector == nil {
+			return true
+		}
+
+		ok := matchesNode(selector, node, client)
+		if ok {
+			matches.WriteByte('1')
+		} else {
+			matches.WriteByte('0')
+		}
+
+		return ok
+	}
+
+	for i, interceptor := range selected.Spec.Interceptors {
+		// Route and backend overrides need the filter of the listener, keep it disabled.
+		if !matchNode(interceptor.NodeSelector) {
+			selected.Spec.Interceptors[i] = gtcv1alpha1.Interceptor{Fault: &gtcv1alpha1.FaultInterceptor{}}
+		}
+	}
+
+	selected.Spec.Routes = selectRoutes(selected.Spec.Routes, matchNode)
+
+	for i := range selected.Spec.VirtualHosts {
+		selected.Spec.VirtualHosts[i].Routes = selectRoutes(selected.Spec.VirtualHosts[i].Routes, matchNode)
+	}
+
+	return selected, "nodeSelectors:" + matches.String()
+}
+
+// selectRoutes returns the routes selecting the node, without the route and backend interceptors not selecting it.
+func selectRoutes(routes []gtcv1alpha1.Route, matchNode func(*gtcv1alpha1.NodeSelector) bool) []gtcv1alpha1.Route {
+	selected := routes[:0]
+
+	for _, routeSpec := range routes {
+		if !matchNode(routeSpec.NodeSelector) {
+			continue
+		}
+
+		routeSpec.Interceptors = selectInterceptors(routeSpec.Interceptors, matchNode)
+
+		for i := range routeSpec.Backends {
+			routeSpec.Backends[i].Interceptors = selectInterceptors(routeSpec.Backends[i].Interceptors, matchNode)
+		}
+
+		selected = append(selected, routeSpec)
+	}
+
+	return selected
+}
+
+func selectInterceptors(interceptors []gtcv1alpha1.Interceptor, matchNode func(*gtcv1alpha1.NodeSelector) bool) []gtcv1alpha1.Interceptor {
+	selected := interceptors[:0]
+
+	for _, interceptor := range interceptors {
+		if matchNode(interceptor.NodeSelector) {
+			selected = append(selected, interceptor)
+		}
+	}
+
+	return selected
+}
+
+func hasNodeSelector(listener *gtcv1alpha1.GRPCListener) bool {
+	return len(listenerNodeSelectors(listener)) > 0
+}
+
+// hasLocalitySelector returns true if the listener selects clients by their locality.
+func hasLocalitySelector(listener *gtcv1alpha1.GRPCListener) bool {
+	for _, selector := range listenerNodeSelectors(listener) {
+		if selector.Locality != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// listenerNodeSelectors returns all the node selectors of a listener.
+func listenerNodeSelectors(listener *gtcv1alpha1.GRPCListener) []*gtcv1alpha1.NodeSelector {
+	var selectors []*gtcv1alpha1.NodeSelector
+
+	appendSelectors := func(interceptors []gtcv1alpha1.Interceptor) {
+		for _, interceptor := range interceptors {
+			if interceptor.NodeSelector != nil {
+				selectors = append(selectors, interceptor.NodeSelector)
+			}
+		}
+	}
+
+	appendSelectors(listener.Spec.Interceptors)
+
+	for _, routeSpec := range listener.Spec.AllRoutes() {
+		if routeSpec.NodeSelector != nil {
+			selectors = append(selectors, routeSpec.NodeSelector)
+		}
+
+		appendSelectors(routeSpec.Interceptors)
+
+		for _, backend := range routeSpec.Backends {
+			appendSelectors(backend.Interceptors)
+		}
+	}
+
+	return selectors
+}
+
+// matchesNode returns true if the node matches all the fields set on the selector.
+// The region and the zone of the client are taken from its resolved topology, its sub zone from the locality it sent.
+func matchesNode(selector *gtcv1alpha1.NodeSelector, node *core.Node, client topology) bool {
+	if selector.ID != "" && !matchesGlob(selector.ID, node.GetId()) {
+		return false
+	}
+
+	fields := node.GetMetadata().GetFields()
+
+	for key, value := range selector.Metadata {
+		field, ok := fields[key]
+		if !ok || field.GetStringValue() != value {
+			return false
+		}
+	}
+
+	if locality := selector.Locality; locality != nil {
+		if !matchesLocalityField(locality.Region, client.region) ||
+			!matchesLocalityField(locality.Zone, client.zone) ||
+			!matchesLocalityField(locality.SubZone, node.GetLocality().GetSubZone()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchesLocalityField(want, got string) bool {
+	return want == "" || want == got
+}
+
+// matchesGlob matches a whole value against a glob, where `*` matches any sequence of characters, `/` included.
+func matchesGlob(glob, value string) bool {
+	prefix, rest, wildcard := strings.Cut(glob, "*")
+	if !wildcard {
+		return glob == value
+	}
+
+	if !strings.HasPrefix(value, prefix) {
+		return false
+	}
+
+	value = value[len(prefix):]
+
+	for {
+		part, next, wildcard := strings.Cut(rest, "*")
+		if !wildcard {
+			// The last part must end the value.
+			return strings.HasSuffix(value, part)
+		}
+
+		// Matching the leftmost occurrence of each part leaves the most room to the next ones.
+		i := strings.Index(value, part)
+		if i < 0 {
+			return false
+		}
+
+		value, rest = value[i+len(part):], next
+	}
+}
@@ -2,28 +2,55 @@ package gtc
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	resourcesv3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	gtcv1alpha1 "github.com/jlevesy/grpc-traffic-controller/api/gtc/v1alpha1"
+	gtcclientset "github.com/jlevesy/grpc-traffic-controller/client/clientset/versioned"
 	gtclisters "github.com/jlevesy/grpc-traffic-controller/client/listers/gtc/v1alpha1"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	kdiscoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
 )
 
 type grpcListenerChangedHandler struct {
 	watches *watches
+	status  *listenerStatus
 	logger  *zap.Logger
 }
 
 func (h *grpcListenerChangedHandler) OnAdd(ctx context.Context, obj any) error {
-	return h.handle(ctx, obj)
+	if err := h.handle(ctx, obj); err != nil {
+		return err
+	}
+
+	return h.updateStatus(ctx, obj)
 }
 
 func (h *grpcListenerChangedHandler) OnUpdate(ctx context.Context, oldObj, newObj any) error {
+	// Status updates are written by this handler, they don't change the listener resources.
+	if statusOnlyUpdate(oldObj, newObj) {
+		return nil
+	}
+
 	// TODO(jly) be clever and detect changes if it makes sense.
-	return h.handle(ctx, newObj)
+	// Names and backends dropped by the update must be notified as well, for their subscribers to stop using them.
+	if err := h.handle(ctx, oldObj); err != nil {
+		return err
+	}
+
+	if err := h.handle(ctx, newObj); err != nil {
+		return err
+	}
+
+	return h.updateStatus(ctx, newObj)
 }
 
 func (h *grpcListenerChangedHandler) OnDelete(ctx context.Context, obj any) error {
@@ -43,52 +70,322 @@ func (h *grpcListenerChangedHandler) handle(ctx context.Context, obj any) error
 		zap.String("grcp_listener_name", lis.GetName()),
 	)
 
-	h.watches.notifyChanged(
-		ctx,
-		resourceRef{
-			typeURL:      resourcesv3.ListenerType,
-			resourceName: listenerName(lis.GetNamespace(), lis.GetName()),
-		},
-	)
+	notifyListenerChanged(ctx, h.watches, lis)
 
-	for routeID, route := range lis.Spec.Routes {
-		for backendID := range route.Backends {
-			h.watches.notifyChanged(
-				ctx,
-				resourceRef{
-					typeURL: resourcesv3.ClusterType,
-					resourceName: backendName(
-						lis.GetNamespace(),
-						lis.GetName(),
-						routeID,
-						backendID,
-					),
-				},
-			)
+	return nil
+}
 
-			h.watches.notifyChanged(
-				ctx,
-				resourceRef{
-					typeURL: resourcesv3.EndpointType,
-					resourceName: backendName(
-						lis.GetNamespace(),
-						lis.GetName(),
-						routeID,
-						backendID,
-					),
-				},
-			)
+func (h *grpcListenerChangedHandler) updateStatus(ctx context.Context, obj any) error {
+	lis, ok := obj.(*gtcv1alpha1.GRPCListener)
+	if !ok {
+		return nil
+	}
+
+	return h.status.update(ctx, lis)
+}
+
+// statusOnlyUpdate returns true if only the status of a listener changed.
+func statusOnlyUpdate(oldObj, newObj any) bool {
+	oldLis, ok := oldObj.(*gtcv1alpha1.GRPCListener)
+	if !ok {
+		return false
+	}
+
+	newLis, ok := newObj.(*gtcv1alpha1.GRPCListener)
+	if !ok {
+		return false
+	}
+
+	return equality.Semantic.DeepEqual(oldLis.Spec, newLis.Spec) &&
+		equality.Semantic.DeepEqual(oldLis.Annotations, newLis.Annotations) &&
+		!equality.Semantic.DeepEqual(oldLis.Status, newLis.Status)
+}
+
+type referenceGrantChangedHandler struct {
+	watches *watches
+	status  *listenerStatus
+	logger  *zap.Logger
+
+	listenersLister gtclisters.GRPCListenerLister
+}
+
+func (h *referenceGrantChangedHandler) OnAdd(ctx context.Context, obj any) error {
+	return h.handle(ctx, obj)
+}
+
+func (h *referenceGrantChangedHandler) OnUpdate(ctx context.Context, oldObj, newObj any) error {
+	return h.handle(ctx, newObj)
+}
+
+func (h *referenceGrantChangedHandler) OnDelete(ctx context.Context, obj any) error {
+	return h.handle(ctx, obj)
+}
+
+func (h *referenceGrantChangedHandler) handle(ctx context.Context, obj any) error {
+	grant, ok := obj.(*gtcv1alpha1.ReferenceGrant)
+	if !ok {
+		h.logger.Error("Invalid object type, expected a ReferenceGrant")
+		return nil
+	}
+
+	listeners, err := h.listenersLister.List(labels.Everything())
+	if err != nil {
+		h.logger.Error("Could not list gRPC listeners", zap.Error(err))
+		return err
+	}
+
+	for _, lis := range listeners {
+		if !referencesNamespace(lis, grant.GetNamespace()) {
+			continue
+		}
+
+		h.logger.Debug(
+			"Reference grant changed",
+			zap.String("grpc_listener_namespace", lis.GetNamespace()),
+			zap.String("grpc_listener_name", lis.GetName()),
+			zap.String("reference_grant_namespace", grant.GetNamespace()),
+			zap.String("reference_grant_name", grant.GetName()),
+		)
+
+		notifyListenerChanged(ctx, h.watches, lis)
+
+		if err := h.status.update(ctx, lis); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// faultExperimentChangedHandler notifies the listeners targeted by FaultExperiments and keeps their phase up to date,
+// handling each experiment again when it starts and when it expires.
+type faultExperimentChangedHandler struct {
+	watches *watches
+	logger  *zap.Logger
+
+	listenersLister   gtclisters.GRPCListenerLister
+	experimentsLister gtclisters.FaultExperimentLister
+	// client writes the status of the experiments, which is left untouched if nil.
+	client gtcclientset.Interface
+	// requeueAfter enqueues the key of an experiment to be handled again after the given delay.
+	requeueAfter func(key string, delay time.Duration)
+
+	mu sync.Mutex
+	// scheduled holds the time each experiment is enqueued to be handled again at, by key.
+	scheduled map[string]time.Time
+}
+
+func (h *faultExperimentChangedHandler) OnAdd(ctx context.Context, obj any) error {
+	experiment, ok := obj.(*gtcv1alpha1.FaultExperiment)
+	if !ok {
+		h.logger.Error("Invalid object type, expected a FaultExperiment")
+		return nil
+	}
+
+	return h.handle(ctx, experiment)
+}
+
+func (h *faultExperimentChangedHandler) OnUpdate(ctx context.Context, oldObj, newObj any) error {
+	oldExperiment, ok := oldObj.(*gtcv1alpha1.FaultExperiment)
+	if !ok {
+		h.logger.Error("Invalid object type, expected a FaultExperiment")
+		return nil
+	}
+
+	newExperiment, ok := newObj.(*gtcv1alpha1.FaultExperiment)
+	if !ok {
+		h.logger.Error("Invalid object type, expected a FaultExperiment")
+		return nil
+	}
+
+	// Status updates are written by this handler, they don't change the faults.
+	if equality.Semantic.DeepEqual(oldExperiment.Spec, newExperiment.Spec) {
+		return nil
+	}
+
+	// The listener previously targeted must drop the fault.
+	if oldExperiment.Spec.Target.Listener != newExperiment.Spec.Target.Listener {
+		h.notifyTarget(ctx, oldExperiment)
+	}
+
+	return h.handle(ctx, newExperiment)
+}
+
+func (h *faultExperimentChangedHandler) OnDelete(ctx context.Context, obj any) error {
+	experiment, ok := obj.(*gtcv1alpha1.FaultExperiment)
+	if !ok {
+		h.logger.Error("Invalid object type, expected a FaultExperiment")
+		return nil
+	}
+
+	h.notifyTarget(ctx, experiment)
+
+	return nil
+}
+
+// OnRequeue handles an experiment again once its phase changes.
+func (h *faultExperimentChangedHandler) OnRequeue(ctx context.Context, key string) error {
+	h.mu.Lock()
+	delete(h.scheduled, key)
+	h.mu.Unlock()
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		h.logger.Error("Invalid fault experiment key", zap.Error(err))
+		return nil
+	}
+
+	experiment, err := h.experimentsLister.FaultExperiments(namespace).Get(name)
+	switch {
+	case kerrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		h.logger.Error("Could not get fault experiment", zap.Error(err))
+		return err
+	}
+
+	return h.handle(ctx, experiment)
+}
+
+func (h *faultExperimentChangedHandler) handle(ctx context.Context, experiment *gtcv1alpha1.FaultExperiment) error {
+	h.notifyTarget(ctx, experiment)
+
+	phase, next := faultExperimentPhase(experiment, time.Now())
+	if !next.IsZero() {
+		h.schedule(experiment, next)
+	}
+
+	var message string
+
+	if phase != gtcv1alpha1.FaultExperimentPhaseCompleted {
+		if err := h.validate(experiment); err != nil {
+			phase, message = gtcv1alpha1.FaultExperimentPhaseInvalid, err.Error()
 		}
+	}
+
+	if h.client == nil || (experiment.Status.Phase == phase && experiment.Status.Message == message) {
+		return nil
+	}
 
+	h.logger.Debug(
+		"Fault experiment phase changed",
+		zap.String("fault_experiment_namespace", experiment.GetNamespace()),
+		zap.String("fault_experiment_name", experiment.GetName()),
+		zap.String("phase", phase),
+		zap.String("message", message),
+	)
+
+	experiment = experiment.DeepCopy()
+	experiment.Status.Phase = phase
+	experiment.Status.Message = message
+
+	_, err := h.client.ApiV1alpha1().FaultExperiments(experiment.Namespace).UpdateStatus(ctx, experiment, metav1.UpdateOptions{})
+	if err != nil {
+		h.logger.Error("Could not update fault experiment status", zap.Error(err))
+		return err
 	}
 
 	return nil
 }
 
+// schedule enqueues the experiment to be handled again at the given time, unless it already is.
+func (h *faultExperimentChangedHandler) schedule(experiment *gtcv1alpha1.FaultExperiment, at time.Time) {
+	key, err := cache.MetaNamespaceKeyFunc(experiment)
+	if err != nil {
+		h.logger.Error("Could not get fault experiment key", zap.Error(err))
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if scheduledAt, ok := h.scheduled[key]; ok && scheduledAt.Equal(at) {
+		return
+	}
+
+	h.scheduled[key] = at
+	h.requeueAfter(key, time.Until(at))
+}
+
+// validate returns an error if the experiment can't be applied to its listener. Experiments targeting a missing listener
+// are not validated, as the listener could be created later on.
+func (h *faultExperimentChangedHandler) validate(experiment *gtcv1alpha1.FaultExperiment) error {
+	lis, err := h.listenersLister.GRPCListeners(experiment.Namespace).Get(experiment.Spec.Target.Listener)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			h.logger.Error("Could not get gRPC listener", zap.Error(err))
+		}
+
+		return nil
+	}
+
+	return validateFaultExperiment(lis, experiment)
+}
+
+func (h *faultExperimentChangedHandler) notifyTarget(ctx context.Context, experiment *gtcv1alpha1.FaultExperiment) {
+	lis, err := h.listenersLister.GRPCListeners(experiment.Namespace).Get(experiment.Spec.Target.Listener)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			h.logger.Error("Could not get gRPC listener", zap.Error(err))
+		}
+
+		return
+	}
+
+	h.logger.Debug(
+		"Fault experiment changed",
+		zap.String("grpc_listener_namespace", lis.GetNamespace()),
+		zap.String("grpc_listener_name", lis.GetName()),
+		zap.String("fault_experiment_name", experiment.GetName()),
+	)
+
+	// Faults are only carried by the listener resources, clusters are left untouched.
+	notifyListenerNames(ctx, h.watches, lis)
+}
+
+// notifyListenerChanged notifies watchers of all the xDS resources derived from a listener.
+func notifyListenerChanged(ctx context.Context, watches *watches, lis *gtcv1alpha1.GRPCListener) {
+	notifyListenerNames(ctx, watches, lis)
+
+	for _, backend := range listenerBackends(lis) {
+		watches.notifyChanged(
+			ctx,
+			resourceRef{
+				typeURL:      resourcesv3.ClusterType,
+				resourceName: backend.name,
+			},
+		)
+
+		watches.notifyChanged(
+			ctx,
+			resourceRef{
+				typeURL:      resourcesv3.EndpointType,
+				resourceName: backend.name,
+			},
+		)
+	}
+}
+
+// notifyListenerNames notifies the listener resources of all the names of a listener.
+func notifyListenerNames(ctx context.Context, watches *watches, lis *gtcv1alpha1.GRPCListener) {
+	for _, resourceName := range listenerResourceNames(lis) {
+		watches.notifyChanged(
+			ctx,
+			resourceRef{
+				typeURL:      resourcesv3.ListenerType,
+				resourceName: resourceName,
+			},
+		)
+	}
+}
+
 type endpointSliceChangedHandler struct {
 	watches *watches
 	logger  *zap.Logger
 
+	// cluster is the remote cluster the endpoint slices come from, empty for the local cluster.
+	cluster string
+
 	listenersLister gtclisters.GRPCListenerLister
 }
 
@@ -98,6 +395,11 @@ func (h *endpointSliceChangedHandler) OnAdd(ctx context.Context, obj any) error
 
 func (h *endpointSliceChangedHandler) OnUpdate(ctx context.Context, oldObj, newObj any) error {
 	// TODO(jly) be clever and detect changes if it makes sense.
+	// Names and backends dropped by the update must be notified as well, for their subscribers to stop using them.
+	if err := h.handle(ctx, oldObj); err != nil {
+		return err
+	}
+
 	return h.handle(ctx, newObj)
 }
 
@@ -121,30 +423,24 @@ func (h *endpointSliceChangedHandler) handle(ctx context.Context, obj any) error
 	// O(n) accross all services isn't good. Yet that's the price of maintaining cross namespace localities.
 	// Dropping this feature would allow us to narrow down the list of services to lookup by namespace.
 	for _, lis := range listeners {
-		for routeID, route := range lis.Spec.Routes {
-			for backendID, backend := range route.Backends {
-				if matchesBackend(objMeta, lis, backend) {
-					h.logger.Debug(
-						"Endpoint changed",
-						zap.String("grpc_listener_namespace", lis.GetNamespace()),
-						zap.String("grpc_listener_name", lis.GetName()),
-						zap.String("endpoint_name", objMeta.GetName()),
-						zap.String("endpoint_namespace", objMeta.GetNamespace()),
-					)
-
-					h.watches.notifyChanged(
-						ctx,
-						resourceRef{
-							typeURL: resourcesv3.EndpointType,
-							resourceName: backendName(
-								lis.GetNamespace(),
-								lis.GetName(),
-								routeID,
-								backendID,
-							),
-						},
-					)
-				}
+		for _, backend := range listenerBackends(lis) {
+			if matchesBackend(objMeta, h.cluster, lis, backend.spec) {
+				h.logger.Debug(
+					"Endpoint changed",
+					zap.String("grpc_listener_namespace", lis.GetNamespace()),
+					zap.String("grpc_listener_name", lis.GetName()),
+					zap.String("endpoint_name", objMeta.GetName()),
+					zap.String("endpoint_namespace", objMeta.GetNamespace()),
+					zap.String("endpoint_cluster", h.cluster),
+				)
+
+				h.watches.notifyChanged(
+					ctx,
+					resourceRef{
+						typeURL:      resourcesv3.EndpointType,
+						resourceName: backend.name,
+					},
+				)
 			}
 		}
 	}
@@ -152,13 +448,15 @@ func (h *endpointSliceChangedHandler) handle(ctx context.Context, obj any) error
 	return nil
 }
 
-func matchesBackend(epSlice metav1.Object, listener *gtcv1alpha1.GRPCListener, backend gtcv1alpha1.Backend) bool {
+func matchesBackend(epSlice metav1.Object, cluster string, listener *gtcv1alpha1.GRPCListener, backend gtcv1alpha1.Backend) bool {
 	switch {
 	case backend.Service != nil:
-		return matchesService(epSlice, listener, backend.Service)
+		return matchesService(epSlice, cluster, listener, kdiscoveryv1.LabelServiceName, backend.Service)
+	case backend.ServiceImport != nil:
+		return matchesService(epSlice, cluster, listener, labelMCSServiceName, &backend.ServiceImport.ServiceRef)
 	case len(backend.Localities) > 0:
 		for _, loc := range backend.Localities {
-			if matchesService(epSlice, listener, loc.Service) {
+			if matchesService(epSlice, cluster, listener, kdiscoveryv1.LabelServiceName, loc.Service) {
 				return true
 			}
 		}
@@ -169,12 +467,228 @@ func matchesBackend(epSlice metav1.Object, listener *gtcv1alpha1.GRPCListener, b
 	}
 }
 
-func matchesService(epSlice metav1.Object, listener *gtcv1alpha1.GRPCListener, serviceRef *gtcv1alpha1.ServiceRef) bool {
+// matchesService returns true if the endpoint slice of the given cluster belongs to the referenced service, its name being held by the given label.
+func matchesService(epSlice metav1.Object, cluster string, listener *gtcv1alpha1.GRPCListener, serviceNameLabel string, serviceRef *gtcv1alpha1.ServiceRef) bool {
+	if serviceRef.Cluster != cluster {
+		return false
+	}
+
 	// If the name doesn't match then we're out.
-	if svcName := epSlice.GetLabels()["kubernetes.io/service-name"]; svcName != serviceRef.Name {
+	if svcName := epSlice.GetLabels()[serviceNameLabel]; svcName != serviceRef.Name {
 		return false
 	}
 
 	// If we don't have a specific namespace, then we match looking at the XDS service namespace.
 	return listener.Namespace == epSlice.GetNamespace() || (serviceRef.Namespace != "" && serviceRef.Namespace == epSlice.GetNamespace())
 }
+
+type podChangedHandler struct {
+	watches *watches
+	logger  *zap.Logger
+
+	listenersLister gtclisters.GRPCListenerLister
+}
+
+func (h *podChangedHandler) OnAdd(ctx context.Context, obj any) error {
+	return h.handle(ctx, obj)
+}
+
+func (h *podChangedHandler) OnUpdate(ctx context.Context, oldObj, newObj any) error {
+	oldPod, ok := oldObj.(*corev1.Pod)
+	if !ok {
+		h.logger.Error("Invalid object type, expected a Pod")
+		return nil
+	}
+
+	newPod, ok := newObj.(*corev1.Pod)
+	if !ok {
+		h.logger.Error("Invalid object type, expected a Pod")
+		return nil
+	}
+
+	// Pods status is updated very often, yet only a few fields are read to resolve endpoints.
+	if !podChanged(oldPod, newPod) {
+		return nil
+	}
+
+	return h.handle(ctx, oldPod, newPod)
+}
+
+func (h *podChangedHandler) OnDelete(ctx context.Context, obj any) error {
+	return h.handle(ctx, obj)
+}
+
+// handle notifies the backends selecting any of the given states of a pod.
+func (h *podChangedHandler) handle(ctx context.Context, objs ...any) error {
+	pods := make([]*corev1.Pod, 0, len(objs))
+
+	for _, obj := range objs {
+		pod, ok := obj.(*corev1.Pod)
+		if !ok {
+			h.logger.Error("Invalid object type, expected a Pod")
+			return nil
+		}
+
+		pods = append(pods, pod)
+	}
+
+	listeners, err := h.listenersLister.List(labels.Everything())
+	if err != nil {
+		h.logger.Error("Could not list gRPC listeners", zap.Error(err))
+		return err
+	}
+
+	for _, lis := range listeners {
+		for _, backend := range listenerBackends(lis) {
+			if !backendSelectsAnyPod(lis, backend.spec, pods) {
+				continue
+			}
+
+			h.logger.Debug(
+				"Pod changed",
+				zap.String("grpc_listener_namespace", lis.GetNamespace()),
+				zap.String("grpc_listener_name", lis.GetName()),
+				zap.String("pod_name", pods[0].GetName()),
+				zap.String("pod_namespace", pods[0].GetNamespace()),
+			)
+
+			h.watches.notifyChanged(
+				ctx,
+				resourceRef{
+					typeURL:      resourcesv3.EndpointType,
+					resourceName: backend.name,
+				},
+			)
+		}
+	}
+
+	return nil
+}
+
+// podChanged returns true if a pod update changes any of the fields read to resolve endpoints:
+// its labels and annotations, which hold its weight and its hash key, its node, its addresses and its health.
+func podChanged(oldPod, newPod *corev1.Pod) bool {
+	return !equality.Semantic.DeepEqual(oldPod.Labels, newPod.Labels) ||
+		!equality.Semantic.DeepEqual(oldPod.Annotations, newPod.Annotations) ||
+		oldPod.Spec.NodeName != newPod.Spec.NodeName ||
+		!equality.Semantic.DeepEqual(oldPod.Status.PodIPs, newPod.Status.PodIPs) ||
+		oldPod.Status.Phase != newPod.Status.Phase ||
+		podReady(oldPod) != podReady(newPod) ||
+		(oldPod.DeletionTimestamp == nil) != (newPod.DeletionTimestamp == nil)
+}
+
+// backendSelectsAnyPod returns true if a backend could select any of the given pods.
+// Pods of a service backend without subset can't be told apart from the other pods of its namespace,
+// as the selector of the service is unknown.
+func backendSelectsAnyPod(listener *gtcv1alpha1.GRPCListener, backend gtcv1alpha1.Backend, pods []*corev1.Pod) bool {
+	for _, pod := range pods {
+		if !backendInNamespace(listener, backend, pod.Namespace) {
+			continue
+		}
+
+		var selector *metav1.LabelSelector
+
+		switch {
+		case backend.Pods != nil:
+			selector = &backend.Pods.Selector
+		case backend.Subset != nil:
+			selector = backend.Subset
+		default:
+			return true
+		}
+
+		// An invalid selector fails to resolve anyway, notify it to be safe.
+		sel, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil || sel.Matches(labels.Set(pod.Labels)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type nodeChangedHandler struct {
+	watches *watches
+	logger  *zap.Logger
+
+	listenersLister gtclisters.GRPCListenerLister
+}
+
+func (h *nodeChangedHandler) OnAdd(ctx context.Context, obj any) error {
+	return h.handle(ctx, obj)
+}
+
+func (h *nodeChangedHandler) OnUpdate(ctx context.Context, oldObj, newObj any) error {
+	oldMeta, err := apimeta.Accessor(oldObj)
+	if err != nil {
+		h.logger.Error("Could not convert object meta", zap.Error(err))
+		return err
+	}
+
+	newMeta, err := apimeta.Accessor(newObj)
+	if err != nil {
+		h.logger.Error("Could not convert object meta", zap.Error(err))
+		return err
+	}
+
+	// Nodes are updated very often, yet only their topology matters.
+	if !topologyChanged(oldMeta.GetLabels(), newMeta.GetLabels()) {
+		return nil
+	}
+
+	return h.handle(ctx, newObj)
+}
+
+func (h *nodeChangedHandler) OnDelete(ctx context.Context, obj any) error {
+	return h.handle(ctx, obj)
+}
+
+func (h *nodeChangedHandler) handle(ctx context.Context, obj any) error {
+	objMeta, err := apimeta.Accessor(obj)
+	if err != nil {
+		h.logger.Error("Could not convert object meta", zap.Error(err))
+		return err
+	}
+
+	listeners, err := h.listenersLister.List(labels.Everything())
+	if err != nil {
+		h.logger.Error("Could not list gRPC listeners", zap.Error(err))
+		return err
+	}
+
+	for _, lis := range listeners {
+		// Listeners select clients by the topology of their node.
+		if hasLocalitySelector(lis) {
+			notifyListenerNames(ctx, h.watches, lis)
+		}
+
+		for _, backend := range listenerBackends(lis) {
+			// Only those backends are prioritized by topology.
+			if backend.spec.Pods == nil && backend.spec.Service == nil && backend.spec.ServiceImport == nil {
+				continue
+			}
+
+			h.logger.Debug(
+				"Node changed",
+				zap.String("grpc_listener_namespace", lis.GetNamespace()),
+				zap.String("grpc_listener_name", lis.GetName()),
+				zap.String("node_name", objMeta.GetName()),
+			)
+
+			h.watches.notifyChanged(
+				ctx,
+				resourceRef{
+					typeURL:      resourcesv3.EndpointType,
+					resourceName: backend.name,
+				},
+			)
+		}
+	}
+
+	return nil
+}
+
+func topologyChanged(oldLabels, newLabels map[string]string) bool {
+	return oldLabels[corev1.LabelTopologyRegion] != newLabels[corev1.LabelTopologyRegion] ||
+		oldLabels[corev1.LabelTopologyZone] != newLabels[corev1.LabelTopologyZone]
+}
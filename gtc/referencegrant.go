@@ -0,0 +1,305 @@
+package gtc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	gtcv1alpha1 "github.com/jlevesy/grpc-traffic-controller/api/gtc/v1alpha1"
+	gtcclientset "github.com/jlevesy/grpc-traffic-controller/client/clientset/versioned"
+	gtclisters "github.com/jlevesy/grpc-traffic-controller/client/listers/gtc/v1alpha1"
+	"go.uber.org/zap"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/retry"
+)
+
+// reference is a kubernetes resource referenced by a backend.
+type reference struct {
+	kind      string
+	namespace string
+	// name is empty for pods, as they are selected by labels.
+	name string
+	// cluster is the remote cluster of the resource, empty for the local cluster.
+	cluster string
+}
+
+func (r reference) String() string {
+	name := r.namespace
+	if r.name != "" {
+		name += "/" + r.name
+	}
+
+	if r.cluster != "" {
+		name += " in cluster " + r.cluster
+	}
+
+	return r.kind + " " + name
+}
+
+func serviceReference(listener *gtcv1alpha1.GRPCListener, serviceRef gtcv1alpha1.ServiceRef) reference {
+	return reference{
+		kind:      gtcv1alpha1.ReferenceKindService,
+		namespace: namespaceOrDefault(serviceRef.Namespace, listener.Namespace),
+		name:      serviceRef.Name,
+		cluster:   serviceRef.Cluster,
+	}
+}
+
+func serviceImportReference(listener *gtcv1alpha1.GRPCListener, serviceImportRef gtcv1alpha1.ServiceImportRef) reference {
+	return reference{
+		kind:      gtcv1alpha1.ReferenceKindServiceImport,
+		namespace: namespaceOrDefault(serviceImportRef.Namespace, listener.Namespace),
+		name:      serviceImportRef.Name,
+		cluster:   serviceImportRef.Cluster,
+	}
+}
+
+func podsReference(listener *gtcv1alpha1.GRPCListener, podsRef gtcv1alpha1.PodsRef) reference {
+	return reference{
+		kind:      gtcv1alpha1.ReferenceKindPod,
+		namespace: namespaceOrDefault(podsRef.Namespace, listener.Namespace),
+	}
+}
+
+// backendReferences returns all the resources referenced by a backend.
+func backendReferences(listener *gtcv1alpha1.GRPCListener, backend gtcv1alpha1.Backend) []reference {
+	switch {
+	case backend.Service != nil:
+		return []reference{serviceReference(listener, *backend.Service)}
+	case backend.Pods != nil:
+		return []reference{podsReference(listener, *backend.Pods)}
+	case backend.ServiceImport != nil:
+		return []reference{serviceImportReference(listener, *backend.ServiceImport)}
+	}
+
+	refs := make([]reference, 0, len(backend.Localities))
+
+	for _, loc := range backend.Localities {
+		if loc.Service != nil {
+			refs = append(refs, serviceReference(listener, *loc.Service))
+		}
+	}
+
+	return refs
+}
+
+// referenceGrants tells if a GRPCListener is allowed to reference a resource of another namespace.
+type referenceGrants struct {
+	lister gtclisters.ReferenceGrantLister
+}
+
+// allows returns true if the listener can reference the given resource, alongside the versions of the
+// ReferenceGrants taken into account.
+func (g referenceGrants) allows(listener *gtcv1alpha1.GRPCListener, ref reference) (bool, []string, error) {
+	if ref.namespace == listener.Namespace {
+		return true, nil, nil
+	}
+
+	grants, err := g.lister.ReferenceGrants(ref.namespace).List(labels.Everything())
+	if err != nil {
+		return false, nil, err
+	}
+
+	var (
+		allowed  bool
+		versions = make([]string, len(grants))
+	)
+
+	for i, grant := range grants {
+		versions[i] = grant.ResourceVersion
+		allowed = allowed || grantsReference(grant, listener.Namespace, ref)
+	}
+
+	return allowed, versions, nil
+}
+
+// notPermitted returns, sorted, the resources referenced by the backends of a listener that no ReferenceGrant allows.
+func (g referenceGrants) notPermitted(listener *gtcv1alpha1.GRPCListener) ([]string, error) {
+	seen := make(map[reference]struct{})
+
+	var refs []string
+
+	for _, backend := range listenerBackends(listener) {
+		for _, ref := range backendReferences(listener, backend.spec) {
+			if _, ok := seen[ref]; ok {
+				continue
+			}
+
+			seen[ref] = struct{}{}
+
+			allowed, _, err := g.allows(listener, ref)
+			if err != nil {
+				return nil, err
+			}
+
+			if !allowed {
+				refs = append(refs, ref.String())
+			}
+		}
+	}
+
+	sort.Strings(refs)
+
+	return refs, nil
+}
+
+func grantsReference(grant *gtcv1alpha1.ReferenceGrant, fromNamespace string, ref reference) bool {
+	var fromAllowed bool
+
+	for _, from := range grant.Spec.From {
+		if from.Namespace == fromNamespace {
+			fromAllowed = true
+			break
+		}
+	}
+
+	if !fromAllowed {
+		return false
+	}
+
+	for _, to := range grant.Spec.To {
+		kind := to.Kind
+		if kind == "" {
+			kind = gtcv1alpha1.ReferenceKindService
+		}
+
+		if kind == ref.kind && (to.Name == "" || to.Name == ref.name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// referencesNamespace returns true if the listener references a resource of the given namespace other than its own.
+func referencesNamespace(listener *gtcv1alpha1.GRPCListener, namespace string) bool {
+	if listener.Namespace == namespace {
+		return false
+	}
+
+	for _, backend := range listenerBackends(listener) {
+		if backendInNamespace(listener, backend.spec, namespace) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backendInNamespace returns true if a backend references a resource of the given namespace.
+func backendInNamespace(listener *gtcv1alpha1.GRPCListener, backend gtcv1alpha1.Backend, namespace string) bool {
+	for _, ref := range backendReferences(listener, backend) {
+		if ref.namespace == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
+// listenerStatus keeps the ResolvedRefs condition of GRPCListeners up to date.
+type listenerStatus struct {
+	referenceGrants referenceGrants
+	// client writes the status of the listeners, which is left untouched if nil.
+	client gtcclientset.Interface
+	logger *zap.Logger
+}
+
+func (s *listenerStatus) update(ctx context.Context, lis *gtcv1alpha1.GRPCListener) error {
+	if s.client == nil {
+		return nil
+	}
+
+	condition, err := s.resolvedRefsCondition(lis)
+	if err != nil {
+		s.logger.Error("Could not resolve gRPC listener references", zap.Error(err))
+		return err
+	}
+
+	if conditionUpToDate(lis, condition) {
+		return nil
+	}
+
+	if condition.Status == metav1.ConditionFalse {
+		s.logger.Info(
+			"gRPC listener references resources it is not permitted to",
+			zap.String("grpc_listener_namespace", lis.GetNamespace()),
+			zap.String("grpc_listener_name", lis.GetName()),
+			zap.String("message", condition.Message),
+		)
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := s.client.ApiV1alpha1().GRPCListeners(lis.Namespace).Get(ctx, lis.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		condition.ObservedGeneration = current.Generation
+		if conditionUpToDate(current, condition) {
+			return nil
+		}
+
+		apimeta.SetStatusCondition(&current.Status.Conditions, condition)
+
+		_, err = s.client.ApiV1alpha1().GRPCListeners(current.Namespace).UpdateStatus(ctx, current, metav1.UpdateOptions{})
+		return err
+	})
+
+	switch {
+	case kerrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		s.logger.Error("Could not update gRPC listener status", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *listenerStatus) resolvedRefsCondition(lis *gtcv1alpha1.GRPCListener) (metav1.Condition, error) {
+	refs, err := s.referenceGrants.notPermitted(lis)
+	if err != nil {
+		return metav1.Condition{}, err
+	}
+
+	if len(refs) > 0 {
+		return metav1.Condition{
+			Type:               gtcv1alpha1.GRPCListenerConditionResolvedRefs,
+			Status:             metav1.ConditionFalse,
+			Reason:             gtcv1alpha1.GRPCListenerReasonRefNotPermitted,
+			Message:            fmt.Sprintf("No ReferenceGrant allows to reference %s", strings.Join(refs, ", ")),
+			ObservedGeneration: lis.Generation,
+		}, nil
+	}
+
+	return metav1.Condition{
+		Type:               gtcv1alpha1.GRPCListenerConditionResolvedRefs,
+		Status:             metav1.ConditionTrue,
+		Reason:             gtcv1alpha1.GRPCListenerReasonResolvedRefs,
+		ObservedGeneration: lis.Generation,
+	}, nil
+}
+
+// conditionUpToDate returns true if the listener already carries the given condition.
+func conditionUpToDate(lis *gtcv1alpha1.GRPCListener, condition metav1.Condition) bool {
+	current := apimeta.FindStatusCondition(lis.Status.Conditions, condition.Type)
+
+	return current != nil &&
+		current.Status == condition.Status &&
+		current.Reason == condition.Reason &&
+		current.Message == condition.Message &&
+		current.ObservedGeneration == condition.ObservedGeneration
+}
+
+func namespaceOrDefault(namespace, defaultNamespace string) string {
+	if namespace == "" {
+		return defaultNamespace
+	}
+
+	return namespace
+}
@@ -1,10 +1,15 @@
 package gtc
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"path"
 	"strconv"
 	"strings"
+
+	gtcv1alpha1 "github.com/jlevesy/grpc-traffic-controller/api/gtc/v1alpha1"
 )
 
 func routeConfigName(namespace, name string) string {
@@ -15,51 +20,145 @@ func vHostName(namespace, name string) string {
 	return path.Join(namespace, name, "vhost")
 }
 
-func backendName(namespace, name string, routeID, backendID int) string {
+func indexedVHostName(namespace, name string, vhostID int) string {
+	return path.Join(namespace, name, "vhost", strconv.Itoa(vhostID))
+}
+
+// backendName returns the name of the cluster of a backend, derived from a hash of its spec so that identical backends
+// of a listener share the same cluster, whatever their route. The weight and the interceptors of a backend are left out,
+// as they are set by routes.
+func backendName(namespace, name string, backend gtcv1alpha1.Backend) string {
 	return path.Join(
 		namespace,
 		name,
-		"route",
-		strconv.Itoa(routeID),
 		"backend",
-		strconv.Itoa(backendID),
+		backendHash(backend),
 	)
 }
 
-// namespace/name/route/<route_id>/backend/<backend_id>
+func backendHash(backend gtcv1alpha1.Backend) string {
+	backend.Weight = 0
+	backend.Interceptors = nil
+
+	// Marshaling a struct can't fail, and map keys are sorted.
+	rawBackend, _ := json.Marshal(backend)
+	sum := sha256.Sum256(rawBackend)
+
+	return hex.EncodeToString(sum[:backendHashSize])
+}
+
+const backendHashSize = 8
+
+// routeBackendNames returns the names of the clusters of the backends of a route. Copies of a backend repeated within
+// a route can't share its cluster if their interceptors differ, each copy is given a cluster of its own. Copies are told
+// apart by their position in the route, as the interceptors of a backend are patched by experiments and node selectors.
+func routeBackendNames(namespace, name string, backends []gtcv1alpha1.Backend) []string {
+	var (
+		names  = make([]string, len(backends))
+		copies = make(map[string]int, len(backends))
+	)
+
+	for i, backend := range backends {
+		shared := backendName(namespace, name, backend)
+
+		names[i] = shared
+		if n := copies[shared]; n > 0 {
+			names[i] = shared + "-" + strconv.Itoa(n)
+		}
+
+		copies[shared]++
+	}
+
+	return names
+}
+
+// targetName returns the name of the cluster of a route lookup target, as answered by the lookup service.
+func targetName(namespace, name, target string) string {
+	return path.Join(
+		namespace,
+		name,
+		"target",
+		target,
+	)
+}
+
+// routeLookupName returns the name of the cluster specifier plugin of a route lookup, derived from a hash of its config.
+func routeLookupName(namespace, name string, rawConfig []byte) string {
+	sum := sha256.Sum256(rawConfig)
+
+	return path.Join(
+		namespace,
+		name,
+		"route-lookup",
+		hex.EncodeToString(sum[:backendHashSize]),
+	)
+}
+
+// listenerBackend is a backend of a listener, along with the name of its cluster.
+type listenerBackend struct {
+	name string
+	spec gtcv1alpha1.Backend
+}
+
+// listenerBackends returns the backends of all the routes of a listener, route lookup targets included.
+func listenerBackends(listener *gtcv1alpha1.GRPCListener) []listenerBackend {
+	var backends []listenerBackend
+
+	for _, route := range listener.Spec.AllRoutes() {
+		names := routeBackendNames(listener.Namespace, listener.Name, route.Backends)
+
+		for i, backend := range route.Backends {
+			backends = append(
+				backends,
+				listenerBackend{
+					name: names[i],
+					spec: backend,
+				},
+			)
+		}
+
+		if route.RouteLookup == nil {
+			continue
+		}
+
+		for _, target := range route.RouteLookup.Targets {
+			backends = append(
+				backends,
+				listenerBackend{
+					name: targetName(listener.Namespace, listener.Name, target.Name),
+					spec: target.Backend,
+				},
+			)
+		}
+	}
+
+	return backends
+}
+
+// namespace/name/backend/<backend_hash> or namespace/name/target/<target_name>
 type parsedBackendName struct {
 	Namespace    string
 	ListenerName string
-	RouteID      int
-	BackendID    int
+	Kind         string
+	ID           string
 }
 
 func (p *parsedBackendName) String() string {
-	return backendName(p.Namespace, p.ListenerName, p.RouteID, p.BackendID)
+	return path.Join(p.Namespace, p.ListenerName, p.Kind, p.ID)
 }
 
 func parseBackendName(resourceName string) (parsedBackendName, error) {
 	sp := strings.Split(resourceName, "/")
 
-	if len(sp) != 6 {
-		return parsedBackendName{}, malformedResourceNameErr(resourceName)
-	}
-
-	routeID, err := strconv.Atoi(sp[3])
-	if err != nil {
-		return parsedBackendName{}, malformedResourceNameErr(resourceName)
-	}
-
-	backendID, err := strconv.Atoi(sp[5])
-	if err != nil {
+	if len(sp) != 4 || (sp[2] != "backend" && sp[2] != "target") || sp[3] == "" {
 		return parsedBackendName{}, malformedResourceNameErr(resourceName)
 	}
 
 	return parsedBackendName{
 		Namespace:    sp[0],
 		ListenerName: sp[1],
-		RouteID:      routeID,
-		BackendID:    backendID,
+		Kind:         sp[2],
+		ID:           sp[3],
 	}, nil
 }
 
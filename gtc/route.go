@@ -3,37 +3,175 @@ package gtc
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
 	"strings"
 
 	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	anyv1 "github.com/golang/protobuf/ptypes/any"
 	gtcv1alpha1 "github.com/jlevesy/grpc-traffic-controller/api/gtc/v1alpha1"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
-func makeRouteConfig(listenerName string, listener *gtcv1alpha1.GRPCListener) (*route.RouteConfiguration, error) {
-	routes := make([]*route.Route, len(listener.Spec.Routes))
+func makeRouteConfig(listener *gtcv1alpha1.GRPCListener) (*route.RouteConfiguration, error) {
+	var listenerRetryPolicy *route.RetryPolicy
+
+	if listener.Spec.Retry != nil {
+		listenerRetryPolicy = makeRetryPolicy(listener.Spec.Retry)
+	}
+
+	vhostNames, err := makeVirtualHostNames(listener)
+	if err != nil {
+		return nil, err
+	}
 
-	for routeID, routeSpec := range listener.Spec.Routes {
-		match, err := makeRouteMatch(routeSpec)
+	if err := checkRouteLookupTargets(listener); err != nil {
+		return nil, err
+	}
+
+	var (
+		vhosts  = make([]*route.VirtualHost, 0, len(listener.Spec.VirtualHosts)+1)
+		plugins = make(map[string]*route.ClusterSpecifierPlugin)
+	)
+
+	for vhostID, vhostSpec := range listener.Spec.VirtualHosts {
+		routes, err := makeRoutes(listener, vhostSpec.Routes, plugins)
 		if err != nil {
 			return nil, err
 		}
 
-		filterOverrides, err := makeFilterOverrides(routeSpec.Interceptors)
+		vhosts = append(
+			vhosts,
+			&route.VirtualHost{
+				Name:        indexedVHostName(listener.Namespace, listener.Name, vhostID),
+				Domains:     makeDomains(listener, vhostSpec.Names),
+				Routes:      routes,
+				RetryPolicy: listenerRetryPolicy,
+			},
+		)
+	}
+
+	// The listener routes serve all the names not claimed by a virtual host.
+	if len(vhostNames) > 0 {
+		routes, err := makeRoutes(listener, listener.Spec.Routes, plugins)
 		if err != nil {
 			return nil, err
 		}
 
-		weighedClusters, err := makeWeightedClusters(
-			listener.Namespace,
-			listener.Name,
-			routeID,
-			routeSpec,
+		vhosts = append(
+			vhosts,
+			&route.VirtualHost{
+				Name:        vHostName(listener.Namespace, listener.Name),
+				Domains:     makeDomains(listener, vhostNames),
+				Routes:      routes,
+				RetryPolicy: listenerRetryPolicy,
+			},
 		)
+	}
+
+	pluginNames := make([]string, 0, len(plugins))
+	for name := range plugins {
+		pluginNames = append(pluginNames, name)
+	}
+
+	slices.Sort(pluginNames)
+
+	clusterSpecifierPlugins := make([]*route.ClusterSpecifierPlugin, len(pluginNames))
+
+	for i, name := range pluginNames {
+		clusterSpecifierPlugins[i] = plugins[name]
+	}
+
+	return &route.RouteConfiguration{
+		Name:                    routeConfigName(listener.Namespace, listener.Name),
+		ValidateClusters:        &wrapperspb.BoolValue{Value: true},
+		VirtualHosts:            vhosts,
+		ClusterSpecifierPlugins: clusterSpecifierPlugins,
+	}, nil
+}
+
+// makeVirtualHostNames checks the names served by the virtual hosts of a listener,
+// and returns the names left to its default virtual host.
+func makeVirtualHostNames(listener *gtcv1alpha1.GRPCListener) ([]string, error) {
+	var (
+		names   = append([]string{listener.Name}, listener.Spec.Aliases...)
+		claimed = make(map[string]bool, len(names))
+	)
+
+	for _, alias := range listener.Spec.Aliases {
+		if alias == "" || alias == listener.Name {
+			return nil, fmt.Errorf("invalid alias %q, an alias can't be empty nor the name of the listener", alias)
+		}
+	}
+
+	for _, vhostSpec := range listener.Spec.VirtualHosts {
+		if len(vhostSpec.Names) == 0 {
+			return nil, errors.New("virtual host must serve at least one name")
+		}
+
+		for _, name := range vhostSpec.Names {
+			if !slices.Contains(names, name) {
+				return nil, fmt.Errorf("virtual host name %q is neither the name nor an alias of the listener", name)
+			}
+
+			if claimed[name] {
+				return nil, fmt.Errorf("name %q is served by more than one virtual host", name)
+			}
+
+			claimed[name] = true
+		}
+	}
+
+	return slices.DeleteFunc(names, func(name string) bool { return claimed[name] }), nil
+}
+
+// makeDomains returns the domains of a virtual host serving the given names of a listener.
+func makeDomains(listener *gtcv1alpha1.GRPCListener, names []string) []string {
+	domains := make([]string, 0, 3*len(names)+1)
+
+	for _, name := range names {
+		domain := listenerName(listener.Namespace, name)
+
+		// Recent gRPC clients percent encode the authority of the target, "/" included.
+		// Short names are dialed by clients of the namespace of the listener.
+		domains = append(domains, domain, url.PathEscape(domain), name)
+
+		if name != listener.Name {
+			continue
+		}
+
+		// The hostname is served along with the name, unless it is one of the names already.
+		hostname, ok := listener.Annotations[gtcv1alpha1.AnnotationHostname]
+		if ok && hostname != listener.Name && !slices.Contains(listener.Spec.Aliases, hostname) {
+			domains = append(domains, hostname)
+		}
+	}
+
+	return domains
+}
+
+// makeRoutes returns the routes of a virtual host, adding the cluster specifier plugins they use to plugins.
+func makeRoutes(listener *gtcv1alpha1.GRPCListener, routeSpecs []gtcv1alpha1.Route, plugins map[string]*route.ClusterSpecifierPlugin) ([]*route.Route, error) {
+	routes := make([]*route.Route, 0, len(routeSpecs))
+
+	for _, routeSpec := range routeSpecs {
+		matches, err := makeRouteMatches(routeSpec)
+		if err != nil {
+			return nil, err
+		}
+
+		filterOverrides, err := makeFilterOverrides(routeSpec.Interceptors)
+		if err != nil {
+			return nil, err
+		}
+
+		faultHeaders, faultOverrides, err := makeHeaderFault(listener, routeSpec, filterOverrides)
 		if err != nil {
 			return nil, err
 		}
@@ -49,43 +187,50 @@ func makeRouteConfig(listenerName string, listener *gtcv1alpha1.GRPCListener) (*
 			return nil, err
 		}
 
-		routes[routeID] = &route.Route{
-			Match:                match,
-			TypedPerFilterConfig: filterOverrides,
-			Action: &route.Route_Route{
-				Route: &route.RouteAction{
-					HashPolicy:  hashPolicy,
-					RetryPolicy: routeRetryPolicy,
-					MaxStreamDuration: &route.RouteAction_MaxStreamDuration{
-						MaxStreamDuration:    makeDuration(routeSpec.MaxStreamDuration),
-						GrpcTimeoutHeaderMax: makeDuration(routeSpec.GrpcTimeoutHeaderMax),
-					},
-					ClusterSpecifier: &route.RouteAction_WeightedClusters{
-						WeightedClusters: weighedClusters,
-					},
+		action := &route.Route_Route{
+			Route: &route.RouteAction{
+				HashPolicy:  hashPolicy,
+				RetryPolicy: routeRetryPolicy,
+				MaxStreamDuration: &route.RouteAction_MaxStreamDuration{
+					MaxStreamDuration:    makeDuration(routeSpec.MaxStreamDuration),
+					GrpcTimeoutHeaderMax: makeDuration(routeSpec.GrpcTimeoutHeaderMax),
 				},
 			},
 		}
-	}
 
-	var listenerRetryPolicy *route.RetryPolicy
+		if err := setClusterSpecifier(action.Route, listener, routeSpec, plugins); err != nil {
+			return nil, err
+		}
 
-	if listener.Spec.Retry != nil {
-		listenerRetryPolicy = makeRetryPolicy(listener.Spec.Retry)
+		// One xDS route per matcher, all of them sharing the same clusters.
+		for _, match := range matches {
+			// Calls matching the headers of the fault are first routed to a copy of the route injecting it.
+			if len(faultHeaders) > 0 {
+				faultMatch := proto.Clone(match).(*route.RouteMatch)
+				faultMatch.Headers = append(faultMatch.Headers, faultHeaders...)
+
+				routes = append(
+					routes,
+					&route.Route{
+						Match:                faultMatch,
+						TypedPerFilterConfig: faultOverrides,
+						Action:               action,
+					},
+				)
+			}
+
+			routes = append(
+				routes,
+				&route.Route{
+					Match:                match,
+					TypedPerFilterConfig: filterOverrides,
+					Action:               action,
+				},
+			)
+		}
 	}
 
-	return &route.RouteConfiguration{
-		Name:             routeConfigName(listener.Namespace, listener.Name),
-		ValidateClusters: &wrapperspb.BoolValue{Value: true},
-		VirtualHosts: []*route.VirtualHost{
-			{
-				Name:        vHostName(listener.Namespace, listener.Name),
-				Domains:     []string{listenerName},
-				Routes:      routes,
-				RetryPolicy: listenerRetryPolicy,
-			},
-		},
-	}, nil
+	return routes, nil
 }
 
 var matchAll = route.RouteMatch{
@@ -94,32 +239,83 @@ var matchAll = route.RouteMatch{
 	},
 }
 
-func makeRouteMatch(spec gtcv1alpha1.Route) (*route.RouteMatch, error) {
-	if spec.Matcher == nil {
+// makeRouteMatches returns the matches of a route, one per matcher.
+func makeRouteMatches(spec gtcv1alpha1.Route) ([]*route.RouteMatch, error) {
+	if len(spec.Matchers) == 0 {
+		match, err := makeRouteMatch(spec.Matcher)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*route.RouteMatch{match}, nil
+	}
+
+	if spec.Matcher != nil {
+		return nil, errors.New("route can't have both a matcher and matchers")
+	}
+
+	matches := make([]*route.RouteMatch, len(spec.Matchers))
+
+	for i := range spec.Matchers {
+		var err error
+
+		matches[i], err = makeRouteMatch(&spec.Matchers[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return matches, nil
+}
+
+func makeRouteMatch(spec *gtcv1alpha1.RouteMatcher) (*route.RouteMatch, error) {
+	if spec == nil {
 		return &matchAll, nil
 	}
 
 	var match route.RouteMatch
 
 	switch {
-	case spec.Matcher.Method != nil:
+	case spec.Method != nil:
 		match.PathSpecifier = &route.RouteMatch_Path{
-			Path: spec.Matcher.Method.Path(),
+			Path: spec.Method.Path(),
+		}
+	case len(spec.Methods) > 0:
+		paths := make([]string, len(spec.Methods))
+		for i, method := range spec.Methods {
+			paths[i] = method.Path()
+		}
+
+		match.PathSpecifier = &route.RouteMatch_SafeRegex{
+			SafeRegex: makeAnyPathRegexMatcher(paths, false),
 		}
-	case spec.Matcher.Service != nil:
+	case spec.Service != nil:
 		match.PathSpecifier = &route.RouteMatch_Prefix{
-			Prefix: spec.Matcher.Service.Prefix(),
+			Prefix: spec.Service.Prefix(),
+		}
+	case len(spec.Services) > 0:
+		prefixes := make([]string, len(spec.Services))
+		for i, service := range spec.Services {
+			prefixes[i] = service.Prefix()
+		}
+
+		match.PathSpecifier = &route.RouteMatch_SafeRegex{
+			SafeRegex: makeAnyPathRegexMatcher(prefixes, true),
 		}
-	case spec.Matcher.Namespace != nil:
+	case spec.Namespace != nil:
 		match.PathSpecifier = &route.RouteMatch_Prefix{
-			Prefix: "/" + *spec.Matcher.Namespace,
+			Prefix: gtcv1alpha1.NamespacePrefix(*spec.Namespace),
+		}
+	case spec.Path != nil:
+		if err := makePathMatch(&match, spec.Path); err != nil {
+			return nil, err
 		}
 	default:
 		match.PathSpecifier = matchAll.PathSpecifier
 	}
 
-	if spec.Matcher.Fraction != nil {
-		fraction, err := makeFractionalPercent(spec.Matcher.Fraction)
+	if spec.Fraction != nil {
+		fraction, err := makeFractionalPercent(spec.Fraction)
 		if err != nil {
 			return nil, err
 		}
@@ -129,9 +325,9 @@ func makeRouteMatch(spec gtcv1alpha1.Route) (*route.RouteMatch, error) {
 		}
 	}
 
-	match.Headers = make([]*route.HeaderMatcher, len(spec.Matcher.Metadata))
+	match.Headers = make([]*route.HeaderMatcher, len(spec.Metadata))
 
-	for i, metadataMatcherSpec := range spec.Matcher.Metadata {
+	for i, metadataMatcherSpec := range spec.Metadata {
 		var err error
 
 		match.Headers[i], err = makeMetadataMatcher(metadataMatcherSpec)
@@ -143,6 +339,75 @@ func makeRouteMatch(spec gtcv1alpha1.Route) (*route.RouteMatch, error) {
 	return &match, nil
 }
 
+// makePathMatch sets the path specifier of a route match, enforcing gRPC rules: paths start with a slash, and regexes are re2 regexes.
+func makePathMatch(match *route.RouteMatch, spec *gtcv1alpha1.PathMatcher) error {
+	var specifiers int
+
+	for _, set := range []bool{spec.Prefix != "", spec.Path != "", spec.Regex != nil} {
+		if set {
+			specifiers++
+		}
+	}
+
+	if specifiers != 1 {
+		return errors.New("path matcher requires exactly one of prefix, path or regex")
+	}
+
+	switch {
+	case spec.Prefix != "":
+		if !strings.HasPrefix(spec.Prefix, "/") {
+			return fmt.Errorf("path prefix %q must start with /", spec.Prefix)
+		}
+
+		match.PathSpecifier = &route.RouteMatch_Prefix{Prefix: spec.Prefix}
+	case spec.Path != "":
+		if !strings.HasPrefix(spec.Path, "/") {
+			return fmt.Errorf("path %q must start with /", spec.Path)
+		}
+
+		match.PathSpecifier = &route.RouteMatch_Path{Path: spec.Path}
+	default:
+		regexMatcher, err := makeRegexMatcher(spec.Regex)
+		if err != nil {
+			return err
+		}
+
+		// Go regexps share the re2 syntax.
+		if _, err := regexp.Compile(spec.Regex.Regex); err != nil {
+			return fmt.Errorf("invalid path regex: %w", err)
+		}
+
+		match.PathSpecifier = &route.RouteMatch_SafeRegex{SafeRegex: regexMatcher}
+	}
+
+	if spec.CaseSensitive != nil {
+		match.CaseSensitive = wrapperspb.Bool(*spec.CaseSensitive)
+	}
+
+	return nil
+}
+
+// makeAnyPathRegexMatcher returns a regex matcher matching any of the given paths, or any path starting with one of them
+// if prefix is set.
+func makeAnyPathRegexMatcher(paths []string, prefix bool) *matcher.RegexMatcher {
+	alternatives := make([]string, len(paths))
+
+	for i, p := range paths {
+		alternatives[i] = regexp.QuoteMeta(p)
+
+		if prefix {
+			alternatives[i] += ".*"
+		}
+	}
+
+	return &matcher.RegexMatcher{
+		Regex: "(?:" + strings.Join(alternatives, "|") + ")",
+		EngineType: &matcher.RegexMatcher_GoogleRe2{
+			GoogleRe2: &matcher.RegexMatcher_GoogleRE2{},
+		},
+	}
+}
+
 func makeMetadataMatcher(spec gtcv1alpha1.MetadataMatcher) (*route.HeaderMatcher, error) {
 	matcher := route.HeaderMatcher{
 		Name:        spec.Name,
@@ -206,24 +471,104 @@ func makeRegexMatcher(spec *gtcv1alpha1.RegexMatcher) (*matcher.RegexMatcher, er
 	}, nil
 }
 
-func makeWeightedClusters(namespace, name string, routeID int, routeSpec gtcv1alpha1.Route) (*route.WeightedCluster, error) {
+func makeRegexRewrite(spec *gtcv1alpha1.RegexRewrite) (*matcher.RegexMatchAndSubstitute, error) {
+	if spec == nil {
+		return nil, nil
+	}
+
+	pattern, err := makeRegexMatcher(&spec.Pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return &matcher.RegexMatchAndSubstitute{
+		Pattern:      pattern,
+		Substitution: spec.Substitution,
+	}, nil
+}
+
+// setClusterSpecifier routes the action to the weighted clusters of the backends of a route, or to the cluster specifier plugin
+// of its route lookup, which is added to plugins.
+func setClusterSpecifier(action *route.RouteAction, listener *gtcv1alpha1.GRPCListener, routeSpec gtcv1alpha1.Route, plugins map[string]*route.ClusterSpecifierPlugin) error {
+	if routeSpec.RouteLookup == nil {
+		weighedClusters, err := makeWeightedClusters(
+			listener.Namespace,
+			listener.Name,
+			routeSpec,
+		)
+		if err != nil {
+			return err
+		}
+
+		action.ClusterSpecifier = &route.RouteAction_WeightedClusters{
+			WeightedClusters: weighedClusters,
+		}
+
+		return nil
+	}
+
+	if len(routeSpec.Backends) > 0 {
+		return errors.New("route can't have both backends and a route lookup")
+	}
+
+	plugin, err := makeRouteLookupPlugin(listener, routeSpec.RouteLookup)
+	if err != nil {
+		return err
+	}
+
+	plugins[plugin.GetExtension().GetName()] = plugin
+
+	action.ClusterSpecifier = &route.RouteAction_ClusterSpecifierPlugin{
+		ClusterSpecifierPlugin: plugin.GetExtension().GetName(),
+	}
+
+	return nil
+}
+
+// makeWeightedClusters merges identical backends of a route with the same interceptors into a single weighted cluster,
+// copies with different interceptors keep a cluster of their own.
+func makeWeightedClusters(namespace, name string, routeSpec gtcv1alpha1.Route) (*route.WeightedCluster, error) {
 	var (
 		totalWeight     uint32
-		weighedClusters = make([]*route.WeightedCluster_ClusterWeight, len(routeSpec.Backends))
+		clusterNames    = routeBackendNames(namespace, name, routeSpec.Backends)
+		weighedClusters = make([]*route.WeightedCluster_ClusterWeight, 0, len(routeSpec.Backends))
+		copiesByName    = make(map[string][]*route.WeightedCluster_ClusterWeight, len(routeSpec.Backends))
 	)
 
-	for backendID, backend := range routeSpec.Backends {
+	for i, backend := range routeSpec.Backends {
+		totalWeight += backend.Weight
+
+		if fault := findFault(backend.Interceptors); fault != nil && len(fault.Headers) > 0 {
+			return nil, errors.New("backend fault interceptors can't match headers")
+		}
+
 		filterOverrides, err := makeFilterOverrides(backend.Interceptors)
 		if err != nil {
 			return nil, err
 		}
 
-		totalWeight += backend.Weight
-		weighedClusters[backendID] = &route.WeightedCluster_ClusterWeight{
-			Name:                 backendName(namespace, name, routeID, backendID),
+		var (
+			sharedName = backendName(namespace, name, backend)
+			copies     = copiesByName[sharedName]
+		)
+
+		sameInterceptors := slices.IndexFunc(copies, func(cluster *route.WeightedCluster_ClusterWeight) bool {
+			return equalFilterOverrides(cluster.TypedPerFilterConfig, filterOverrides)
+		})
+
+		if sameInterceptors >= 0 {
+			copies[sameInterceptors].Weight = wrapperspb.UInt32(copies[sameInterceptors].Weight.GetValue() + backend.Weight)
+			continue
+		}
+
+		cluster := &route.WeightedCluster_ClusterWeight{
+			Name:                 clusterNames[i],
 			Weight:               wrapperspb.UInt32(backend.Weight),
 			TypedPerFilterConfig: filterOverrides,
 		}
+
+		copiesByName[sharedName] = append(copies, cluster)
+		weighedClusters = append(weighedClusters, cluster)
 	}
 
 	return &route.WeightedCluster{
@@ -232,6 +577,20 @@ func makeWeightedClusters(namespace, name string, routeID int, routeSpec gtcv1al
 	}, nil
 }
 
+func equalFilterOverrides(a, b map[string]*anyv1.Any) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for name, cfg := range a {
+		if !proto.Equal(cfg, b[name]) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func makeRetryPolicy(spec *gtcv1alpha1.RetryPolicy) *route.RetryPolicy {
 	var (
 		numRetries uint32 = 1
@@ -269,10 +628,16 @@ func makeHashPolicy(policies []gtcv1alpha1.HashPolicy) ([]*route.RouteAction_Has
 	for i, policy := range policies {
 		switch {
 		case policy.Metadata != "":
+			regexRewrite, err := makeRegexRewrite(policy.RegexRewrite)
+			if err != nil {
+				return nil, err
+			}
+
 			result[i] = &route.RouteAction_HashPolicy{
 				PolicySpecifier: &route.RouteAction_HashPolicy_Header_{
 					Header: &route.RouteAction_HashPolicy_Header{
-						HeaderName: policy.Metadata,
+						HeaderName:   policy.Metadata,
+						RegexRewrite: regexRewrite,
 					},
 				},
 				Terminal: policy.Terminal,
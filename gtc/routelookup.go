@@ -0,0 +1,184 @@
+package gtc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	gtcv1alpha1 "github.com/jlevesy/grpc-traffic-controller/api/gtc/v1alpha1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	// Registers the grpc.lookup.v1 protos, which grpc-go doesn't expose.
+	_ "google.golang.org/grpc/balancer/rls"
+)
+
+const routeLookupClusterSpecifierName = "grpc.lookup.v1.RouteLookupClusterSpecifier"
+
+// routeLookupClusterSpecifier is the JSON form of the grpc.lookup.v1.RouteLookupClusterSpecifier proto.
+type routeLookupClusterSpecifier struct {
+	RouteLookupConfig routeLookupConfig `json:"routeLookupConfig"`
+}
+
+type routeLookupConfig struct {
+	GrpcKeybuilders      []grpcKeyBuilder `json:"grpcKeybuilders"`
+	LookupService        string           `json:"lookupService"`
+	LookupServiceTimeout string           `json:"lookupServiceTimeout,omitempty"`
+	MaxAge               string           `json:"maxAge,omitempty"`
+	StaleAge             string           `json:"staleAge,omitempty"`
+	CacheSizeBytes       int64            `json:"cacheSizeBytes"`
+	ValidTargets         []string         `json:"validTargets,omitempty"`
+	DefaultTarget        string           `json:"defaultTarget,omitempty"`
+}
+
+type grpcKeyBuilder struct {
+	Names        []grpcKeyBuilderName `json:"names"`
+	ExtraKeys    grpcKeyBuilderExtra  `json:"extraKeys"`
+	Headers      []nameMatcher        `json:"headers,omitempty"`
+	ConstantKeys map[string]string    `json:"constantKeys,omitempty"`
+}
+
+type grpcKeyBuilderName struct {
+	Service string `json:"service"`
+	Method  string `json:"method,omitempty"`
+}
+
+type grpcKeyBuilderExtra struct {
+	Host    string `json:"host,omitempty"`
+	Service string `json:"service,omitempty"`
+	Method  string `json:"method,omitempty"`
+}
+
+type nameMatcher struct {
+	Key   string   `json:"key"`
+	Names []string `json:"names"`
+}
+
+// makeRouteLookupPlugin returns the RLS cluster specifier plugin of a route, named after a hash of its config
+// so that identical route lookups of a listener share the same plugin.
+func makeRouteLookupPlugin(listener *gtcv1alpha1.GRPCListener, spec *gtcv1alpha1.RouteLookup) (*route.ClusterSpecifierPlugin, error) {
+	if spec.LookupService == "" {
+		return nil, errors.New("route lookup must have a lookup service")
+	}
+
+	config := routeLookupConfig{
+		GrpcKeybuilders:      make([]grpcKeyBuilder, len(spec.KeyBuilders)),
+		LookupService:        spec.LookupService,
+		LookupServiceTimeout: makeJSONDuration(spec.LookupServiceTimeout),
+		MaxAge:               makeJSONDuration(spec.MaxAge),
+		StaleAge:             makeJSONDuration(spec.StaleAge),
+		CacheSizeBytes:       spec.CacheSizeBytes,
+		ValidTargets:         make([]string, len(spec.Targets)),
+	}
+
+	for i, keyBuilderSpec := range spec.KeyBuilders {
+		keyBuilder := grpcKeyBuilder{
+			Names: make([]grpcKeyBuilderName, len(keyBuilderSpec.Methods)),
+			ExtraKeys: grpcKeyBuilderExtra{
+				Host:    keyBuilderSpec.HostKey,
+				Service: keyBuilderSpec.ServiceKey,
+				Method:  keyBuilderSpec.MethodKey,
+			},
+			Headers:      make([]nameMatcher, len(keyBuilderSpec.Metadata)),
+			ConstantKeys: keyBuilderSpec.ConstantKeys,
+		}
+
+		for j, method := range keyBuilderSpec.Methods {
+			if method.Service == "" {
+				return nil, errors.New("route lookup key builder methods must have a service")
+			}
+
+			serviceMatcher := gtcv1alpha1.ServiceMatcher{Namespace: method.Namespace, Service: method.Service}
+
+			keyBuilder.Names[j] = grpcKeyBuilderName{
+				Service: serviceMatcher.FullName(),
+				Method:  method.Method,
+			}
+		}
+
+		for j, metadata := range keyBuilderSpec.Metadata {
+			keyBuilder.Headers[j] = nameMatcher{
+				Key:   metadata.Key,
+				Names: metadata.Names,
+			}
+		}
+
+		config.GrpcKeybuilders[i] = keyBuilder
+	}
+
+	for i, target := range spec.Targets {
+		config.ValidTargets[i] = targetName(listener.Namespace, listener.Name, target.Name)
+
+		if target.Name == spec.DefaultTarget {
+			config.DefaultTarget = config.ValidTargets[i]
+		}
+	}
+
+	if spec.DefaultTarget != "" && config.DefaultTarget == "" {
+		return nil, fmt.Errorf("route lookup default target %q is not one of its targets", spec.DefaultTarget)
+	}
+
+	rawSpecifier, err := json.Marshal(routeLookupClusterSpecifier{RouteLookupConfig: config})
+	if err != nil {
+		return nil, err
+	}
+
+	specifierType, err := protoregistry.GlobalTypes.FindMessageByName(routeLookupClusterSpecifierName)
+	if err != nil {
+		return nil, err
+	}
+
+	specifier := specifierType.New().Interface()
+	if err := protojson.Unmarshal(rawSpecifier, specifier); err != nil {
+		return nil, err
+	}
+
+	typedConfig, err := anypb.New(specifier)
+	if err != nil {
+		return nil, err
+	}
+
+	return &route.ClusterSpecifierPlugin{
+		Extension: &corev3.TypedExtensionConfig{
+			Name:        routeLookupName(listener.Namespace, listener.Name, rawSpecifier),
+			TypedConfig: typedConfig,
+		},
+	}, nil
+}
+
+// makeJSONDuration returns the JSON form of a google.protobuf.Duration.
+func makeJSONDuration(d *metav1.Duration) string {
+	if d == nil {
+		return ""
+	}
+
+	return strconv.FormatFloat(d.Duration.Seconds(), 'f', -1, 64) + "s"
+}
+
+// checkRouteLookupTargets returns an error if a route lookup target name of a listener stands for different backends.
+func checkRouteLookupTargets(listener *gtcv1alpha1.GRPCListener) error {
+	targets := make(map[string]string)
+
+	for _, routeSpec := range listener.Spec.AllRoutes() {
+		if routeSpec.RouteLookup == nil {
+			continue
+		}
+
+		for _, target := range routeSpec.RouteLookup.Targets {
+			hash := backendHash(target.Backend)
+
+			if knownHash, ok := targets[target.Name]; ok && knownHash != hash {
+				return fmt.Errorf("route lookup target %q is defined with different backends", target.Name)
+			}
+
+			targets[target.Name] = hash
+		}
+	}
+
+	return nil
+}
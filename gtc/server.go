@@ -2,6 +2,7 @@ package gtc
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
@@ -10,13 +11,16 @@ import (
 	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	sotwv3 "github.com/envoyproxy/go-control-plane/pkg/server/sotw/v3"
+	gtcclientset "github.com/jlevesy/grpc-traffic-controller/client/clientset/versioned"
 	gtcinformers "github.com/jlevesy/grpc-traffic-controller/client/informers/externalversions"
 	"github.com/jlevesy/grpc-traffic-controller/pkg/controllersupport"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
 	kubeinformers "k8s.io/client-go/informers"
+	discoveryv1listers "k8s.io/client-go/listers/discovery/v1"
 )
 
 const (
@@ -27,9 +31,20 @@ const (
 )
 
 type XDSServerConfig struct {
-	BindAddr     string
+	BindAddr string
+	// TLS enables TLS on the xDS server if set.
+	TLS *tls.Config
+	// Authentication configures how xDS clients are authenticated.
+	Authentication AuthenticationConfig
+	// Authorization configures which GRPCListeners a client is allowed to read.
+	Authorization AuthorizationConfig
+
 	K8sInformers kubeinformers.SharedInformerFactory
 	GTCInformers gtcinformers.SharedInformerFactory
+	// GTCClient writes the status of GRPCListeners and FaultExperiments, left untouched if not set.
+	GTCClient gtcclientset.Interface
+	// RemoteClusters are the informers of the remote clusters backends can read EndpointSlices from, by cluster name.
+	RemoteClusters map[string]kubeinformers.SharedInformerFactory
 }
 
 type XDSServer struct {
@@ -37,42 +52,59 @@ type XDSServer struct {
 	server   *grpc.Server
 	logger   *zap.Logger
 
-	grpcListenerChangedQueue  *controllersupport.QueuedEventHandler
-	endpointSliceChangedQueue *controllersupport.QueuedEventHandler
+	grpcListenerChangedQueue         *controllersupport.QueuedEventHandler
+	endpointSliceChangedQueue        *controllersupport.QueuedEventHandler
+	remoteEndpointSliceChangedQueues []*controllersupport.QueuedEventHandler
+	referenceGrantChangedQueue       *controllersupport.QueuedEventHandler
+	podChangedQueue                  *controllersupport.QueuedEventHandler
+	nodeChangedQueue                 *controllersupport.QueuedEventHandler
+	faultExperimentChangedQueue      *controllersupport.QueuedEventHandler
 }
 
 func NewXDSServer(ctx context.Context, cfg XDSServerConfig, logger *zap.Logger) (*XDSServer, error) {
-	var (
-		grpcServer = grpc.NewServer(
-			grpc.MaxConcurrentStreams(grpcMaxConcurrentStreams),
-			grpc.KeepaliveParams(
-				keepalive.ServerParameters{
-					Time:    grpcKeepaliveTime,
-					Timeout: grpcKeepaliveTimeout,
-				},
-			),
-			grpc.KeepaliveEnforcementPolicy(
-				keepalive.EnforcementPolicy{
-					MinTime:             grpcKeepaliveMinTime,
-					PermitWithoutStream: true,
-				},
-			),
+	serverCallbacks := callbacks{&loggerCallbacks{l: logger}}
+
+	if cfg.Authorization.Enabled {
+		serverCallbacks = append(
+			serverCallbacks,
+			newAuthorizer(cfg.GTCInformers.Api().V1alpha1().GRPCListeners().Lister(), logger),
 		)
-		watches = newWatches()
-		srv     = sotwv3.NewServer(
+	}
+
+	remoteEndpointSlices := make(map[string]discoveryv1listers.EndpointSliceLister, len(cfg.RemoteClusters))
+	for cluster, informers := range cfg.RemoteClusters {
+		remoteEndpointSlices[cluster] = informers.Discovery().V1().EndpointSlices().Lister()
+	}
+
+	var (
+		grpcServer = grpc.NewServer(grpcServerOptions(cfg, logger)...)
+		watches    = newWatches()
+		srv        = sotwv3.NewServer(
 			ctx,
 			newConfigWatcher(
 				cfg.K8sInformers.Discovery().V1().EndpointSlices().Lister(),
+				remoteEndpointSlices,
+				cfg.K8sInformers.Core().V1().Pods().Lister(),
+				cfg.K8sInformers.Core().V1().Nodes().Lister(),
 				cfg.GTCInformers.Api().V1alpha1().GRPCListeners().Lister(),
+				cfg.GTCInformers.Api().V1alpha1().ReferenceGrants().Lister(),
+				cfg.GTCInformers.Api().V1alpha1().FaultExperiments().Lister(),
 				watches,
 				logger,
 			),
-			&loggerCallbacks{l: logger},
+			serverCallbacks,
 		)
 
+		listenerStatus = &listenerStatus{
+			referenceGrants: referenceGrants{lister: cfg.GTCInformers.Api().V1alpha1().ReferenceGrants().Lister()},
+			client:          cfg.GTCClient,
+			logger:          logger,
+		}
+
 		grpcListenerChangedQueue = controllersupport.NewQueuedEventHandler(
 			&grpcListenerChangedHandler{
 				watches: watches,
+				status:  listenerStatus,
 				logger:  logger,
 			},
 			10,
@@ -90,8 +122,60 @@ func NewXDSServer(ctx context.Context, cfg XDSServerConfig, logger *zap.Logger)
 			"endpointslices-changes",
 			logger,
 		)
+
+		referenceGrantChangedQueue = controllersupport.NewQueuedEventHandler(
+			&referenceGrantChangedHandler{
+				listenersLister: cfg.GTCInformers.Api().V1alpha1().GRPCListeners().Lister(),
+				watches:         watches,
+				status:          listenerStatus,
+				logger:          logger,
+			},
+			10,
+			"referencegrants-changes",
+			logger,
+		)
+
+		podChangedQueue = controllersupport.NewQueuedEventHandler(
+			&podChangedHandler{
+				listenersLister: cfg.GTCInformers.Api().V1alpha1().GRPCListeners().Lister(),
+				watches:         watches,
+				logger:          logger,
+			},
+			10,
+			"pods-changes",
+			logger,
+		)
+
+		nodeChangedQueue = controllersupport.NewQueuedEventHandler(
+			&nodeChangedHandler{
+				listenersLister: cfg.GTCInformers.Api().V1alpha1().GRPCListeners().Lister(),
+				watches:         watches,
+				logger:          logger,
+			},
+			10,
+			"nodes-changes",
+			logger,
+		)
+
+		faultExperimentChangedHandler = &faultExperimentChangedHandler{
+			listenersLister:   cfg.GTCInformers.Api().V1alpha1().GRPCListeners().Lister(),
+			experimentsLister: cfg.GTCInformers.Api().V1alpha1().FaultExperiments().Lister(),
+			client:            cfg.GTCClient,
+			scheduled:         make(map[string]time.Time),
+			watches:           watches,
+			logger:            logger,
+		}
+
+		faultExperimentChangedQueue = controllersupport.NewQueuedEventHandler(
+			faultExperimentChangedHandler,
+			10,
+			"faultexperiments-changes",
+			logger,
+		)
 	)
 
+	faultExperimentChangedHandler.requeueAfter = faultExperimentChangedQueue.RequeueAfter
+
 	discoveryv3.RegisterAggregatedDiscoveryServiceServer(
 		grpcServer, &adsHandler{srv: srv},
 	)
@@ -116,15 +200,122 @@ func NewXDSServer(ctx context.Context, cfg XDSServerConfig, logger *zap.Logger)
 		return nil, err
 	}
 
+	remoteEndpointSliceChangedQueues := make([]*controllersupport.QueuedEventHandler, 0, len(cfg.RemoteClusters))
+
+	for cluster, informers := range cfg.RemoteClusters {
+		queue := controllersupport.NewQueuedEventHandler(
+			&endpointSliceChangedHandler{
+				listenersLister: cfg.GTCInformers.Api().V1alpha1().GRPCListeners().Lister(),
+				watches:         watches,
+				logger:          logger,
+				cluster:         cluster,
+			},
+			10,
+			"endpointslices-changes-"+cluster,
+			logger,
+		)
+
+		_, err = informers.
+			Discovery().
+			V1().
+			EndpointSlices().
+			Informer().
+			AddEventHandler(queue)
+		if err != nil {
+			return nil, err
+		}
+
+		remoteEndpointSliceChangedQueues = append(remoteEndpointSliceChangedQueues, queue)
+	}
+
+	_, err = cfg.GTCInformers.
+		Api().
+		V1alpha1().
+		ReferenceGrants().
+		Informer().
+		AddEventHandler(referenceGrantChangedQueue)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = cfg.GTCInformers.
+		Api().
+		V1alpha1().
+		FaultExperiments().
+		Informer().
+		AddEventHandler(faultExperimentChangedQueue)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = cfg.K8sInformers.
+		Core().
+		V1().
+		Pods().
+		Informer().
+		AddEventHandler(podChangedQueue)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = cfg.K8sInformers.
+		Core().
+		V1().
+		Nodes().
+		Informer().
+		AddEventHandler(nodeChangedQueue)
+	if err != nil {
+		return nil, err
+	}
+
 	return &XDSServer{
-		grpcListenerChangedQueue:  grpcListenerChangedQueue,
-		endpointSliceChangedQueue: endpointSliceChangedQueue,
-		bindAddr:                  cfg.BindAddr,
-		server:                    grpcServer,
-		logger:                    logger,
+		grpcListenerChangedQueue:         grpcListenerChangedQueue,
+		endpointSliceChangedQueue:        endpointSliceChangedQueue,
+		remoteEndpointSliceChangedQueues: remoteEndpointSliceChangedQueues,
+		referenceGrantChangedQueue:       referenceGrantChangedQueue,
+		podChangedQueue:                  podChangedQueue,
+		nodeChangedQueue:                 nodeChangedQueue,
+		faultExperimentChangedQueue:      faultExperimentChangedQueue,
+		bindAddr:                         cfg.BindAddr,
+		server:                           grpcServer,
+		logger:                           logger,
 	}, nil
 }
 
+func grpcServerOptions(cfg XDSServerConfig, logger *zap.Logger) []grpc.ServerOption {
+	opts := []grpc.ServerOption{
+		grpc.MaxConcurrentStreams(grpcMaxConcurrentStreams),
+		grpc.KeepaliveParams(
+			keepalive.ServerParameters{
+				Time:    grpcKeepaliveTime,
+				Timeout: grpcKeepaliveTimeout,
+			},
+		),
+		grpc.KeepaliveEnforcementPolicy(
+			keepalive.EnforcementPolicy{
+				MinTime:             grpcKeepaliveMinTime,
+				PermitWithoutStream: true,
+			},
+		),
+	}
+
+	if cfg.TLS != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(cfg.TLS)))
+	}
+
+	// Authorization relies on the identity of the clients.
+	if cfg.Authentication.enabled() || cfg.Authorization.Enabled {
+		authn := authenticator{
+			cfg:    cfg.Authentication,
+			logger: logger.With(zap.String("component", "authenticator")),
+		}
+
+		opts = append(opts, grpc.StreamInterceptor(authn.streamInterceptor))
+	}
+
+	return opts
+}
+
 func (s *XDSServer) Run(ctx context.Context) error {
 	errGroup, groupCtx := errgroup.WithContext(ctx)
 
@@ -138,6 +329,33 @@ func (s *XDSServer) Run(ctx context.Context) error {
 		return nil
 	})
 
+	for _, queue := range s.remoteEndpointSliceChangedQueues {
+		errGroup.Go(func() error {
+			queue.Run(groupCtx)
+			return nil
+		})
+	}
+
+	errGroup.Go(func() error {
+		s.referenceGrantChangedQueue.Run(groupCtx)
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		s.podChangedQueue.Run(groupCtx)
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		s.nodeChangedQueue.Run(groupCtx)
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		s.faultExperimentChangedQueue.Run(groupCtx)
+		return nil
+	})
+
 	errGroup.Go(func() error {
 		lis, err := net.Listen("tcp", s.bindAddr)
 		if err != nil {
@@ -0,0 +1,239 @@
+package gtc
+
+import (
+	"sort"
+	"strings"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+
+	gtcv1alpha1 "github.com/jlevesy/grpc-traffic-controller/api/gtc/v1alpha1"
+	"github.com/jlevesy/grpc-traffic-controller/bootstrap"
+)
+
+// Endpoints are prioritized by their proximity with the client.
+const (
+	proximityNode = iota
+	proximityZone
+	proximityRegion
+	proximityAny
+)
+
+// topology is the location of a client or of an endpoint.
+type topology struct {
+	region string
+	zone   string
+}
+
+func (t topology) String() string {
+	return t.region + "/" + t.zone
+}
+
+// proximity tells how close an endpoint is from the client.
+func (t topology) proximity(endpoint topology) int {
+	switch {
+	case t.zone != "" && t.zone == endpoint.zone && (t.region == "" || endpoint.region == "" || t.region == endpoint.region):
+		return proximityZone
+	case t.region != "" && t.region == endpoint.region:
+		return proximityRegion
+	default:
+		return proximityAny
+	}
+}
+
+// topologies resolves the topology of clients and kubernetes nodes.
+type topologies struct {
+	pods  corev1listers.PodLister
+	nodes corev1listers.NodeLister
+}
+
+// clientTopology returns the topology and the name of the kubernetes node of a client.
+// If the client identifies its pod, they are taken from the pod and its node, falling back on the locality
+// and the node name sent by the client if the pod or its node is unknown.
+func (h topologies) clientTopology(node *core.Node) (topology, string, error) {
+	var (
+		client = topology{
+			region: node.GetLocality().GetRegion(),
+			zone:   node.GetLocality().GetZone(),
+		}
+		clientNode = node.GetMetadata().GetFields()[bootstrap.MetadataNodeName].GetStringValue()
+	)
+
+	namespace, name, ok := clientPodRef(node)
+	if !ok {
+		return client, clientNode, nil
+	}
+
+	pod, err := h.pods.Pods(namespace).Get(name)
+	switch {
+	case kerrors.IsNotFound(err):
+		return client, clientNode, nil
+	case err != nil:
+		return topology{}, "", err
+	}
+
+	if pod.Spec.NodeName == "" {
+		return client, clientNode, nil
+	}
+
+	podTopology, err := h.nodeTopology(pod.Spec.NodeName)
+	if err != nil {
+		return topology{}, "", err
+	}
+
+	if podTopology != (topology{}) {
+		client = podTopology
+	}
+
+	return client, pod.Spec.NodeName, nil
+}
+
+// clientPodRef returns the namespace and the name of the pod of a client, read from its metadata,
+// or from its node ID if formatted as <namespace>/<name>.
+func clientPodRef(node *core.Node) (string, string, bool) {
+	var (
+		fields    = node.GetMetadata().GetFields()
+		namespace = fields[bootstrap.MetadataPodNamespace].GetStringValue()
+		name      = fields[bootstrap.MetadataPodName].GetStringValue()
+	)
+
+	if namespace != "" && name != "" {
+		return namespace, name, true
+	}
+
+	namespace, name, ok := strings.Cut(node.GetId(), "/")
+
+	return namespace, name, ok && namespace != "" && name != ""
+}
+
+// sameNodeProximity applies the same node policy of a backend to the proximity of an endpoint.
+// It returns false if the endpoint should not be served to the client.
+func sameNodeProximity(policy *gtcv1alpha1.SameNodePolicy, clientNode, endpointNode string, proximity int) (int, bool) {
+	if policy == nil {
+		return proximity, true
+	}
+
+	if clientNode != "" && clientNode == endpointNode {
+		return proximityNode, true
+	}
+
+	switch policy.Fallback {
+	case gtcv1alpha1.SameNodeFallbackNone:
+		return proximity, false
+	case gtcv1alpha1.SameNodeFallbackZone:
+		return proximity, proximity == proximityZone
+	default:
+		return proximity, true
+	}
+}
+
+// nodeTopology returns the topology of a kubernetes node, taken from its well known topology labels.
+func (h topologies) nodeTopology(nodeName string) (topology, error) {
+	if nodeName == "" {
+		return topology{}, nil
+	}
+
+	node, err := h.nodes.Get(nodeName)
+	switch {
+	case kerrors.IsNotFound(err):
+		return topology{}, nil
+	case err != nil:
+		return topology{}, err
+	}
+
+	return topology{
+		region: node.Labels[corev1.LabelTopologyRegion],
+		zone:   node.Labels[corev1.LabelTopologyZone],
+	}, nil
+}
+
+type prioritizedEndpoint struct {
+	topology  topology
+	proximity int
+	endpoint  *endpointv3.LbEndpoint
+}
+
+// makePrioritizedLocalities groups endpoints in one locality per topology and proximity.
+// Localities of the closest endpoints get the highest priority, and empty priorities are skipped to avoid gaps.
+// Localities are weighted by the sum of the weights of their endpoints, to evenly spread calls accross all endpoints of the same priority.
+func makePrioritizedLocalities(subZone string, endpoints []prioritizedEndpoint) []*endpointv3.LocalityLbEndpoints {
+	type localityKey struct {
+		proximity int
+		topology  topology
+	}
+
+	var (
+		keys       []localityKey
+		localities = make(map[localityKey]*endpointv3.LocalityLbEndpoints)
+	)
+
+	for _, ep := range endpoints {
+		key := localityKey{proximity: ep.proximity, topology: ep.topology}
+
+		loc, ok := localities[key]
+		if !ok {
+			loc = &endpointv3.LocalityLbEndpoints{
+				Locality: &core.Locality{
+					Region:  ep.topology.region,
+					Zone:    ep.topology.zone,
+					SubZone: subZone,
+				},
+			}
+
+			localities[key] = loc
+			keys = append(keys, key)
+		}
+
+		loc.LbEndpoints = append(loc.LbEndpoints, ep.endpoint)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].proximity != keys[j].proximity {
+			return keys[i].proximity < keys[j].proximity
+		}
+
+		return keys[i].topology.String() < keys[j].topology.String()
+	})
+
+	var (
+		result        = make([]*endpointv3.LocalityLbEndpoints, len(keys))
+		priority      uint32
+		lastProximity = -1
+	)
+
+	for i, key := range keys {
+		if lastProximity != -1 && key.proximity != lastProximity {
+			priority++
+		}
+
+		lastProximity = key.proximity
+
+		loc := localities[key]
+		loc.Priority = priority
+		loc.LoadBalancingWeight = wrapperspb.UInt32(localityWeight(loc.LbEndpoints))
+
+		result[i] = loc
+	}
+
+	return result
+}
+
+// localityWeight sums the weights of the given endpoints, endpoints without weight count for 1.
+func localityWeight(endpoints []*endpointv3.LbEndpoint) uint32 {
+	var weight uint32
+
+	for _, ep := range endpoints {
+		if ep.GetLoadBalancingWeight() == nil {
+			weight++
+			continue
+		}
+
+		weight += ep.GetLoadBalancingWeight().GetValue()
+	}
+
+	return weight
+}
@@ -83,3 +83,15 @@ Usefull when passing down a ko reference for developnent
 {{- .Values.image.repository }}:{{- .Values.image.tag | default .Chart.AppVersion }}
 {{- end }}
 {{- end }}
+
+{{/*
+Returns the comma separated namespace/name references of the remote clusters secrets.
+*/}}
+{{- define "helm.remoteClusterSecrets" -}}
+{{- $namespace := default "default" .Release.Namespace }}
+{{- $refs := list }}
+{{- range .Values.remoteClusters.secretNames }}
+{{- $refs = append $refs (printf "%s/%s" $namespace .) }}
+{{- end }}
+{{- join "," $refs }}
+{{- end }}
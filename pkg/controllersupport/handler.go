@@ -19,6 +19,12 @@ type EventHandler interface {
 	OnDelete(ctx context.Context, obj any) error
 }
 
+// RequeueEventHandler is an EventHandler also handling the keys it enqueued using QueuedEventHandler.RequeueAfter.
+type RequeueEventHandler interface {
+	EventHandler
+	OnRequeue(ctx context.Context, key string) error
+}
+
 // QueuedEventHandler implements cache.EventHandler over a workqueue.
 // It also handles the type conversion of updated objects to pass them down to an EventHandler.
 type QueuedEventHandler struct {
@@ -58,6 +64,12 @@ func (h *QueuedEventHandler) OnDelete(obj any) {
 	h.workqueue.Add(queueEvent{kind: kindDelete, object: obj})
 }
 
+// RequeueAfter enqueues the given key once the given delay is elapsed, to be handled by a RequeueEventHandler.
+// A key already waiting is handled once, at the earliest of the times requested.
+func (h *QueuedEventHandler) RequeueAfter(key string, delay time.Duration) {
+	h.workqueue.AddAfter(queueEvent{kind: kindRequeue, object: key}, delay)
+}
+
 // Run starts workers and waits until completion.
 func (h *QueuedEventHandler) Run(ctx context.Context) {
 	defer utilruntime.HandleCrash()
@@ -107,6 +119,13 @@ func (h *QueuedEventHandler) processItem(ctx context.Context) bool {
 		err = h.handler.OnUpdate(ctx, event.oldObj, event.newObj)
 	case kindDelete:
 		err = h.handler.OnDelete(ctx, event.object)
+	case kindRequeue:
+		requeueHandler, ok := h.handler.(RequeueEventHandler)
+		if !ok {
+			return true
+		}
+
+		err = requeueHandler.OnRequeue(ctx, event.object.(string))
 
 	default:
 		return true
@@ -127,6 +146,7 @@ const (
 	kindAdd
 	kindUpdate
 	kindDelete
+	kindRequeue
 )
 
 type queueEvent struct {
@@ -40,10 +40,42 @@ func TestQueuedEventHandler_HandlesEvent(t *testing.T) {
 	assert.True(t, handler.isComplete())
 }
 
+func TestQueuedEventHandler_RequeueAfter(t *testing.T) {
+	var (
+		ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+		handler     = testHandler{
+			wantCalls: 2,
+			done:      cancel,
+		}
+		eventHandler = controllersupport.NewQueuedEventHandler(
+			&handler,
+			1,
+			"test",
+			zap.NewNop(),
+		)
+		delay = 200 * time.Millisecond
+		start = time.Now()
+	)
+
+	defer cancel()
+
+	// A key already waiting is handled once, at the earliest time requested.
+	eventHandler.RequeueAfter("a", 2*delay)
+	eventHandler.RequeueAfter("a", delay)
+	eventHandler.RequeueAfter("b", 3*delay)
+
+	eventHandler.Run(ctx)
+
+	assert.True(t, handler.isComplete())
+	assert.Equal(t, []string{"a", "b"}, handler.requeuedKeys)
+	assert.GreaterOrEqual(t, time.Since(start), 3*delay)
+}
+
 type testHandler struct {
 	addReceived    int
 	updateReceived int
 	deleteReceived int
+	requeuedKeys   []string
 
 	wantCalls int
 	done      func()
@@ -68,6 +100,12 @@ func (h *testHandler) OnDelete(context.Context, any) error {
 	return nil
 }
 
+func (h *testHandler) OnRequeue(_ context.Context, key string) error {
+	h.requeuedKeys = append(h.requeuedKeys, key)
+	h.call()
+	return nil
+}
+
 func (h *testHandler) call() {
 	if h.isComplete() {
 		h.done()
@@ -75,5 +113,5 @@ func (h *testHandler) call() {
 }
 
 func (h *testHandler) isComplete() bool {
-	return h.wantCalls == (h.addReceived + h.updateReceived + h.deleteReceived)
+	return h.wantCalls == (h.addReceived + h.updateReceived + h.deleteReceived + len(h.requeuedKeys))
 }
@@ -0,0 +1,96 @@
+package testruntime
+
+import (
+	"context"
+	"crypto/tls"
+	"testing"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// ADSStream is an aggregated discovery stream to an xDS server, acknowledging the responses it receives.
+type ADSStream struct {
+	stream discoveryv3.AggregatedDiscoveryService_StreamAggregatedResourcesClient
+	node   *corev3.Node
+	// responses holds the last response received by type URL.
+	responses map[string]*discoveryv3.DiscoveryResponse
+}
+
+// OpenADSStream opens an aggregated discovery stream to the xDS server at addr over TLS, on behalf of the given node.
+// The stream is closed at the end of the test.
+func OpenADSStream(t *testing.T, addr string, tlsConfig *tls.Config, node *corev3.Node) *ADSStream {
+	t.Helper()
+
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	t.Cleanup(func() {
+		cancel()
+		_ = conn.Close()
+	})
+
+	stream, err := discoveryv3.NewAggregatedDiscoveryServiceClient(conn).StreamAggregatedResources(ctx)
+	require.NoError(t, err)
+
+	return &ADSStream{
+		stream:    stream,
+		node:      node,
+		responses: make(map[string]*discoveryv3.DiscoveryResponse),
+	}
+}
+
+// Subscribe subscribes to the given resources, acknowledging the last response of their type,
+// and returns the names of the resources of the response received.
+func (s *ADSStream) Subscribe(t *testing.T, typeURL string, resourceNames ...string) []string {
+	t.Helper()
+
+	err := s.stream.Send(
+		&discoveryv3.DiscoveryRequest{
+			Node:          s.node,
+			TypeUrl:       typeURL,
+			ResourceNames: resourceNames,
+			VersionInfo:   s.responses[typeURL].GetVersionInfo(),
+			ResponseNonce: s.responses[typeURL].GetNonce(),
+		},
+	)
+	require.NoError(t, err)
+
+	type received struct {
+		resp *discoveryv3.DiscoveryResponse
+		err  error
+	}
+
+	recvCh := make(chan received, 1)
+
+	go func() {
+		resp, err := s.stream.Recv()
+		recvCh <- received{resp: resp, err: err}
+	}()
+
+	select {
+	case recv := <-recvCh:
+		require.NoError(t, recv.err)
+		s.responses[typeURL] = recv.resp
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no response received")
+	}
+
+	names := make([]string, len(s.responses[typeURL].GetResources()))
+
+	for i, res := range s.responses[typeURL].GetResources() {
+		msg, err := res.UnmarshalNew()
+		require.NoError(t, err)
+
+		names[i] = cache.GetResourceName(msg)
+	}
+
+	return names
+}
@@ -2,10 +2,19 @@ package testruntime
 
 import (
 	"context"
+	"encoding/json"
 	"strings"
 	"testing"
 	"time"
 
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
@@ -14,7 +23,10 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/xds"
+	"google.golang.org/protobuf/proto"
 
+	"github.com/jlevesy/grpc-traffic-controller/bootstrap"
 	echo "github.com/jlevesy/grpc-traffic-controller/pkg/echoserver/proto"
 )
 
@@ -155,6 +167,53 @@ func DefaultCallContext(addr string) func(t *testing.T) *CallContext {
 	}
 }
 
+// AuthorityCallContext dials addr overriding the authority of the target, which xDS clients match virtual hosts against.
+func AuthorityCallContext(addr, authority string) func(t *testing.T) *CallContext {
+	return func(t *testing.T) *CallContext {
+		conn, err := grpc.Dial(
+			addr,
+			grpc.WithTransportCredentials(
+				insecure.NewCredentials(),
+			),
+			grpc.WithAuthority(authority),
+		)
+		require.NoError(t, err)
+
+		return &CallContext{
+			addr:   addr,
+			conn:   conn,
+			client: echo.NewEchoClient(conn),
+		}
+	}
+}
+
+// BootstrapCallContext dials addr with an xDS client configured by the given bootstrap config
+// instead of the one referenced by the GRPC_XDS_BOOTSTRAP environment variable.
+func BootstrapCallContext(addr string, cfg bootstrap.BootstrapConfig) func(t *testing.T) *CallContext {
+	return func(t *testing.T) *CallContext {
+		rawConfig, err := json.Marshal(&cfg)
+		require.NoError(t, err)
+
+		xdsResolver, err := xds.NewXDSResolverWithConfigForTesting(rawConfig)
+		require.NoError(t, err)
+
+		conn, err := grpc.Dial(
+			addr,
+			grpc.WithTransportCredentials(
+				insecure.NewCredentials(),
+			),
+			grpc.WithResolvers(xdsResolver),
+		)
+		require.NoError(t, err)
+
+		return &CallContext{
+			addr:   addr,
+			conn:   conn,
+			client: echo.NewEchoClient(conn),
+		}
+	}
+}
+
 func CallOnce(caller Caller, assertions ...CallsAssertion) func(t *testing.T, callCtx *CallContext) {
 	return CallN(caller, 1, assertions...)
 }
@@ -183,6 +242,31 @@ func CallN(caller Caller, count int, assertions ...CallsAssertion) func(t *testi
 	}
 }
 
+// CallEach calls once with each of the given callers, and runs the assertions against all the calls.
+func CallEach(callers []Caller, assertions ...CallsAssertion) func(t *testing.T, callCtx *CallContext) {
+	return func(t *testing.T, callCtx *CallContext) {
+		calls := make([]call, len(callers))
+
+		for i, caller := range callers {
+			var c call
+
+			resp, err := caller.Do(callCtx.client)
+
+			c.addr = callCtx.addr
+			c.err = err
+			if err == nil {
+				c.backendID = resp.ServerId
+			}
+
+			calls[i] = c
+		}
+
+		for _, assert := range assertions {
+			assert(t, calls)
+		}
+	}
+}
+
 func CallNParallel(caller Caller, count int, assertions ...CallsAssertion) func(t *testing.T, callCtx *CallContext) {
 	return func(t *testing.T, callCtx *CallContext) {
 		var (
@@ -335,3 +419,79 @@ func AssertCountWithinDelta(backendID string, wantCount int, delta float64) Aggr
 		assert.InDelta(t, wantCount, aggs[backendID], delta, backendID)
 	}
 }
+
+// AssertLoadAssignment fetches the load assignment of the given cluster from the xDS server at addr
+// on behalf of the given node, and runs the given assertion against it.
+func AssertLoadAssignment(addr string, node *corev3.Node, clusterName string, assertion func(t *testing.T, cla *endpointv3.ClusterLoadAssignment)) func(*testing.T, *CallContext) {
+	return func(t *testing.T, _ *CallContext) {
+		var cla endpointv3.ClusterLoadAssignment
+
+		fetchResource(t, addr, node, resource.EndpointType, clusterName, &cla)
+
+		assertion(t, &cla)
+	}
+}
+
+// AssertCluster fetches the given cluster from the xDS server at addr on behalf of the given node,
+// and runs the given assertion against it.
+func AssertCluster(addr string, node *corev3.Node, clusterName string, assertion func(t *testing.T, cluster *clusterv3.Cluster)) func(*testing.T, *CallContext) {
+	return func(t *testing.T, _ *CallContext) {
+		var cluster clusterv3.Cluster
+
+		fetchResource(t, addr, node, resource.ClusterType, clusterName, &cluster)
+
+		assertion(t, &cluster)
+	}
+}
+
+// AssertRouteConfig fetches the given listener from the xDS server at addr on behalf of the given node,
+// and runs the given assertion against its inlined route configuration.
+func AssertRouteConfig(addr string, node *corev3.Node, listenerName string, assertion func(t *testing.T, routeConfig *routev3.RouteConfiguration)) func(*testing.T, *CallContext) {
+	return func(t *testing.T, _ *CallContext) {
+		var (
+			listener listenerv3.Listener
+			manager  hcm.HttpConnectionManager
+		)
+
+		fetchResource(t, addr, node, resource.ListenerType, listenerName, &listener)
+
+		require.NoError(t, listener.GetApiListener().GetApiListener().UnmarshalTo(&manager))
+
+		assertion(t, manager.GetRouteConfig())
+	}
+}
+
+func fetchResource(t *testing.T, addr string, node *corev3.Node, typeURL, resourceName string, res proto.Message) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(
+		ctx,
+		addr,
+		grpc.WithTransportCredentials(
+			insecure.NewCredentials(),
+		),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	stream, err := discoveryv3.NewAggregatedDiscoveryServiceClient(conn).StreamAggregatedResources(ctx)
+	require.NoError(t, err)
+
+	err = stream.Send(
+		&discoveryv3.DiscoveryRequest{
+			Node:          node,
+			TypeUrl:       typeURL,
+			ResourceNames: []string{resourceName},
+		},
+	)
+	require.NoError(t, err)
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Len(t, resp.Resources, 1)
+
+	require.NoError(t, resp.Resources[0].UnmarshalTo(res))
+}
@@ -19,6 +19,12 @@ func DurationPtr(d time.Duration) *metav1.Duration {
 
 type BackendOption func(c *gtcv1alpha1.Backend)
 
+func WithBackendWeight(weight uint32) BackendOption {
+	return func(c *gtcv1alpha1.Backend) {
+		c.Weight = weight
+	}
+}
+
 func WithMaxRequests(req uint32) BackendOption {
 	return func(c *gtcv1alpha1.Backend) {
 		c.MaxRequests = &req
@@ -31,6 +37,18 @@ func WithServiceRef(s gtcv1alpha1.ServiceRef) BackendOption {
 	}
 }
 
+func WithPodsRef(p gtcv1alpha1.PodsRef) BackendOption {
+	return func(c *gtcv1alpha1.Backend) {
+		c.Pods = &p
+	}
+}
+
+func WithServiceImportRef(s gtcv1alpha1.ServiceImportRef) BackendOption {
+	return func(c *gtcv1alpha1.Backend) {
+		c.ServiceImport = &s
+	}
+}
+
 func WithLocalities(l ...gtcv1alpha1.Locality) BackendOption {
 	return func(c *gtcv1alpha1.Backend) {
 		c.Localities = l
@@ -55,6 +73,24 @@ func WithBackendLBPolicy(p string) BackendOption {
 	}
 }
 
+func WithBackendSubset(matchLabels map[string]string) BackendOption {
+	return func(c *gtcv1alpha1.Backend) {
+		c.Subset = &metav1.LabelSelector{MatchLabels: matchLabels}
+	}
+}
+
+func WithBackendSameNode(fallback string) BackendOption {
+	return func(c *gtcv1alpha1.Backend) {
+		c.SameNode = &gtcv1alpha1.SameNodePolicy{Fallback: fallback}
+	}
+}
+
+func WithBackendPublishNotReady() BackendOption {
+	return func(c *gtcv1alpha1.Backend) {
+		c.PublishNotReady = true
+	}
+}
+
 func BuildBackend(opts ...BackendOption) gtcv1alpha1.Backend {
 	c := gtcv1alpha1.Backend{Weight: 1}
 
@@ -142,6 +178,18 @@ func WithMethodMatcher(namespace, service, method string) RouteMatcherOption {
 	}
 }
 
+func WithMethodsMatcher(methods ...gtcv1alpha1.MethodMatcher) RouteMatcherOption {
+	return func(m *gtcv1alpha1.RouteMatcher) {
+		m.Methods = methods
+	}
+}
+
+func WithServicesMatcher(services ...gtcv1alpha1.ServiceMatcher) RouteMatcherOption {
+	return func(m *gtcv1alpha1.RouteMatcher) {
+		m.Services = services
+	}
+}
+
 func WithFractionMatcher(fr gtcv1alpha1.Fraction) RouteMatcherOption {
 	return func(r *gtcv1alpha1.RouteMatcher) {
 		r.Fraction = &fr
@@ -163,6 +211,12 @@ func WithNamespaceMatcher(namespace string) RouteMatcherOption {
 	}
 }
 
+func WithPathMatcher(pm gtcv1alpha1.PathMatcher) RouteMatcherOption {
+	return func(m *gtcv1alpha1.RouteMatcher) {
+		m.Path = &pm
+	}
+}
+
 func WithMetadataMatchers(mms ...gtcv1alpha1.MetadataMatcher) RouteMatcherOption {
 	return func(m *gtcv1alpha1.RouteMatcher) {
 		m.Metadata = mms
@@ -187,6 +241,12 @@ func WithRouteMatcher(m gtcv1alpha1.RouteMatcher) RouteOption {
 	}
 }
 
+func WithRouteMatchers(ms ...gtcv1alpha1.RouteMatcher) RouteOption {
+	return func(r *gtcv1alpha1.Route) {
+		r.Matchers = ms
+	}
+}
+
 func WithRouteMaxStreamDuration(d time.Duration) RouteOption {
 	return func(r *gtcv1alpha1.Route) {
 		r.MaxStreamDuration = &metav1.Duration{Duration: d}
@@ -199,6 +259,12 @@ func WithBackends(backends ...gtcv1alpha1.Backend) RouteOption {
 	}
 }
 
+func WithRouteLookup(l gtcv1alpha1.RouteLookup) RouteOption {
+	return func(r *gtcv1alpha1.Route) {
+		r.RouteLookup = &l
+	}
+}
+
 func WithRouteInterceptorOverrides(overrides ...gtcv1alpha1.Interceptor) RouteOption {
 	return func(r *gtcv1alpha1.Route) {
 		r.Interceptors = overrides
@@ -217,6 +283,12 @@ func WithRouteHashPolicy(ps ...gtcv1alpha1.HashPolicy) RouteOption {
 	}
 }
 
+func WithRouteNodeSelector(selector gtcv1alpha1.NodeSelector) RouteOption {
+	return func(r *gtcv1alpha1.Route) {
+		r.NodeSelector = &selector
+	}
+}
+
 func BuildRoute(opts ...RouteOption) gtcv1alpha1.Route {
 	r := gtcv1alpha1.Route{}
 
@@ -235,12 +307,37 @@ func WithInterceptors(fs ...gtcv1alpha1.Interceptor) ListenerOption {
 	}
 }
 
+func WithAnnotations(annotations map[string]string) ListenerOption {
+	return func(s *gtcv1alpha1.GRPCListener) {
+		s.Annotations = annotations
+	}
+}
+
 func WithRoutes(rs ...gtcv1alpha1.Route) ListenerOption {
 	return func(s *gtcv1alpha1.GRPCListener) {
 		s.Spec.Routes = rs
 	}
 }
 
+func WithAliases(aliases ...string) ListenerOption {
+	return func(s *gtcv1alpha1.GRPCListener) {
+		s.Spec.Aliases = aliases
+	}
+}
+
+func WithVirtualHosts(vhosts ...gtcv1alpha1.VirtualHost) ListenerOption {
+	return func(s *gtcv1alpha1.GRPCListener) {
+		s.Spec.VirtualHosts = vhosts
+	}
+}
+
+func BuildVirtualHost(names []string, routes ...gtcv1alpha1.Route) gtcv1alpha1.VirtualHost {
+	return gtcv1alpha1.VirtualHost{
+		Names:  names,
+		Routes: routes,
+	}
+}
+
 func WithMaxStreamDuration(d time.Duration) ListenerOption {
 	return func(s *gtcv1alpha1.GRPCListener) {
 		s.Spec.MaxStreamDuration = &metav1.Duration{Duration: d}
@@ -267,3 +364,43 @@ func BuildGRPCListener(name, namespace string, opts ...ListenerOption) gtcv1alph
 
 	return s
 }
+
+// BuildReferenceGrant allows listeners of the given namespaces to reference the given services, or all services if none is given.
+func BuildReferenceGrant(name, namespace string, fromNamespaces []string, serviceNames ...string) gtcv1alpha1.ReferenceGrant {
+	g := gtcv1alpha1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	for _, ns := range fromNamespaces {
+		g.Spec.From = append(g.Spec.From, gtcv1alpha1.ReferenceGrantFrom{Namespace: ns})
+	}
+
+	if len(serviceNames) == 0 {
+		g.Spec.To = []gtcv1alpha1.ReferenceGrantTo{{}}
+	}
+
+	for _, name := range serviceNames {
+		g.Spec.To = append(g.Spec.To, gtcv1alpha1.ReferenceGrantTo{Name: name})
+	}
+
+	return g
+}
+
+// BuildFaultExperiment injects the given fault in the target from the start time, for the given duration.
+func BuildFaultExperiment(name, namespace string, target gtcv1alpha1.FaultExperimentTarget, start time.Time, duration time.Duration, fault gtcv1alpha1.FaultInterceptor) gtcv1alpha1.FaultExperiment {
+	return gtcv1alpha1.FaultExperiment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: gtcv1alpha1.FaultExperimentSpec{
+			Target:    target,
+			StartTime: &metav1.Time{Time: start},
+			Duration:  metav1.Duration{Duration: duration},
+			Fault:     fault,
+		},
+	}
+}
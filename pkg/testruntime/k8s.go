@@ -10,11 +10,14 @@ import (
 	"testing"
 	"time"
 
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	kubeinformers "k8s.io/client-go/informers"
 	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 
 	gtcv1alpha1 "github.com/jlevesy/grpc-traffic-controller/api/gtc/v1alpha1"
 	gtcfake "github.com/jlevesy/grpc-traffic-controller/client/clientset/versioned/fake"
@@ -28,6 +31,15 @@ type FakeK8s struct {
 
 	GTCApi       *gtcfake.Clientset
 	GTCInformers gtcinformers.SharedInformerFactory
+
+	// RemoteClusters are the fake remote clusters, by name.
+	RemoteClusters map[string]FakeCluster
+}
+
+// FakeCluster is a fake remote kubernetes cluster.
+type FakeCluster struct {
+	K8s          *kubefake.Clientset
+	K8sInformers kubeinformers.SharedInformerFactory
 }
 
 func NewFakeK8s(t *testing.T, listeners []gtcv1alpha1.GRPCListener, endpointSlices []discoveryv1.EndpointSlice) FakeK8s {
@@ -47,11 +59,40 @@ func NewFakeK8s(t *testing.T, listeners []gtcv1alpha1.GRPCListener, endpointSlic
 	)
 
 	return FakeK8s{
-		K8s:          k8sClientSet,
-		K8sInformers: k8sInformers,
-		GTCApi:       gtcClientSet,
-		GTCInformers: gtcInformers,
+		K8s:            k8sClientSet,
+		K8sInformers:   k8sInformers,
+		GTCApi:         gtcClientSet,
+		GTCInformers:   gtcInformers,
+		RemoteClusters: make(map[string]FakeCluster),
+	}
+}
+
+// AddRemoteCluster adds a fake remote cluster serving the given endpoint slices.
+func (f *FakeK8s) AddRemoteCluster(name string, endpointSlices []discoveryv1.EndpointSlice) FakeCluster {
+	k8sClientSet := kubefake.NewSimpleClientset(endpointSlicesToRuntimeObjects(endpointSlices)...)
+
+	cluster := FakeCluster{
+		K8s: k8sClientSet,
+		K8sInformers: kubeinformers.NewSharedInformerFactory(
+			k8sClientSet,
+			60*time.Second,
+		),
+	}
+
+	f.RemoteClusters[name] = cluster
+
+	return cluster
+}
+
+// RemoteK8sInformers returns the informers of the fake remote clusters, by name.
+func (f *FakeK8s) RemoteK8sInformers() map[string]kubeinformers.SharedInformerFactory {
+	informers := make(map[string]kubeinformers.SharedInformerFactory, len(f.RemoteClusters))
+
+	for name, cluster := range f.RemoteClusters {
+		informers[name] = cluster.K8sInformers
 	}
+
+	return informers
 }
 
 func (f *FakeK8s) Start(ctx context.Context, t *testing.T) {
@@ -65,6 +106,150 @@ func (f *FakeK8s) Start(ctx context.Context, t *testing.T) {
 
 	err = checkInformerSync(f.GTCInformers.WaitForCacheSync(ctx.Done()))
 	require.NoError(t, err)
+
+	for _, cluster := range f.RemoteClusters {
+		cluster.K8sInformers.Start(ctx.Done())
+
+		err = checkInformerSync(cluster.K8sInformers.WaitForCacheSync(ctx.Done()))
+		require.NoError(t, err)
+	}
+}
+
+// AcceptTokens makes the fake TokenReview API authenticate the given tokens as the associated usernames.
+// Any other token is rejected.
+func (f *FakeK8s) AcceptTokens(usernamesByToken map[string]string) {
+	f.K8s.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview).DeepCopy()
+
+		username, ok := usernamesByToken[review.Spec.Token]
+		if !ok {
+			review.Status.Error = "unknown token"
+			return true, review, nil
+		}
+
+		review.Status.Authenticated = true
+		review.Status.User.Username = username
+
+		return true, review, nil
+	})
+}
+
+// CreateReferenceGrants creates the given ReferenceGrants using the fake gTC API.
+func (f *FakeK8s) CreateReferenceGrants(t *testing.T, grants ...gtcv1alpha1.ReferenceGrant) {
+	t.Helper()
+
+	for _, g := range grants {
+		g := g
+
+		_, err := f.GTCApi.ApiV1alpha1().ReferenceGrants(g.Namespace).Create(context.Background(), &g, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+}
+
+// CreatePods creates the given pods using the fake k8s API.
+func (f *FakeK8s) CreatePods(t *testing.T, pods ...corev1.Pod) {
+	t.Helper()
+
+	for _, p := range pods {
+		p := p
+
+		_, err := f.K8s.CoreV1().Pods(p.Namespace).Create(context.Background(), &p, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+}
+
+// CreateNodes creates the given nodes using the fake k8s API.
+func (f *FakeK8s) CreateNodes(t *testing.T, nodes ...corev1.Node) {
+	t.Helper()
+
+	for _, n := range nodes {
+		n := n
+
+		_, err := f.K8s.CoreV1().Nodes().Create(context.Background(), &n, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+}
+
+type PodOption func(*corev1.Pod)
+
+// WithPodBackend makes the pod a ready pod serving the given backend through its grpc container port.
+func WithPodBackend(backend Backend) PodOption {
+	return func(p *corev1.Pod) {
+		p.Spec.Containers = []corev1.Container{
+			{
+				Name: "server",
+				Ports: []corev1.ContainerPort{
+					{
+						Name:          "grpc",
+						ContainerPort: backend.PortNumber(),
+					},
+				},
+			},
+		}
+		p.Status = corev1.PodStatus{
+			Phase:  corev1.PodRunning,
+			PodIP:  "127.0.0.1",
+			PodIPs: []corev1.PodIP{{IP: "127.0.0.1"}},
+			Conditions: []corev1.PodCondition{
+				{
+					Type:   corev1.PodReady,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		}
+	}
+}
+
+func WithPodAnnotations(annotations map[string]string) PodOption {
+	return func(p *corev1.Pod) {
+		p.Annotations = annotations
+	}
+}
+
+func WithPodNode(nodeName string) PodOption {
+	return func(p *corev1.Pod) {
+		p.Spec.NodeName = nodeName
+	}
+}
+
+func BuildPod(name, namespace string, labels map[string]string, opts ...PodOption) corev1.Pod {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&pod)
+	}
+
+	return pod
+}
+
+func BuildNode(name, region, zone string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				corev1.LabelTopologyRegion: region,
+				corev1.LabelTopologyZone:   zone,
+			},
+		},
+	}
+}
+
+// CreateFaultExperiments creates the given FaultExperiments using the fake gTC API.
+func (f *FakeK8s) CreateFaultExperiments(t *testing.T, experiments ...gtcv1alpha1.FaultExperiment) {
+	t.Helper()
+
+	for _, e := range experiments {
+		e := e
+
+		_, err := f.GTCApi.ApiV1alpha1().FaultExperiments(e.Namespace).Create(context.Background(), &e, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
 }
 
 func checkInformerSync(syncResult map[reflect.Type]bool) error {
@@ -109,6 +294,52 @@ func WithEndpointZone(zone string) EndpointSliceOption {
 	}
 }
 
+func WithEndpointNodeName(nodeName string) EndpointSliceOption {
+	return func(s *discoveryv1.EndpointSlice) {
+		s.Endpoints[0].NodeName = &nodeName
+	}
+}
+
+func WithEndpointTargetRef(podName string) EndpointSliceOption {
+	return func(s *discoveryv1.EndpointSlice) {
+		s.Endpoints[0].TargetRef = &corev1.ObjectReference{
+			Kind:      "Pod",
+			Name:      podName,
+			Namespace: s.Namespace,
+		}
+	}
+}
+
+func WithEndpointConditions(ready, serving, terminating bool) EndpointSliceOption {
+	return func(s *discoveryv1.EndpointSlice) {
+		s.Endpoints[0].Conditions = discoveryv1.EndpointConditions{
+			Ready:       &ready,
+			Serving:     &serving,
+			Terminating: &terminating,
+		}
+	}
+}
+
+// WithEndpointAddresses replaces the addresses of the endpoint of the slice, of the given address type.
+func WithEndpointAddresses(addressType discoveryv1.AddressType, addrs ...string) EndpointSliceOption {
+	return func(s *discoveryv1.EndpointSlice) {
+		s.AddressType = addressType
+		s.Endpoints[0].Addresses = addrs
+	}
+}
+
+// WithEndpointSourceCluster makes the slice an EndpointSlice of the ServiceImport of the same name, coming from the given cluster.
+func WithEndpointSourceCluster(cluster string) EndpointSliceOption {
+	return func(s *discoveryv1.EndpointSlice) {
+		serviceName := s.Labels[discoveryv1.LabelServiceName]
+
+		s.Labels = map[string]string{
+			"multicluster.kubernetes.io/service-name":   serviceName,
+			"multicluster.kubernetes.io/source-cluster": cluster,
+		}
+	}
+}
+
 func WithEndpointHints(hints discoveryv1.EndpointHints) EndpointSliceOption {
 	return func(s *discoveryv1.EndpointSlice) {
 		s.Endpoints[0].Hints = &hints
@@ -127,6 +358,7 @@ func BuildEndpointSlice(id int, name, namespace string, backend Backend, opts ..
 				"kubernetes.io/service-name": name,
 			},
 		},
+		AddressType: discoveryv1.AddressTypeIPv4,
 		Ports: []discoveryv1.EndpointPort{
 			{
 				Port: Ptr(int32(pp)),
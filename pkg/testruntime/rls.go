@@ -0,0 +1,127 @@
+package testruntime
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	// Registers the RLS LB policy for test clients, as well as the grpc.lookup.v1 protos, which grpc-go doesn't expose.
+	_ "google.golang.org/grpc/balancer/rls"
+)
+
+// RouteLookupFunc returns the targets of a lookup request, given its keys.
+// Returning no target fails the lookup.
+type RouteLookupFunc func(keys map[string]string) []string
+
+// FakeRouteLookupService is a local Route Lookup Service answering lookup requests with a RouteLookupFunc.
+type FakeRouteLookupService struct {
+	Listener net.Listener
+	Server   *grpc.Server
+
+	lookup RouteLookupFunc
+}
+
+func StartFakeRouteLookupService(lookup RouteLookupFunc) (*FakeRouteLookupService, error) {
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &FakeRouteLookupService{
+		Listener: listener,
+		Server:   grpc.NewServer(grpc.Creds(insecure.NewCredentials())),
+		lookup:   lookup,
+	}
+
+	s.Server.RegisterService(&routeLookupServiceDesc, s)
+
+	go func() {
+		_ = s.Server.Serve(listener)
+	}()
+
+	return s, nil
+}
+
+// Target returns the gRPC target URI of the service.
+func (s *FakeRouteLookupService) Target() string {
+	return "dns:///localhost:" + strconv.Itoa(s.Listener.Addr().(*net.TCPAddr).Port)
+}
+
+// Stop stops the server, closing its listener.
+func (s *FakeRouteLookupService) Stop() {
+	s.Server.Stop()
+}
+
+var routeLookupServiceDesc = grpc.ServiceDesc{
+	ServiceName: "grpc.lookup.v1.RouteLookupService",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RouteLookup",
+			Handler:    routeLookupHandler,
+		},
+	},
+}
+
+// routeLookupHandler handles the lookup requests using the JSON form of the grpc.lookup.v1 protos.
+func routeLookupHandler(srv any, _ context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+	req, err := newLookupMessage("grpc.lookup.v1.RouteLookupRequest")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+
+	rawReq, err := protojson.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var lookupReq struct {
+		KeyMap map[string]string `json:"keyMap"`
+	}
+
+	if err := json.Unmarshal(rawReq, &lookupReq); err != nil {
+		return nil, err
+	}
+
+	targets := srv.(*FakeRouteLookupService).lookup(lookupReq.KeyMap)
+	if len(targets) == 0 {
+		return nil, status.Error(codes.NotFound, "no target found")
+	}
+
+	rawResp, err := json.Marshal(struct {
+		Targets []string `json:"targets"`
+	}{Targets: targets})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := newLookupMessage("grpc.lookup.v1.RouteLookupResponse")
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, protojson.Unmarshal(rawResp, resp)
+}
+
+func newLookupMessage(name protoreflect.FullName) (proto.Message, error) {
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return messageType.New().Interface(), nil
+}
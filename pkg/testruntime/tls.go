@@ -0,0 +1,150 @@
+package testruntime
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type TLSFiles struct {
+	CAFile         string
+	ServerCertFile string
+	ServerKeyFile  string
+	ClientCertFile string
+	ClientKeyFile  string
+}
+
+// ServerConfig returns a server TLS config verifying client certificates if given.
+func (f TLSFiles) ServerConfig(t *testing.T) *tls.Config {
+	t.Helper()
+
+	cert, err := tls.LoadX509KeyPair(f.ServerCertFile, f.ServerKeyFile)
+	require.NoError(t, err)
+
+	caPEM, err := os.ReadFile(f.CAFile)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(caPEM))
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+	}
+}
+
+// ClientConfig returns a client TLS config presenting the client certificate.
+func (f TLSFiles) ClientConfig(t *testing.T) *tls.Config {
+	t.Helper()
+
+	cert, err := tls.LoadX509KeyPair(f.ClientCertFile, f.ClientKeyFile)
+	require.NoError(t, err)
+
+	caPEM, err := os.ReadFile(f.CAFile)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(caPEM))
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+	}
+}
+
+// GenerateTLSFiles writes a CA, a server certificate valid for localhost and
+// a client certificate carrying the given SPIFFE ID in a temporary directory.
+func GenerateTLSFiles(t *testing.T, clientSPIFFEID string) TLSFiles {
+	t.Helper()
+
+	var (
+		dir   = t.TempDir()
+		files = TLSFiles{
+			CAFile:         filepath.Join(dir, "ca.crt"),
+			ServerCertFile: filepath.Join(dir, "server.crt"),
+			ServerKeyFile:  filepath.Join(dir, "server.key"),
+			ClientCertFile: filepath.Join(dir, "client.crt"),
+			ClientKeyFile:  filepath.Join(dir, "client.key"),
+		}
+	)
+
+	caTemplate := certTemplate(1)
+	caTemplate.Subject.CommonName = "gtc-test-ca"
+	caTemplate.IsCA = true
+	caTemplate.BasicConstraintsValid = true
+	caTemplate.KeyUsage = x509.KeyUsageCertSign
+
+	caCert, caKey := writeCertificate(t, caTemplate, nil, nil, files.CAFile, "")
+
+	serverTemplate := certTemplate(2)
+	serverTemplate.Subject.CommonName = "localhost"
+	serverTemplate.DNSNames = []string{"localhost"}
+	serverTemplate.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+	serverTemplate.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+
+	writeCertificate(t, serverTemplate, caCert, caKey, files.ServerCertFile, files.ServerKeyFile)
+
+	spiffeID, err := url.Parse(clientSPIFFEID)
+	require.NoError(t, err)
+
+	clientTemplate := certTemplate(3)
+	clientTemplate.URIs = []*url.URL{spiffeID}
+	clientTemplate.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+
+	writeCertificate(t, clientTemplate, caCert, caKey, files.ClientCertFile, files.ClientKeyFile)
+
+	return files
+}
+
+func certTemplate(serial int64) *x509.Certificate {
+	return &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+}
+
+// writeCertificate signs the template with the parent, or self signs if parent is nil.
+func writeCertificate(t *testing.T, template, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, certFile, keyFile string) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)
+	require.NoError(t, err)
+
+	if keyFile != "" {
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+
+		err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600)
+		require.NoError(t, err)
+	}
+
+	return cert, key
+}